@@ -0,0 +1,89 @@
+// import загружает команды и пользователей из YAML или CSV файла.
+//
+// Пример:
+//
+//	DB_DSN=... go run ./cmd/import -file roster.yaml -dry-run
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/qwerty268/pull_request_service/internal/events"
+	teamUsecase "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	teamStorage "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
+)
+
+func main() {
+	file := flag.String("file", "", "path to roster file")
+	format := flag.String("format", "", "roster format: csv or yaml (default: by file extension)")
+	dryRun := flag.Bool("dry-run", false, "print the diff against the database without writing")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*file), ".")
+	}
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		log.Fatal("DB_DSN is empty")
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		log.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("failed to open roster: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := teamUsecase.ParseRoster(*format, f)
+	if err != nil {
+		log.Fatalf("failed to parse roster: %v", err)
+	}
+
+	usecase := teamUsecase.NewUsecase(teamStorage.NewStorage(db), events.Discard)
+	result, err := usecase.ImportRoster(context.Background(), rows, *dryRun)
+	if err != nil && !errors.Is(err, teamUsecase.ErrInvalidRoster) {
+		log.Fatalf("failed to import roster: %v", err)
+	}
+
+	for _, change := range result.Changes {
+		line := fmt.Sprintf("%-12s team=%s", change.Kind, change.TeamName)
+		if change.UserID != "" {
+			line += " user=" + change.UserID
+		}
+		if change.Details != "" {
+			line += " (" + change.Details + ")"
+		}
+		fmt.Println(line)
+	}
+	for _, rowErr := range result.Errors {
+		fmt.Fprintf(os.Stderr, "error: %s: %s\n", rowErr.Location, rowErr.Message)
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		fmt.Fprintf(os.Stderr, "%d errors, nothing imported\n", len(result.Errors))
+		os.Exit(1)
+	case result.Applied:
+		fmt.Printf("imported, %d changes\n", len(result.Changes))
+	default:
+		fmt.Printf("dry run, %d changes\n", len(result.Changes))
+	}
+}
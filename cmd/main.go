@@ -1,21 +1,42 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
 
-	prHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/pullrequests"
-	teamsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/teams"
+	"github.com/qwerty268/pull_request_service/internal/events"
+	grpcapi "github.com/qwerty268/pull_request_service/internal/grpc_api"
+	"github.com/qwerty268/pull_request_service/internal/metrics"
+	"github.com/qwerty268/pull_request_service/internal/openapi"
+	"github.com/qwerty268/pull_request_service/internal/rest_api/routes"
 	userHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/users"
+	webhooksHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/webhooks"
+	graphUsecase "github.com/qwerty268/pull_request_service/internal/usecases/graph"
+	graphStorage "github.com/qwerty268/pull_request_service/internal/usecases/graph/storage"
 	prUsecase "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
 	prStorage "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/storage"
+	scimUsecase "github.com/qwerty268/pull_request_service/internal/usecases/scim"
+	statsUsecase "github.com/qwerty268/pull_request_service/internal/usecases/stats"
+	statsStorage "github.com/qwerty268/pull_request_service/internal/usecases/stats/storage"
 	teamUsecase "github.com/qwerty268/pull_request_service/internal/usecases/teams"
 	teamStorage "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
 	userUsecase "github.com/qwerty268/pull_request_service/internal/usecases/users"
 	userStorage "github.com/qwerty268/pull_request_service/internal/usecases/users/storage"
+	webhooksUsecase "github.com/qwerty268/pull_request_service/internal/usecases/webhooks"
+	webhooksStorage "github.com/qwerty268/pull_request_service/internal/usecases/webhooks/storage"
 	"github.com/qwerty268/pull_request_service/internal/utils"
 )
 
@@ -48,25 +69,157 @@ func main() {
 	prStorage := prStorage.NewStorage(db)
 	teamStorage := teamStorage.NewStorage(db)
 	userStorage := userStorage.NewStorage(db)
+	statsStorage := statsStorage.NewStorage(db)
+	graphStorage := graphStorage.NewStorage(db)
+	webhooksStorage := webhooksStorage.NewStorage(db)
 
-	prUsecase := prUsecase.NewUsecase(prStorage, teamStorage, userStorage)
-	teamUsecase := teamUsecase.NewUsecase(teamStorage)
-	userUsecase := userUsecase.NewUsecase(userStorage, prStorage)
+	eventBroker := events.NewBroker(events.DefaultHistorySize)
 
-	prHandlers := prHandlers.NewHandlers(prUsecase)
-	teamsHandlers := teamsHandlers.NewHandlers(teamUsecase)
-	userHandlers := userHandlers.NewUserHandlers(userUsecase)
+	teamUsecase := teamUsecase.NewUsecase(teamStorage, eventBroker)
+	prUsecase := prUsecase.NewUsecase(prStorage, teamStorage, userStorage, teamUsecase, eventBroker)
+	userUsecase := userUsecase.NewUsecase(userStorage, prStorage, eventBroker)
+	scimUsecase := scimUsecase.NewUsecase(userStorage, teamStorage, userUsecase, eventBroker)
+	statsUsecase := statsUsecase.NewUsecase(statsStorage)
+	graphUsecase := graphUsecase.NewUsecase(graphStorage)
+	webhooksUsecase := webhooksUsecase.NewUsecase(webhooksStorage, prUsecase)
+
+	// SCIM_TOKEN - bearer-токен IdP, без него эндпоинты SCIM не монтируются.
+	scimToken := os.Getenv("SCIM_TOKEN")
+	if scimToken == "" {
+		log.Print("SCIM_TOKEN is empty, SCIM endpoints are disabled")
+	}
+
+	// ADMIN_TOKENS - токены администраторов вида name:token через запятую, без них удаление пользователей не монтируется.
+	adminTokens, err := userHandlers.ParseAdminTokens(os.Getenv("ADMIN_TOKENS"))
+	if err != nil {
+		log.Fatalf("invalid ADMIN_TOKENS: %v", err)
+	}
+	if len(adminTokens) == 0 {
+		log.Print("ADMIN_TOKENS is empty, user delete and erase endpoints are disabled")
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Fatalf("failed to load openapi spec: %v", err)
+	}
+	// OPENAPI_ENFORCE_RESPONSES=false отключает замену ответов не по спецификации на 500, по умолчанию включена.
+	enforceResponses := true
+	if v := os.Getenv("OPENAPI_ENFORCE_RESPONSES"); v != "" {
+		enforceResponses, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Fatalf("invalid OPENAPI_ENFORCE_RESPONSES: %v", err)
+		}
+	}
+	specValidator, err := openapi.Middleware(spec, enforceResponses)
+	if err != nil {
+		log.Fatalf("failed to build openapi validator: %v", err)
+	}
 
 	e := echo.New()
 	e.Validator = utils.NewHTTPRequestValidator()
+	e.HTTPErrorHandler = utils.HTTPErrorHandler
+	e.Use(metrics.Middleware())
+	e.Use(specValidator)
 
-	prHandlers.RegisterHandlers(e)
-	teamsHandlers.RegisterHandlers(e)
-	userHandlers.RegisterHandlers(e)
+	// Потоки событий не завершаются сами, поэтому закрываем их в начале Shutdown, а не по таймауту.
+	eventsShutdown := make(chan struct{})
+	e.Server.RegisterOnShutdown(func() { close(eventsShutdown) })
+
+	routes.Register(e, routes.Deps{
+		PullRequests:   prUsecase,
+		Teams:          teamUsecase,
+		Users:          userUsecase,
+		AdminTokens:    adminTokens,
+		Stats:          statsUsecase,
+		Graph:          graphUsecase,
+		Events:         eventBroker,
+		EventsShutdown: eventsShutdown,
+		SCIM:           scimUsecase,
+		SCIMToken:      scimToken,
+		Webhooks:       webhooksUsecase,
+		// GITHUB_WEBHOOK_SECRET и GITLAB_WEBHOOK_TOKEN - секреты вебхуков, без них доставки отклоняются.
+		WebhooksConfig: webhooksHandlers.Config{
+			GitHubSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+			GitLabToken:  os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+		},
+		Spec: spec,
+	})
+
+	go metrics.RunTeamGauges(context.Background(), metrics.DefaultRefreshInterval,
+		func(ctx context.Context) ([]metrics.TeamActivity, error) {
+			activity, err := statsUsecase.GetTeamActivity(ctx)
+			if err != nil {
+				return nil, err
+			}
+			gauges := make([]metrics.TeamActivity, len(activity))
+			for i, v := range activity {
+				gauges[i] = metrics.TeamActivity(v)
+			}
+			return gauges, nil
+		},
+	)
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("failed to listen grpc port: %v", err)
+	}
+	grpcServer := grpcapi.NewServer(prUsecase, teamUsecase, userUsecase)
 
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
-	e.Logger.Fatal(e.Start(":" + port))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Оба сервера останавливаются вместе: по сигналу или если один из них упал.
+	serveErrs := make(chan error, 2)
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			serveErrs <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+	go func() {
+		if err := e.Start(":" + port); !errors.Is(err, http.ErrServerClosed) {
+			serveErrs <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Print("shutting down")
+	case err := <-serveErrs:
+		log.Printf("%v, shutting down", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("failed to shut down http server: %v", err)
+	}
+	stopGRPC(shutdownCtx, grpcServer)
+}
+
+// shutdownTimeout - сколько ждем завершения текущих запросов при остановке.
+const shutdownTimeout = 15 * time.Second
+
+// stopGRPC дожидается завершения текущих вызовов, а по истечении ctx обрывает их.
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Print("grpc graceful stop timed out, closing connections")
+		s.Stop()
+	}
 }
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qwerty268/pull_request_service/pkg/client"
+)
+
+type commands struct {
+	client *client.Client
+	out    printer
+}
+
+func (c *commands) dispatch(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("command is required")
+	}
+	switch args[0] {
+	case "team":
+		return c.team(ctx, args[1:])
+	case "user":
+		return c.user(ctx, args[1:])
+	case "pr":
+		return c.pr(ctx, args[1:])
+	case "stats":
+		return c.stats(ctx, args[1:])
+	case "export":
+		return c.export(ctx, args[1:])
+	}
+	return usageError("unknown command %q", args[0])
+}
+
+// parseArgs разбирает флаги подкоманды в любом месте среди позиционных аргументов и проверяет их число.
+func parseArgs(fs *flag.FlagSet, args []string, positional ...string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var values []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, usageError("%s: %v", fs.Name(), err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		values = append(values, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(values) != len(positional) {
+		if len(positional) == 0 {
+			return nil, usageError("%s: unexpected arguments %v", fs.Name(), values)
+		}
+		return nil, usageError("%s: expected <%s>", fs.Name(), strings.Join(positional, "> <"))
+	}
+	return values, nil
+}
+
+func (c *commands) team(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("team: subcommand is required")
+	}
+	switch args[0] {
+	case "list":
+		return c.teamList(ctx, args[1:])
+	case "show":
+		return c.teamShow(ctx, args[1:])
+	case "add":
+		return c.teamAdd(ctx, args[1:])
+	}
+	return usageError("team: unknown subcommand %q", args[0])
+}
+
+func (c *commands) teamList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("team list", flag.ContinueOnError)
+	prefix := fs.String("prefix", "", "team name prefix")
+	limit := fs.Int("limit", 0, "page size")
+	offset := fs.Int("offset", 0, "page offset")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	resp, err := c.client.ListTeams(ctx, client.ListTeamsRequest{NamePrefix: *prefix, Limit: *limit, Offset: *offset})
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, len(resp.Teams))
+	for i, v := range resp.Teams {
+		rows[i] = []string{v.TeamName, strconv.Itoa(v.MembersCount), strconv.Itoa(v.ActiveMembersCount), strconv.Itoa(v.OpenPRsCount)}
+	}
+	return c.out.print(resp, []string{"TEAM", "MEMBERS", "ACTIVE", "OPEN PRS"}, rows)
+}
+
+func (c *commands) teamShow(ctx context.Context, args []string) error {
+	values, err := parseArgs(flag.NewFlagSet("team show", flag.ContinueOnError), args, "team_name")
+	if err != nil {
+		return err
+	}
+
+	team, err := c.client.GetTeam(ctx, values[0])
+	if err != nil {
+		return err
+	}
+	return c.out.print(team, memberHeader, memberRows(team.Members))
+}
+
+var memberHeader = []string{"USER ID", "USERNAME", "ACTIVE", "ROLE"}
+
+func memberRows(members []client.TeamMemberResponse) [][]string {
+	rows := make([][]string, len(members))
+	for i, v := range members {
+		rows[i] = []string{v.UserID, v.Username, formatBool(v.IsActive), v.Role}
+	}
+	return rows
+}
+
+// memberFlags - участники команды в виде user_id:username[:role], все активны.
+type memberFlags []client.TeamMemberRequest
+
+func (m *memberFlags) String() string {
+	return fmt.Sprint(*m)
+}
+
+func (m *memberFlags) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("member %q must be user_id:username[:role]", value)
+	}
+	active := true
+	member := client.TeamMemberRequest{UserID: parts[0], Username: parts[1], IsActive: &active}
+	if len(parts) == 3 {
+		member.Role = parts[2]
+	}
+	*m = append(*m, member)
+	return nil
+}
+
+func (c *commands) teamAdd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("team add", flag.ContinueOnError)
+	var members memberFlags
+	fs.Var(&members, "member", "team member as user_id:username[:role], repeatable")
+	values, err := parseArgs(fs, args, "team_name")
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return usageError("team add: at least one -member is required")
+	}
+
+	if _, err := c.client.AddTeam(ctx, client.AddTeamRequest{TeamName: values[0], Members: members}); err != nil {
+		return err
+	}
+	// Ответ на создание повторяет запрос, роли по умолчанию показывает только чтение команды.
+	team, err := c.client.GetTeam(ctx, values[0])
+	if err != nil {
+		return err
+	}
+	return c.out.print(team, memberHeader, memberRows(team.Members))
+}
+
+func (c *commands) user(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("user: subcommand is required")
+	}
+	var active bool
+	switch args[0] {
+	case "activate":
+		active = true
+	case "deactivate":
+		active = false
+	default:
+		return usageError("user: unknown subcommand %q", args[0])
+	}
+	values, err := parseArgs(flag.NewFlagSet("user "+args[0], flag.ContinueOnError), args[1:], "user_id")
+	if err != nil {
+		return err
+	}
+
+	user, err := c.client.SetUserActive(ctx, client.SetUserActiveRequest{UserID: values[0], IsActive: &active})
+	if err != nil {
+		return err
+	}
+	return c.out.print(user,
+		[]string{"USER ID", "USERNAME", "TEAM", "ACTIVE"},
+		[][]string{{user.UserID, user.Username, user.TeamName, formatBool(user.IsActive)}},
+	)
+}
+
+func (c *commands) pr(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("pr: subcommand is required")
+	}
+	switch args[0] {
+	case "show":
+		values, err := parseArgs(flag.NewFlagSet("pr show", flag.ContinueOnError), args[1:], "pull_request_id")
+		if err != nil {
+			return err
+		}
+		pr, err := c.client.GetPR(ctx, values[0])
+		if err != nil {
+			return err
+		}
+		return c.printPR(pr)
+	case "merge":
+		values, err := parseArgs(flag.NewFlagSet("pr merge", flag.ContinueOnError), args[1:], "pull_request_id")
+		if err != nil {
+			return err
+		}
+		pr, err := c.client.MergePR(ctx, client.MergePRRequest{PullRequestID: values[0]})
+		if err != nil {
+			return err
+		}
+		return c.printPR(pr)
+	case "reassign":
+		values, err := parseArgs(flag.NewFlagSet("pr reassign", flag.ContinueOnError), args[1:], "pull_request_id", "old_user_id")
+		if err != nil {
+			return err
+		}
+		resp, err := c.client.ReassignReviewer(ctx, client.ReassignReviewerRequest{PullRequestID: values[0], OldUserID: values[1]})
+		if err != nil {
+			return err
+		}
+		return c.out.print(resp,
+			[]string{"PR", "STATUS", "REVIEWERS", "REPLACED BY", "ESCALATED"},
+			[][]string{{resp.PR.PullRequestID, resp.PR.Status, formatList(resp.PR.AssignedReviewers), resp.ReplacedBy, formatBool(resp.Escalated)}},
+		)
+	}
+	return usageError("pr: unknown subcommand %q", args[0])
+}
+
+func (c *commands) printPR(pr *client.PullRequest) error {
+	return c.out.print(pr,
+		[]string{"PR", "NAME", "AUTHOR", "STATUS", "REVIEWERS", "CREATED", "MERGED"},
+		[][]string{{pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, formatList(pr.AssignedReviewers), formatTime(pr.CreatedAt), formatTime(pr.MergedAt)}},
+	)
+}
+
+// timeFlag - необязательная граница окна статистики в RFC 3339.
+type timeFlag struct {
+	time.Time
+}
+
+func (t *timeFlag) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (t *timeFlag) Set(value string) error {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return errors.New("must be RFC 3339, e.g. 2026-01-02T15:04:05Z")
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (c *commands) stats(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	by := fs.String("by", "teams", "group by teams or reviewers")
+	team := fs.String("team", "", "only this team")
+	var from, to timeFlag
+	fs.Var(&from, "from", "window start, RFC 3339 (default: 30 days ago)")
+	fs.Var(&to, "to", "window end, RFC 3339 (default: now)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	window := client.WindowRequest{From: from.Time, To: to.Time, TeamName: *team}
+
+	switch *by {
+	case "teams":
+		resp, err := c.client.GetTeamStats(ctx, window)
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, len(resp.Teams))
+		for i, v := range resp.Teams {
+			rows[i] = []string{v.TeamName, strconv.Itoa(v.MembersCount), strconv.Itoa(v.Assigned), strconv.Itoa(v.ReassignedAway), strconv.Itoa(v.Authored)}
+		}
+		return c.out.print(resp, []string{"TEAM", "MEMBERS", "ASSIGNED", "REASSIGNED AWAY", "AUTHORED"}, rows)
+	case "reviewers":
+		resp, err := c.client.GetReviewerStats(ctx, window)
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, len(resp.Reviewers))
+		for i, v := range resp.Reviewers {
+			rows[i] = []string{v.UserID, v.Username, v.TeamName, strconv.Itoa(v.Assigned), strconv.Itoa(v.ReassignedAway), strconv.Itoa(v.Authored)}
+		}
+		return c.out.print(resp, []string{"USER ID", "USERNAME", "TEAM", "ASSIGNED", "REASSIGNED AWAY", "AUTHORED"}, rows)
+	}
+	return usageError("stats: -by must be teams or reviewers")
+}
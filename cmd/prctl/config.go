@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultServer = "http://localhost:8080"
+
+type config struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+}
+
+// loadConfig читает файл конфигурации, переменные окружения важнее него. Файла по умолчанию может не быть,
+// а явно заданный через -config или PRCTL_CONFIG должен существовать.
+func loadConfig(path string, getenv func(string) string) (config, error) {
+	var cfg config
+
+	if path == "" {
+		path = getenv("PRCTL_CONFIG")
+	}
+	explicit := path != ""
+	if !explicit {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "prctl", "config.yaml")
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+			}
+		case errors.Is(err, fs.ErrNotExist) && !explicit:
+		default:
+			return cfg, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	if v := getenv("PRCTL_SERVER"); v != "" {
+		cfg.Server = v
+	}
+	if v := getenv("PRCTL_TOKEN"); v != "" {
+		cfg.Token = v
+	}
+	if cfg.Server == "" {
+		cfg.Server = defaultServer
+	}
+	return cfg, nil
+}
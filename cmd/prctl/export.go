@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/qwerty268/pull_request_service/pkg/client"
+)
+
+// exportPageSize - максимальный размер страницы /team/list.
+const exportPageSize = 100
+
+// rosterTeam - команда в формате YAML импорта (/team/import, cmd/import).
+type rosterTeam struct {
+	TeamName string         `yaml:"team_name"`
+	Members  []rosterMember `yaml:"members"`
+}
+
+type rosterMember struct {
+	UserID   string `yaml:"user_id"`
+	Username string `yaml:"username"`
+	IsActive bool   `yaml:"is_active"`
+	Role     string `yaml:"role"`
+}
+
+// export выгружает все команды в формате импорта, чтобы состав можно было поправить и загрузить обратно.
+func (c *commands) export(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "csv", "roster format: csv or yaml")
+	file := fs.String("file", "", "output file (default: stdout)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *format != "csv" && *format != "yaml" {
+		return usageError("export: -format must be csv or yaml")
+	}
+
+	teams, err := c.fetchTeams(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := c.out.w
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *file, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *format == "yaml" {
+		return writeRosterYAML(w, teams)
+	}
+	return writeRosterCSV(w, teams)
+}
+
+func (c *commands) fetchTeams(ctx context.Context) ([]*client.TeamResponse, error) {
+	var teams []*client.TeamResponse
+	for offset := 0; ; offset += exportPageSize {
+		page, err := c.client.ListTeams(ctx, client.ListTeamsRequest{Limit: exportPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range page.Teams {
+			team, err := c.client.GetTeam(ctx, summary.TeamName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get team %s: %w", summary.TeamName, err)
+			}
+			teams = append(teams, team)
+		}
+		if len(page.Teams) == 0 || offset+len(page.Teams) >= page.Total {
+			return teams, nil
+		}
+	}
+}
+
+func writeRosterCSV(w io.Writer, teams []*client.TeamResponse) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"team_name", "user_id", "username", "is_active", "role"}); err != nil {
+		return err
+	}
+	for _, team := range teams {
+		if len(team.Members) == 0 {
+			if err := cw.Write([]string{team.TeamName, "", "", "", ""}); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, m := range team.Members {
+			if err := cw.Write([]string{team.TeamName, m.UserID, m.Username, strconv.FormatBool(m.IsActive), m.Role}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeRosterYAML(w io.Writer, teams []*client.TeamResponse) error {
+	roster := struct {
+		Teams []rosterTeam `yaml:"teams"`
+	}{Teams: make([]rosterTeam, len(teams))}
+	for i, team := range teams {
+		roster.Teams[i] = rosterTeam{TeamName: team.TeamName, Members: make([]rosterMember, len(team.Members))}
+		for j, m := range team.Members {
+			roster.Teams[i].Members[j] = rosterMember(m)
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(roster); err != nil {
+		return err
+	}
+	return enc.Close()
+}
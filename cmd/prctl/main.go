@@ -0,0 +1,95 @@
+// prctl - консольная утилита для просмотра и правки состояния сервиса через HTTP API.
+//
+// Адрес сервиса и токен берутся из флагов, переменных PRCTL_SERVER и PRCTL_TOKEN
+// или файла конфигурации (по умолчанию <user config dir>/prctl/config.yaml).
+//
+// Пример:
+//
+//	PRCTL_SERVER=http://localhost:8080 go run ./cmd/prctl team show backend
+//	go run ./cmd/prctl -o json pr merge pr-1001
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/qwerty268/pull_request_service/pkg/client"
+)
+
+const usage = `usage: prctl [-server URL] [-token TOKEN] [-config FILE] [-o table|json] <command>
+
+commands:
+  team list [-prefix PREFIX] [-limit N] [-offset N]
+  team show <team_name>
+  team add <team_name> -member user_id:username[:role] [-member ...]
+  user activate <user_id>
+  user deactivate <user_id>
+  pr show <pull_request_id>
+  pr merge <pull_request_id>
+  pr reassign <pull_request_id> <old_user_id>
+  stats [-by teams|reviewers] [-team TEAM] [-from RFC3339] [-to RFC3339]
+  export [-format csv|yaml] [-file PATH]
+`
+
+var errUsage = errors.New("invalid usage")
+
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Getenv, os.Stdout)
+	if err == nil {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "error:", err)
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(os.Stderr, "\n"+usage)
+		os.Exit(2)
+	}
+	os.Exit(1)
+}
+
+func run(ctx context.Context, args []string, getenv func(string) string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	server := fs.String("server", "", "service URL")
+	token := fs.String("token", "", "bearer token")
+	configPath := fs.String("config", "", "config file")
+	output := fs.String("o", outputTable, "output format: table or json")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			_, err = fmt.Fprint(stdout, usage)
+			return err
+		}
+		return usageError("%v", err)
+	}
+	if *output != outputTable && *output != outputJSON {
+		return usageError("unknown output format %q", *output)
+	}
+
+	cfg, err := loadConfig(*configPath, getenv)
+	if err != nil {
+		return err
+	}
+	if *server != "" {
+		cfg.Server = *server
+	}
+	if *token != "" {
+		cfg.Token = *token
+	}
+
+	cmd := &commands{
+		client: client.New(cfg.Server, client.WithToken(cfg.Token)),
+		out:    printer{format: *output, w: stdout},
+	}
+	return cmd.dispatch(ctx, fs.Args())
+}
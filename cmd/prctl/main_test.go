@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/events"
+	prHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/pullrequests"
+	prMocks "github.com/qwerty268/pull_request_service/internal/rest_api/pullrequests/mocks"
+	teamsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/teams"
+	teamsMocks "github.com/qwerty268/pull_request_service/internal/rest_api/teams/mocks"
+	"github.com/qwerty268/pull_request_service/internal/rest_api/versions"
+	ucPR "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	ucTeams "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	teamsStorageMocks "github.com/qwerty268/pull_request_service/internal/usecases/teams/mocks"
+	teamsRepository "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+	"github.com/qwerty268/pull_request_service/pkg/client"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *prMocks.MockPRCreator, *teamsMocks.MockUsecase) {
+	ctrl := gomock.NewController(t)
+	prMock := prMocks.NewMockPRCreator(ctrl)
+	teamsMock := teamsMocks.NewMockUsecase(ctrl)
+
+	e := echo.New()
+	e.Validator = utils.NewHTTPRequestValidator()
+	e.HTTPErrorHandler = utils.HTTPErrorHandler
+	v1 := versions.Version{
+		Name:     "v1",
+		Handlers: []versions.Registrar{prHandlers.NewHandlers(prMock), teamsHandlers.NewHandlers(teamsMock)},
+	}
+	v1.Mount(e)
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv, prMock, teamsMock
+}
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+// runWithConfig запускает prctl с пустым файлом конфигурации, чтобы не читать настоящий.
+func runWithConfig(t *testing.T, getenv func(string) string, args ...string) (string, error) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(config, nil, 0o600))
+
+	var out bytes.Buffer
+	err := run(context.Background(), append([]string{"-config", config}, args...), getenv, &out)
+	return out.String(), err
+}
+
+func TestRun(t *testing.T) {
+	srv, prMock, teamsMock := newTestServer(t)
+	getenv := env(map[string]string{"PRCTL_SERVER": srv.URL})
+
+	t.Run("team show table", func(t *testing.T) {
+		teamsMock.EXPECT().
+			GetTeam(gomock.Any(), "backend").
+			Return(&ucTeams.Team{TeamName: "backend", Members: []ucTeams.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true, Role: "lead"},
+				{UserID: "u2", Username: "Bob", Role: "member"},
+			}}, nil)
+
+		out, err := runWithConfig(t, getenv, "team", "show", "backend")
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"USER ID  USERNAME  ACTIVE  ROLE\n"+
+			"u1       Alice     true    lead\n"+
+			"u2       Bob       false   member\n", out)
+	})
+
+	t.Run("pr merge json", func(t *testing.T) {
+		prMock.EXPECT().
+			MergePR(gomock.Any(), "pr1").
+			Return(&ucPR.PullRequest{PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "u2", Status: "MERGED"}, nil)
+
+		out, err := runWithConfig(t, getenv, "-o", "json", "pr", "merge", "pr1")
+		require.NoError(t, err)
+		assert.Contains(t, out, `"status": "MERGED"`)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		prMock.EXPECT().
+			ReassignReviewer(gomock.Any(), "pr1", "u2").
+			Return(nil, ucPR.ErrPRMerged)
+
+		_, err := runWithConfig(t, getenv, "pr", "reassign", "pr1", "u2")
+		require.ErrorIs(t, err, client.ErrPRMerged)
+	})
+
+	t.Run("export csv", func(t *testing.T) {
+		teamsMock.EXPECT().
+			ListTeams(gomock.Any(), gomock.Any()).
+			Return(&ucTeams.TeamsPage{Teams: []ucTeams.TeamSummary{{TeamName: "backend"}}, Total: 1}, nil)
+		teamsMock.EXPECT().
+			GetTeam(gomock.Any(), "backend").
+			Return(&ucTeams.Team{TeamName: "backend", Members: []ucTeams.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true, Role: "lead"}}}, nil)
+
+		out, err := runWithConfig(t, getenv, "export")
+		require.NoError(t, err)
+
+		rows, err := ucTeams.ParseRoster("csv", strings.NewReader(out))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "u1", rows[0].UserID)
+		assert.Equal(t, "lead", rows[0].Role)
+	})
+
+	t.Run("export round trip", func(t *testing.T) {
+		for _, format := range []string{"csv", "yaml"} {
+			teamsMock.EXPECT().
+				ListTeams(gomock.Any(), gomock.Any()).
+				Return(&ucTeams.TeamsPage{Teams: []ucTeams.TeamSummary{{TeamName: "backend"}, {TeamName: "infra"}}, Total: 2}, nil)
+			teamsMock.EXPECT().
+				GetTeam(gomock.Any(), "backend").
+				Return(&ucTeams.Team{TeamName: "backend", Members: []ucTeams.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true, Role: "lead"}}}, nil)
+			teamsMock.EXPECT().
+				GetTeam(gomock.Any(), "infra").
+				Return(&ucTeams.Team{TeamName: "infra", Members: []ucTeams.TeamMember{}}, nil)
+
+			out, err := runWithConfig(t, getenv, "export", "-format", format)
+			require.NoError(t, err)
+
+			// Выгрузка загружается обратно без ошибок, пустая команда остается пустой.
+			storage := teamsStorageMocks.NewMockstorage(gomock.NewController(t))
+			storage.EXPECT().
+				GetRosterState(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&teamsRepository.RosterState{ExistingTeams: []string{"backend", "infra"}}, nil)
+			storage.EXPECT().ImportTeams([]teamsRepository.Team{
+				{TeamName: "backend", Members: []teamsRepository.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true, Role: "lead"}}},
+				{TeamName: "infra", Members: []teamsRepository.TeamMember{}},
+			})
+
+			rows, err := ucTeams.ParseRoster(format, strings.NewReader(out))
+			require.NoError(t, err, format)
+			result, err := ucTeams.NewUsecase(storage, events.Discard).ImportRoster(context.Background(), rows, false)
+			require.NoError(t, err, format)
+			assert.Empty(t, result.Errors)
+			assert.True(t, result.Applied)
+		}
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		_, err := runWithConfig(t, getenv, "pr", "reassign", "pr1")
+		require.ErrorIs(t, err, errUsage)
+
+		_, err = runWithConfig(t, getenv, "-o", "xml", "team", "list")
+		require.ErrorIs(t, err, errUsage)
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server: http://config:8080\ntoken: from-file\n"), 0o600))
+
+	cfg, err := loadConfig(path, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, config{Server: "http://config:8080", Token: "from-file"}, cfg)
+
+	cfg, err = loadConfig("", env(map[string]string{"PRCTL_CONFIG": path, "PRCTL_TOKEN": "from-env"}))
+	require.NoError(t, err)
+	assert.Equal(t, config{Server: "http://config:8080", Token: "from-env"}, cfg)
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"), env(nil))
+	require.Error(t, err)
+}
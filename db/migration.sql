@@ -29,3 +29,100 @@ CREATE TABLE IF NOT EXISTS pr_reviewers_map (
     pull_request_id TEXT REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
     user_id TEXT REFERENCES "user"(user_id) ON DELETE CASCADE
 );
+
+CREATE TABLE IF NOT EXISTS team_settings (
+    team_name           TEXT PRIMARY KEY REFERENCES team(team_name) ON DELETE CASCADE,
+    reviewers_count     INT,
+    selection_strategy  TEXT,
+    min_reviewers       INT
+);
+
+ALTER TABLE team_user_map ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'member';
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS email TEXT;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS display_name TEXT;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS timezone TEXT;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS chat_handle TEXT;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS vcs_login TEXT;
+
+-- История назначений на ревью. Строка закрывается при снятии ревьюера, user_id без FK,
+-- чтобы история переживала удаление пользователя.
+CREATE TABLE IF NOT EXISTS review_assignment (
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
+    user_id         TEXT NOT NULL,
+    assigned_at     TIMESTAMPTZ NOT NULL,
+    unassigned_at   TIMESTAMPTZ,
+    reason          TEXT
+);
+
+CREATE INDEX IF NOT EXISTS review_assignment_user_idx ON review_assignment (user_id, assigned_at);
+
+-- Переносим текущие назначения, сделанные до появления истории.
+INSERT INTO review_assignment (pull_request_id, user_id, assigned_at)
+SELECT prm.pull_request_id, prm.user_id, pr.created_at
+FROM pr_reviewers_map AS prm
+JOIN pull_request AS pr ON pr.pull_request_id = prm.pull_request_id
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM review_assignment AS ra
+    WHERE ra.pull_request_id = prm.pull_request_id AND ra.user_id = prm.user_id
+);
+
+-- Журнал удаления персональных данных. Исходный user_id не сохраняется.
+CREATE TABLE IF NOT EXISTS user_erasure_audit (
+    id                 BIGSERIAL PRIMARY KEY,
+    pseudonym_id       TEXT NOT NULL,
+    requested_by       TEXT NOT NULL,
+    reason             TEXT,
+    authored_prs       INT NOT NULL,
+    review_assignments INT NOT NULL,
+    erased_at          TIMESTAMPTZ NOT NULL
+);
+
+-- Журнал смен активности пользователей для отчетов с поправкой на время активности.
+-- Пишется триггером, чтобы не зависеть от того, какой код меняет is_active.
+CREATE TABLE IF NOT EXISTS user_activity (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    TEXT NOT NULL,
+    is_active  BOOLEAN NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS user_activity_user_idx ON user_activity (user_id, changed_at);
+
+CREATE OR REPLACE FUNCTION log_user_activity() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.is_active IS DISTINCT FROM OLD.is_active THEN
+        INSERT INTO user_activity (user_id, is_active, changed_at)
+        VALUES (NEW.user_id, COALESCE(NEW.is_active, false), now());
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS user_activity_log ON "user";
+CREATE TRIGGER user_activity_log
+AFTER INSERT OR UPDATE OF is_active ON "user"
+FOR EACH ROW EXECUTE FUNCTION log_user_activity();
+
+-- Пользователи, заведенные до появления журнала, считаются в текущем состоянии с начала времен.
+INSERT INTO user_activity (user_id, is_active, changed_at)
+SELECT u.user_id, COALESCE(u.is_active, false), '-infinity'
+FROM "user" AS u
+WHERE NOT EXISTS (
+    SELECT 1 FROM user_activity AS ua WHERE ua.user_id = u.user_id
+);
+
+-- Обработанные доставки вебхуков внешних систем, чтобы повтор одной доставки не применялся дважды.
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    source      TEXT NOT NULL,
+    delivery_id TEXT NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (source, delivery_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_vcs_login_idx ON "user" (lower(vcs_login));
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS gitlab_user_id TEXT;
+CREATE INDEX IF NOT EXISTS user_gitlab_user_id_idx ON "user" (gitlab_user_id);
@@ -0,0 +1,8 @@
+// Package assignment - общие значения журнала назначений на ревью (review_assignment).
+package assignment
+
+// Причины снятия ревьюера в review_assignment.reason.
+const (
+	ReasonReassigned = "reassigned"
+	ReasonHandover   = "handover"
+)
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHistorySize - сколько последних событий хранится для возобновления по Last-Event-ID.
+	DefaultHistorySize = 1000
+	// subscriptionBuffer - запас канала подписки. Подписчик, который не успевает его разбирать, отключается.
+	subscriptionBuffer = 64
+)
+
+// Broker раздает события подписчикам в памяти процесса. Публикация не блокируется медленными подписчиками.
+// История живет до перезапуска. Нумерация начинается с времени запуска в микросекундах, поэтому
+// id после перезапуска больше прежних и не совпадают с ними (и остаются точными числами для JavaScript).
+type Broker struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event
+	historySize int
+	subs        map[*Subscription]struct{}
+	now         func() time.Time
+}
+
+func NewBroker(historySize int) *Broker {
+	return &Broker{
+		lastID:      uint64(time.Now().UnixMicro()),
+		historySize: historySize,
+		subs:        make(map[*Subscription]struct{}),
+		now:         time.Now,
+	}
+}
+
+// Publish нумерует событие и рассылает его подходящим подписчикам.
+func (b *Broker) Publish(ev Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	ev.ID = b.lastID
+	ev.OccurredAt = b.now()
+
+	b.history = append(b.history, ev)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for sub := range b.subs {
+		if !sub.filter.Match(ev) {
+			continue
+		}
+		select {
+		case sub.events <- ev:
+		default:
+			b.drop(sub)
+		}
+	}
+}
+
+// Subscribe подписывает на события по фильтру. Если lastEventID не нулевой, сначала придут
+// сохраненные события после него. Если пропущенное уже не восстановить (id из прошлого запуска
+// или вытесненный из истории), первым придет Resync.
+func (b *Broker) Subscribe(filter Filter, lastEventID uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var backlog []Event
+	// История без пропусков: в ней события от oldest+1 до lastID.
+	oldest := b.lastID - uint64(len(b.history))
+	switch {
+	case lastEventID == 0:
+	case lastEventID < oldest || lastEventID > b.lastID:
+		backlog = append(backlog, Event{ID: b.lastID, Type: Resync, OccurredAt: b.now(), Data: struct{}{}})
+	default:
+		for _, ev := range b.history {
+			if ev.ID > lastEventID && filter.Match(ev) {
+				backlog = append(backlog, ev)
+			}
+		}
+	}
+
+	sub := &Subscription{
+		broker: b,
+		filter: filter,
+		events: make(chan Event, subscriptionBuffer+len(backlog)),
+	}
+	for _, ev := range backlog {
+		sub.events <- ev
+	}
+
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *Broker) drop(sub *Subscription) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	close(sub.events)
+}
+
+type Subscription struct {
+	broker *Broker
+	filter Filter
+	events chan Event
+}
+
+// Events закрывается, когда подписка отменена или подписчик отстал.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.drop(s)
+}
+
+// Discard - издатель, который никуда не рассылает события. Его передают в usecase, когда события
+// никому не нужны, например при импорте.
+var Discard discard
+
+type discard struct{}
+
+func (discard) Publish(Event) {}
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, sub *Subscription) []Event {
+	t.Helper()
+	var got []Event
+	for {
+		select {
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return got
+			}
+			got = append(got, ev)
+		default:
+			return got
+		}
+	}
+}
+
+func TestBroker(t *testing.T) {
+	t.Run("filter", func(t *testing.T) {
+		b := NewBroker(DefaultHistorySize)
+		start := b.lastID
+		byTeam := b.Subscribe(Filter{TeamName: "backend"}, 0)
+		byUser := b.Subscribe(Filter{UserID: "u2"}, 0)
+		all := b.Subscribe(Filter{}, 0)
+
+		b.Publish(Event{Type: PRCreated, TeamName: "backend", UserIDs: []string{"u1", "u2"}})
+		b.Publish(Event{Type: TeamCreated, TeamName: "frontend", UserIDs: []string{"u3"}})
+		b.Publish(Event{Type: UserDeactivated, TeamName: "frontend", UserIDs: []string{"u2"}})
+
+		team := receive(t, byTeam)
+		require.Len(t, team, 1)
+		assert.Equal(t, start+1, team[0].ID)
+		assert.False(t, team[0].OccurredAt.IsZero())
+
+		user := receive(t, byUser)
+		require.Len(t, user, 2)
+		assert.Equal(t, PRCreated, user[0].Type)
+		assert.Equal(t, UserDeactivated, user[1].Type)
+
+		assert.Len(t, receive(t, all), 3)
+	})
+
+	t.Run("resume from last event id", func(t *testing.T) {
+		b := NewBroker(2)
+		start := b.lastID
+		for i := 0; i < 4; i++ {
+			b.Publish(Event{Type: PRMerged, TeamName: "backend"})
+		}
+
+		sub := b.Subscribe(Filter{}, start+2)
+		got := receive(t, sub)
+		require.Len(t, got, 2)
+		assert.Equal(t, start+3, got[0].ID)
+		assert.Equal(t, start+4, got[1].ID)
+
+		// Вытесненные из истории события не восстановить, клиент получает Resync с текущим id.
+		got = receive(t, b.Subscribe(Filter{}, start+1))
+		require.Len(t, got, 1)
+		assert.Equal(t, Resync, got[0].Type)
+		assert.Equal(t, start+4, got[0].ID)
+
+		// Последнее событие уже получено, досылать нечего.
+		assert.Empty(t, receive(t, b.Subscribe(Filter{}, start+4)))
+	})
+
+	t.Run("id from previous run", func(t *testing.T) {
+		old := NewBroker(DefaultHistorySize)
+		old.Publish(Event{Type: PRCreated})
+		oldID := old.lastID
+
+		// Новый запуск нумерует события с более позднего времени.
+		b := NewBroker(DefaultHistorySize)
+		b.lastID = oldID + 1000
+		b.Publish(Event{Type: PRMerged})
+
+		got := receive(t, b.Subscribe(Filter{}, oldID))
+		require.Len(t, got, 1)
+		assert.Equal(t, Resync, got[0].Type)
+		assert.Equal(t, b.lastID, got[0].ID)
+
+		// id из будущего тоже неизвестен.
+		got = receive(t, b.Subscribe(Filter{}, b.lastID+5))
+		require.Len(t, got, 1)
+		assert.Equal(t, Resync, got[0].Type)
+	})
+
+	t.Run("slow subscriber is dropped", func(t *testing.T) {
+		b := NewBroker(DefaultHistorySize)
+		slow := b.Subscribe(Filter{}, 0)
+		for i := 0; i < subscriptionBuffer+1; i++ {
+			b.Publish(Event{Type: PRCreated})
+		}
+
+		assert.Len(t, receive(t, slow), subscriptionBuffer)
+		_, ok := <-slow.Events()
+		assert.False(t, ok)
+		assert.Empty(t, b.subs)
+
+		// Повторное закрытие после отключения не паникует.
+		slow.Close()
+	})
+
+	t.Run("close", func(t *testing.T) {
+		b := NewBroker(DefaultHistorySize)
+		sub := b.Subscribe(Filter{}, 0)
+		sub.Close()
+		b.Publish(Event{Type: PRCreated})
+
+		_, ok := <-sub.Events()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"time"
+)
+
+const (
+	PRCreated          = "PR_CREATED"
+	PRMerged           = "PR_MERGED"
+	ReviewerReassigned = "REVIEWER_REASSIGNED"
+	UserDeactivated    = "USER_DEACTIVATED"
+	TeamCreated        = "TEAM_CREATED"
+	// Resync приходит вместо пропущенных событий, которых больше нет в истории. Клиенту нужно
+	// перечитать состояние через API и продолжать с id этого события.
+	Resync = "RESYNC"
+)
+
+// Event - событие для подписчиков /events. ID и время проставляет Broker при публикации.
+type Event struct {
+	ID         uint64    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// TeamName и UserIDs нужны для фильтрации подписок: команда, к которой относится событие,
+	// и все затронутые пользователи (автор, ревьюеры, участники команды).
+	TeamName string   `json:"team_name,omitempty"`
+	UserIDs  []string `json:"user_ids,omitempty"`
+	Data     any      `json:"data"`
+}
+
+type PullRequest struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	Status            string   `json:"status"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+}
+
+type Reassignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"new_user_id"`
+	Escalated     bool   `json:"escalated"`
+}
+
+type User struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+}
+
+type Team struct {
+	TeamName string   `json:"team_name"`
+	Members  []string `json:"members"`
+}
+
+// Filter - условие подписки. Пустые поля не фильтруют.
+type Filter struct {
+	TeamName string
+	UserID   string
+}
+
+func (f Filter) Match(ev Event) bool {
+	if f.TeamName != "" && f.TeamName != ev.TeamName {
+		return false
+	}
+	if f.UserID == "" {
+		return true
+	}
+	for _, id := range ev.UserIDs {
+		if id == f.UserID {
+			return true
+		}
+	}
+	return false
+}
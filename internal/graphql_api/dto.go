@@ -0,0 +1,9 @@
+package graphqlapi
+
+// Request - тело POST /graphql
+type Request struct {
+	// Query ограничен по длине, чтобы разбор запроса не стоил дороже его выполнения.
+	Query         string                 `json:"query" validate:"required,max=10000"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
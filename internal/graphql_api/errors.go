@@ -0,0 +1,42 @@
+package graphqlapi
+
+import (
+	"errors"
+	"log"
+
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+// codedError - ошибка резолвера с кодом из REST API в extensions.code.
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e codedError) Unwrap() error {
+	return e.err
+}
+
+func (e codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// errInternal - текст для клиента вместо неизвестных ошибок.
+var errInternal = errors.New("internal error")
+
+// withCode навешивает код на известные ошибки usecase. Остальные, в том числе ошибки загрузчиков,
+// отдаются как INTERNAL без текста, а причина пишется в лог.
+func withCode(err error) error {
+	if err == nil {
+		return nil
+	}
+	if code, ok := utils.ErrorCode(err); ok {
+		return codedError{code: code, err: err}
+	}
+	log.Printf("graphql internal error: %v", err)
+	return codedError{code: utils.Internal, err: errInternal}
+}
@@ -0,0 +1,85 @@
+//go:generate mockgen --source=handlers.go --destination=mocks/handlers.go -package=mocks
+
+package graphqlapi
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/labstack/echo/v4"
+
+	"github.com/qwerty268/pull_request_service/internal/usecases/graph"
+	prDto "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxParallelism - сколько резолверов одного запроса работают параллельно.
+// Чем больше, тем крупнее пачки у загрузчиков.
+const maxParallelism = 64
+
+// maxDepth ограничивает вложенность запроса: схема рекурсивна (user -> reviews -> author -> ...).
+const maxDepth = 10
+
+// Reader - пакетное чтение для загрузчиков.
+type Reader interface {
+	GetUsers(ctx context.Context, userIDs []string) (map[string]graph.User, error)
+	ListTeamNames(ctx context.Context) ([]string, error)
+	GetTeams(ctx context.Context, teamNames []string) (map[string]graph.Team, error)
+	GetPullRequests(ctx context.Context, prIDs []string) (map[string]graph.PullRequest, error)
+	GetReviewPRIDs(ctx context.Context, userIDs []string) (map[string][]string, error)
+	GetAuthoredPRIDs(ctx context.Context, userIDs []string) (map[string][]string, error)
+}
+
+// PRMutator - мутации идут через тот же usecase, что и REST.
+type PRMutator interface {
+	CreatePR(ctx context.Context, pr prDto.CreatePROpst) (*prDto.PullRequest, error)
+	MergePR(ctx context.Context, prID string) (*prDto.PullRequest, error)
+	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*prDto.ReassignedRewiew, error)
+}
+
+type Handlers struct {
+	schema    *graphql.Schema
+	reader    Reader
+	batchWait time.Duration
+}
+
+func NewHandlers(reader Reader, prMutator PRMutator) *Handlers {
+	schema := graphql.MustParseSchema(
+		schemaSDL,
+		&resolver{reader: reader, prMutator: prMutator},
+		graphql.MaxParallelism(maxParallelism),
+		graphql.MaxDepth(maxDepth),
+	)
+	return &Handlers{
+		schema:    schema,
+		reader:    reader,
+		batchWait: DefaultBatchWait,
+	}
+}
+
+func (h *Handlers) RegisterHandlers(r utils.Router) {
+	r.POST("/graphql", h.Query)
+}
+
+// Query выполняет запрос GraphQL. Ошибки резолверов отдаются в errors с кодом 200.
+func (h *Handlers) Query(c echo.Context) error {
+	ctx := withLoaders(context.Background(), newLoaders(h.reader, h.batchWait))
+
+	req := new(Request)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,255 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/graphql_api/mocks"
+	"github.com/qwerty268/pull_request_service/internal/usecases/graph"
+	prDto "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+type gqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func doQuery(t *testing.T, h *Handlers, body string) (int, gqlResponse) {
+	t.Helper()
+	e := echo.New()
+	e.Validator = utils.NewHTTPRequestValidator()
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, h.Query(c))
+	var resp gqlResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec.Code, resp
+}
+
+func queryBody(t *testing.T, query string) string {
+	t.Helper()
+	body, err := json.Marshal(Request{Query: query})
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestHandlers_NestedQueryIsBatched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reader := mocks.NewMockReader(ctrl)
+	h := NewHandlers(reader, mocks.NewMockPRMutator(ctrl))
+
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	users := map[string]graph.User{
+		"u1": {UserID: "u1", Username: "alice", TeamName: "backend", IsActive: true},
+		"u2": {UserID: "u2", Username: "bob", TeamName: "backend", IsActive: true},
+		"u3": {UserID: "u3", Username: "carol", TeamName: "backend", IsActive: false},
+	}
+	prs := map[string]graph.PullRequest{
+		"pr1": {PullRequestID: "pr1", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{"u2", "u3"}, CreatedAt: created},
+		"pr2": {PullRequestID: "pr2", AuthorID: "u3", Status: "MERGED", AssignedReviewers: []string{"u1"}, CreatedAt: created},
+	}
+
+	reader.EXPECT().ListTeamNames(gomock.Any()).Return([]string{"backend"}, nil)
+	reader.EXPECT().GetTeams(gomock.Any(), []string{"backend"}).Return(map[string]graph.Team{
+		"backend": {TeamName: "backend", Members: []graph.TeamMember{
+			{UserID: "u1", Role: "lead"}, {UserID: "u2", Role: "member"}, {UserID: "u3", Role: "member"},
+		}},
+	}, nil)
+	// Каждая выборка должна пройти одной пачкой, сколько бы участников ни было.
+	reader.EXPECT().GetUsers(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ids []string) (map[string]graph.User, error) {
+			assert.ElementsMatch(t, []string{"u1", "u2", "u3"}, ids)
+			return users, nil
+		})
+	reader.EXPECT().GetReviewPRIDs(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ids []string) (map[string][]string, error) {
+			assert.ElementsMatch(t, []string{"u1", "u2", "u3"}, ids)
+			return map[string][]string{"u1": {"pr2"}, "u2": {"pr1"}, "u3": {"pr1"}}, nil
+		})
+	reader.EXPECT().GetPullRequests(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ids []string) (map[string]graph.PullRequest, error) {
+			assert.ElementsMatch(t, []string{"pr1", "pr2"}, ids)
+			return prs, nil
+		})
+
+	code, resp := doQuery(t, h, queryBody(t, `{
+		teams {
+			name
+			members {
+				role
+				user {
+					username
+					reviews(status: OPEN) { id author { username } reviewers { username } mergedAt }
+				}
+			}
+		}
+	}`))
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, resp.Errors)
+
+	members := resp.Data["teams"].([]any)[0].(map[string]any)["members"].([]any)
+	require.Len(t, members, 3)
+
+	alice := members[0].(map[string]any)["user"].(map[string]any)
+	assert.Equal(t, "alice", alice["username"])
+	assert.Empty(t, alice["reviews"], "merged pr2 is filtered out")
+
+	bob := members[1].(map[string]any)["user"].(map[string]any)
+	review := bob["reviews"].([]any)[0].(map[string]any)
+	assert.Equal(t, "pr1", review["id"])
+	assert.Equal(t, "alice", review["author"].(map[string]any)["username"])
+	assert.Len(t, review["reviewers"], 2)
+	assert.Nil(t, review["mergedAt"])
+}
+
+func TestHandlers_QueryNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reader := mocks.NewMockReader(ctrl)
+	h := NewHandlers(reader, mocks.NewMockPRMutator(ctrl))
+
+	reader.EXPECT().GetPullRequests(gomock.Any(), []string{"ghost"}).Return(map[string]graph.PullRequest{}, nil)
+
+	code, resp := doQuery(t, h, queryBody(t, `{ pullRequest(id: "ghost") { id } }`))
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, resp.Errors)
+	assert.Nil(t, resp.Data["pullRequest"])
+}
+
+func TestHandlers_LoaderErrorIsInternal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reader := mocks.NewMockReader(ctrl)
+	h := NewHandlers(reader, mocks.NewMockPRMutator(ctrl))
+
+	reader.EXPECT().
+		GetPullRequests(gomock.Any(), []string{"pr1"}).
+		Return(nil, fmt.Errorf("GetPullRequests: dial tcp 10.0.0.5:5432: connection refused"))
+
+	code, resp := doQuery(t, h, queryBody(t, `{ pullRequest(id: "pr1") { id } }`))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "internal error", resp.Errors[0].Message)
+	assert.Equal(t, utils.Internal, resp.Errors[0].Extensions["code"])
+}
+
+func TestHandlers_MaxDepth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewHandlers(mocks.NewMockReader(ctrl), mocks.NewMockPRMutator(ctrl))
+
+	query := `{ user(id: "u1") { reviews { author { reviews { author { reviews { author { reviews { author { reviews { author { id } } } } } } } } } } }`
+	code, resp := doQuery(t, h, queryBody(t, query))
+	require.Equal(t, http.StatusOK, code)
+	require.NotEmpty(t, resp.Errors)
+	assert.Nil(t, resp.Data)
+}
+
+func TestHandlers_Mutations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reader := mocks.NewMockReader(ctrl)
+	prMutator := mocks.NewMockPRMutator(ctrl)
+	h := NewHandlers(reader, prMutator)
+
+	t.Run("create", func(t *testing.T) {
+		prMutator.EXPECT().
+			CreatePR(gomock.Any(), prDto.CreatePROpst{PullRequestID: "pr1", PullRequestName: "feat", AuthorID: "u1"}).
+			Return(&prDto.PullRequest{PullRequestID: "pr1", PullRequestName: "feat", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{"u2"}}, nil)
+
+		code, resp := doQuery(t, h, queryBody(t, `mutation {
+			createPullRequest(input: {id: "pr1", name: "feat", authorId: "u1"}) { id status }
+		}`))
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]any{"id": "pr1", "status": "OPEN"}, resp.Data["createPullRequest"])
+	})
+
+	t.Run("merge error has code", func(t *testing.T) {
+		prMutator.EXPECT().
+			MergePR(gomock.Any(), "pr1").
+			Return(nil, fmt.Errorf("pr has 1 of 2 required reviewers: %w", prDto.ErrNotEnoughReviewers))
+
+		code, resp := doQuery(t, h, queryBody(t, `mutation { mergePullRequest(id: "pr1") { id } }`))
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, utils.NotEnoughReviewers, resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		prMutator.EXPECT().
+			ReassignReviewer(gomock.Any(), "pr1", "u2").
+			Return(&prDto.ReassignedRewiew{
+				Pr:          prDto.PullRequest{PullRequestID: "pr1", Status: "OPEN", AssignedReviewers: []string{"u4"}},
+				NewReviewer: "u4",
+			}, nil)
+		reader.EXPECT().
+			GetUsers(gomock.Any(), []string{"u4"}).
+			Return(map[string]graph.User{"u4": {UserID: "u4", Username: "dave"}}, nil)
+
+		code, resp := doQuery(t, h, queryBody(t, `mutation {
+			reassignReviewer(id: "pr1", oldUserId: "u2") { replacedBy { username } escalated }
+		}`))
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]any{
+			"replacedBy": map[string]any{"username": "dave"},
+			"escalated":  false,
+		}, resp.Data["reassignReviewer"])
+	})
+}
+
+func TestHandlers_BadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewHandlers(mocks.NewMockReader(ctrl), mocks.NewMockPRMutator(ctrl))
+
+	e := echo.New()
+	e.Validator = utils.NewHTTPRequestValidator()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": ""}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	err := h.Query(c)
+	var validationErr *utils.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []utils.FieldError{{Field: "query", Rule: "required", Message: "is required"}}, validationErr.Fields)
+
+	body := queryBody(t, "{ teams { name } }"+strings.Repeat(" ", 10000))
+	req = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	err = h.Query(c)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "query", validationErr.Fields[0].Field)
+	assert.Equal(t, "max", validationErr.Fields[0].Rule)
+}
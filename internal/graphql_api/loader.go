@@ -0,0 +1,116 @@
+package graphqlapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultBatchWait - сколько загрузчик копит ключи перед походом в usecase.
+const DefaultBatchWait = 2 * time.Millisecond
+
+// BatchFunc забирает значения по пачке ключей. Ключей, которых нет в ответе, не существует.
+type BatchFunc[V any] func(ctx context.Context, keys []string) (map[string]V, error)
+
+// Loader собирает ключи от параллельно работающих резолверов в одну пачку и кеширует
+// результат на время запроса. Нужен, чтобы вложенные запросы не превращались в N+1.
+type Loader[V any] struct {
+	fetch BatchFunc[V]
+	wait  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*loadResult[V]
+	batch []string
+}
+
+type loadResult[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+func NewLoader[V any](fetch BatchFunc[V], wait time.Duration) *Loader[V] {
+	return &Loader[V]{
+		fetch: fetch,
+		wait:  wait,
+		cache: make(map[string]*loadResult[V]),
+	}
+}
+
+// Load выдает значение по ключу, found=false - ключа нет.
+func (l *Loader[V]) Load(ctx context.Context, key string) (V, bool, error) {
+	res := l.enqueue(ctx, []string{key})[0]
+	return l.await(ctx, res)
+}
+
+// LoadMany выдает значения по ключам в том же порядке, отсутствующие ключи пропускаются.
+func (l *Loader[V]) LoadMany(ctx context.Context, keys []string) ([]V, error) {
+	results := l.enqueue(ctx, keys)
+	values := make([]V, 0, len(keys))
+	for _, res := range results {
+		v, found, err := l.await(ctx, res)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+func (l *Loader[V]) enqueue(ctx context.Context, keys []string) []*loadResult[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	results := make([]*loadResult[V], len(keys))
+	for i, key := range keys {
+		if res, ok := l.cache[key]; ok {
+			results[i] = res
+			continue
+		}
+		res := &loadResult[V]{done: make(chan struct{})}
+		l.cache[key] = res
+		results[i] = res
+
+		if len(l.batch) == 0 {
+			// Контекст первого ключа живет весь запрос, его и отдаем в пачку.
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx) })
+		}
+		l.batch = append(l.batch, key)
+	}
+	return results
+}
+
+func (l *Loader[V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	keys := l.batch
+	l.batch = nil
+	results := make([]*loadResult[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.cache[key]
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, keys)
+	for i, key := range keys {
+		res := results[i]
+		if err != nil {
+			res.err = err
+		} else {
+			res.value, res.found = values[key]
+		}
+		close(res.done)
+	}
+}
+
+func (l *Loader[V]) await(ctx context.Context, res *loadResult[V]) (V, bool, error) {
+	select {
+	case <-res.done:
+		return res.value, res.found, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
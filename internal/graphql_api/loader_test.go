@@ -0,0 +1,61 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("batches concurrent loads and caches", func(t *testing.T) {
+		var calls atomic.Int32
+		loader := NewLoader(func(_ context.Context, keys []string) (map[string]int, error) {
+			calls.Add(1)
+			values := make(map[string]int, len(keys))
+			for _, k := range keys {
+				if k != "missing" {
+					values[k] = len(k)
+				}
+			}
+			return values, nil
+		}, 5*time.Millisecond)
+
+		var wg sync.WaitGroup
+		for _, key := range []string{"a", "bb", "a", "missing"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v, found, err := loader.Load(ctx, key)
+				assert.NoError(t, err)
+				assert.Equal(t, key != "missing", found)
+				if found {
+					assert.Equal(t, len(key), v)
+				}
+			}()
+		}
+		wg.Wait()
+		require.EqualValues(t, 1, calls.Load())
+
+		values, err := loader.LoadMany(ctx, []string{"bb", "missing", "a"})
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 1}, values)
+		require.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("error is shared by batch", func(t *testing.T) {
+		loader := NewLoader(func(_ context.Context, _ []string) (map[string]int, error) {
+			return nil, errors.New("db down")
+		}, time.Millisecond)
+
+		_, err := loader.LoadMany(ctx, []string{"a", "b"})
+		require.ErrorContains(t, err, "db down")
+	})
+}
@@ -0,0 +1,37 @@
+package graphqlapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/qwerty268/pull_request_service/internal/usecases/graph"
+)
+
+type loadersKey struct{}
+
+// loaders живут один запрос: кеш не переживает мутации соседних запросов.
+type loaders struct {
+	users    *Loader[graph.User]
+	teams    *Loader[graph.Team]
+	prs      *Loader[graph.PullRequest]
+	reviews  *Loader[[]string]
+	authored *Loader[[]string]
+}
+
+func newLoaders(reader Reader, wait time.Duration) *loaders {
+	return &loaders{
+		users:    NewLoader(reader.GetUsers, wait),
+		teams:    NewLoader(reader.GetTeams, wait),
+		prs:      NewLoader(reader.GetPullRequests, wait),
+		reviews:  NewLoader(reader.GetReviewPRIDs, wait),
+		authored: NewLoader(reader.GetAuthoredPRIDs, wait),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
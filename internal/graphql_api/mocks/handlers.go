@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handlers.go
+//
+// Generated by this command:
+//
+//	mockgen --source=handlers.go --destination=mocks/handlers.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	graph "github.com/qwerty268/pull_request_service/internal/usecases/graph"
+	pullrequests "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// GetAuthoredPRIDs mocks base method.
+func (m *MockReader) GetAuthoredPRIDs(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthoredPRIDs", ctx, userIDs)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthoredPRIDs indicates an expected call of GetAuthoredPRIDs.
+func (mr *MockReaderMockRecorder) GetAuthoredPRIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthoredPRIDs", reflect.TypeOf((*MockReader)(nil).GetAuthoredPRIDs), ctx, userIDs)
+}
+
+// GetPullRequests mocks base method.
+func (m *MockReader) GetPullRequests(ctx context.Context, prIDs []string) (map[string]graph.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequests", ctx, prIDs)
+	ret0, _ := ret[0].(map[string]graph.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequests indicates an expected call of GetPullRequests.
+func (mr *MockReaderMockRecorder) GetPullRequests(ctx, prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequests", reflect.TypeOf((*MockReader)(nil).GetPullRequests), ctx, prIDs)
+}
+
+// GetReviewPRIDs mocks base method.
+func (m *MockReader) GetReviewPRIDs(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewPRIDs", ctx, userIDs)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewPRIDs indicates an expected call of GetReviewPRIDs.
+func (mr *MockReaderMockRecorder) GetReviewPRIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewPRIDs", reflect.TypeOf((*MockReader)(nil).GetReviewPRIDs), ctx, userIDs)
+}
+
+// GetTeams mocks base method.
+func (m *MockReader) GetTeams(ctx context.Context, teamNames []string) (map[string]graph.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeams", ctx, teamNames)
+	ret0, _ := ret[0].(map[string]graph.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeams indicates an expected call of GetTeams.
+func (mr *MockReaderMockRecorder) GetTeams(ctx, teamNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeams", reflect.TypeOf((*MockReader)(nil).GetTeams), ctx, teamNames)
+}
+
+// GetUsers mocks base method.
+func (m *MockReader) GetUsers(ctx context.Context, userIDs []string) (map[string]graph.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, userIDs)
+	ret0, _ := ret[0].(map[string]graph.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockReaderMockRecorder) GetUsers(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockReader)(nil).GetUsers), ctx, userIDs)
+}
+
+// ListTeamNames mocks base method.
+func (m *MockReader) ListTeamNames(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeamNames", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeamNames indicates an expected call of ListTeamNames.
+func (mr *MockReaderMockRecorder) ListTeamNames(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamNames", reflect.TypeOf((*MockReader)(nil).ListTeamNames), ctx)
+}
+
+// MockPRMutator is a mock of PRMutator interface.
+type MockPRMutator struct {
+	ctrl     *gomock.Controller
+	recorder *MockPRMutatorMockRecorder
+	isgomock struct{}
+}
+
+// MockPRMutatorMockRecorder is the mock recorder for MockPRMutator.
+type MockPRMutatorMockRecorder struct {
+	mock *MockPRMutator
+}
+
+// NewMockPRMutator creates a new mock instance.
+func NewMockPRMutator(ctrl *gomock.Controller) *MockPRMutator {
+	mock := &MockPRMutator{ctrl: ctrl}
+	mock.recorder = &MockPRMutatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPRMutator) EXPECT() *MockPRMutatorMockRecorder {
+	return m.recorder
+}
+
+// CreatePR mocks base method.
+func (m *MockPRMutator) CreatePR(ctx context.Context, pr pullrequests.CreatePROpst) (*pullrequests.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePR", ctx, pr)
+	ret0, _ := ret[0].(*pullrequests.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePR indicates an expected call of CreatePR.
+func (mr *MockPRMutatorMockRecorder) CreatePR(ctx, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePR", reflect.TypeOf((*MockPRMutator)(nil).CreatePR), ctx, pr)
+}
+
+// MergePR mocks base method.
+func (m *MockPRMutator) MergePR(ctx context.Context, prID string) (*pullrequests.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePR", ctx, prID)
+	ret0, _ := ret[0].(*pullrequests.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergePR indicates an expected call of MergePR.
+func (mr *MockPRMutatorMockRecorder) MergePR(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePR", reflect.TypeOf((*MockPRMutator)(nil).MergePR), ctx, prID)
+}
+
+// ReassignReviewer mocks base method.
+func (m *MockPRMutator) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*pullrequests.ReassignedRewiew, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignReviewer", ctx, prID, oldUserID)
+	ret0, _ := ret[0].(*pullrequests.ReassignedRewiew)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignReviewer indicates an expected call of ReassignReviewer.
+func (mr *MockPRMutatorMockRecorder) ReassignReviewer(ctx, prID, oldUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignReviewer", reflect.TypeOf((*MockPRMutator)(nil).ReassignReviewer), ctx, prID, oldUserID)
+}
@@ -0,0 +1,266 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/qwerty268/pull_request_service/internal/usecases/graph"
+	prDto "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+)
+
+// resolver - корень схемы: и Query, и Mutation.
+type resolver struct {
+	reader    Reader
+	prMutator PRMutator
+}
+
+func (r *resolver) Team(ctx context.Context, args struct{ Name string }) (*teamResolver, error) {
+	team, found, err := loadersFrom(ctx).teams.Load(ctx, args.Name)
+	if err != nil || !found {
+		return nil, withCode(err)
+	}
+	return &teamResolver{team: team}, nil
+}
+
+func (r *resolver) Teams(ctx context.Context) ([]*teamResolver, error) {
+	names, err := r.reader.ListTeamNames(ctx)
+	if err != nil {
+		return nil, withCode(err)
+	}
+	teams, err := loadersFrom(ctx).teams.LoadMany(ctx, names)
+	if err != nil {
+		return nil, withCode(err)
+	}
+
+	resolvers := make([]*teamResolver, len(teams))
+	for i, v := range teams {
+		resolvers[i] = &teamResolver{team: v}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	return loadUser(ctx, string(args.ID))
+}
+
+func (r *resolver) PullRequest(ctx context.Context, args struct{ ID graphql.ID }) (*prResolver, error) {
+	pr, found, err := loadersFrom(ctx).prs.Load(ctx, string(args.ID))
+	if err != nil || !found {
+		return nil, withCode(err)
+	}
+	return &prResolver{pr: pr}, nil
+}
+
+type createPullRequestInput struct {
+	ID       graphql.ID
+	Name     string
+	AuthorID graphql.ID
+}
+
+func (r *resolver) CreatePullRequest(ctx context.Context, args struct{ Input createPullRequestInput }) (*prResolver, error) {
+	pr, err := r.prMutator.CreatePR(ctx, prDto.CreatePROpst{
+		PullRequestID:   string(args.Input.ID),
+		PullRequestName: args.Input.Name,
+		AuthorID:        string(args.Input.AuthorID),
+	})
+	if err != nil {
+		return nil, withCode(err)
+	}
+	return &prResolver{pr: graph.PullRequest(*pr)}, nil
+}
+
+func (r *resolver) MergePullRequest(ctx context.Context, args struct{ ID graphql.ID }) (*prResolver, error) {
+	pr, err := r.prMutator.MergePR(ctx, string(args.ID))
+	if err != nil {
+		return nil, withCode(err)
+	}
+	return &prResolver{pr: graph.PullRequest(*pr)}, nil
+}
+
+func (r *resolver) ReassignReviewer(ctx context.Context, args struct {
+	ID        graphql.ID
+	OldUserID graphql.ID
+}) (*reassignResolver, error) {
+	reassigned, err := r.prMutator.ReassignReviewer(ctx, string(args.ID), string(args.OldUserID))
+	if err != nil {
+		return nil, withCode(err)
+	}
+	return &reassignResolver{reassigned: reassigned}, nil
+}
+
+type teamResolver struct {
+	team graph.Team
+}
+
+func (r *teamResolver) Name() string {
+	return r.team.TeamName
+}
+
+func (r *teamResolver) Members(ctx context.Context) ([]*teamMemberResolver, error) {
+	ids := make([]string, len(r.team.Members))
+	for i, m := range r.team.Members {
+		ids[i] = m.UserID
+	}
+	// Грузим всех участников одной пачкой, дальше teamMemberResolver.User берет их из кеша.
+	if _, err := loadersFrom(ctx).users.LoadMany(ctx, ids); err != nil {
+		return nil, withCode(err)
+	}
+
+	resolvers := make([]*teamMemberResolver, len(r.team.Members))
+	for i, m := range r.team.Members {
+		resolvers[i] = &teamMemberResolver{member: m}
+	}
+	return resolvers, nil
+}
+
+type teamMemberResolver struct {
+	member graph.TeamMember
+}
+
+func (r *teamMemberResolver) Role() string {
+	return r.member.Role
+}
+
+func (r *teamMemberResolver) User(ctx context.Context) (*userResolver, error) {
+	user, err := loadUser(ctx, r.member.UserID)
+	if err != nil {
+		return nil, withCode(err)
+	}
+	if user == nil {
+		return nil, errors.New("team member not found: " + r.member.UserID)
+	}
+	return user, nil
+}
+
+type userResolver struct {
+	user graph.User
+}
+
+func loadUser(ctx context.Context, userID string) (*userResolver, error) {
+	user, found, err := loadersFrom(ctx).users.Load(ctx, userID)
+	if err != nil || !found {
+		return nil, withCode(err)
+	}
+	return &userResolver{user: user}, nil
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(r.user.UserID)
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *userResolver) IsActive() bool {
+	return r.user.IsActive
+}
+
+func (r *userResolver) Team(ctx context.Context) (*teamResolver, error) {
+	if r.user.TeamName == "" {
+		return nil, nil
+	}
+	team, found, err := loadersFrom(ctx).teams.Load(ctx, r.user.TeamName)
+	if err != nil || !found {
+		return nil, withCode(err)
+	}
+	return &teamResolver{team: team}, nil
+}
+
+type statusFilter struct {
+	Status *string
+}
+
+func (r *userResolver) Reviews(ctx context.Context, args statusFilter) ([]*prResolver, error) {
+	return loadUserPRs(ctx, loadersFrom(ctx).reviews, r.user.UserID, args.Status)
+}
+
+func (r *userResolver) Authored(ctx context.Context, args statusFilter) ([]*prResolver, error) {
+	return loadUserPRs(ctx, loadersFrom(ctx).authored, r.user.UserID, args.Status)
+}
+
+func loadUserPRs(ctx context.Context, ids *Loader[[]string], userID string, status *string) ([]*prResolver, error) {
+	prIDs, _, err := ids.Load(ctx, userID)
+	if err != nil {
+		return nil, withCode(err)
+	}
+	prs, err := loadersFrom(ctx).prs.LoadMany(ctx, prIDs)
+	if err != nil {
+		return nil, withCode(err)
+	}
+
+	resolvers := make([]*prResolver, 0, len(prs))
+	for _, v := range prs {
+		if status != nil && v.Status != *status {
+			continue
+		}
+		resolvers = append(resolvers, &prResolver{pr: v})
+	}
+	return resolvers, nil
+}
+
+type prResolver struct {
+	pr graph.PullRequest
+}
+
+func (r *prResolver) ID() graphql.ID {
+	return graphql.ID(r.pr.PullRequestID)
+}
+
+func (r *prResolver) Name() string {
+	return r.pr.PullRequestName
+}
+
+func (r *prResolver) Status() string {
+	return r.pr.Status
+}
+
+func (r *prResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.pr.AuthorID)
+}
+
+func (r *prResolver) Reviewers(ctx context.Context) ([]*userResolver, error) {
+	users, err := loadersFrom(ctx).users.LoadMany(ctx, r.pr.AssignedReviewers)
+	if err != nil {
+		return nil, withCode(err)
+	}
+
+	resolvers := make([]*userResolver, len(users))
+	for i, v := range users {
+		resolvers[i] = &userResolver{user: v}
+	}
+	return resolvers, nil
+}
+
+func (r *prResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.pr.CreatedAt}
+}
+
+func (r *prResolver) MergedAt() *graphql.Time {
+	// У открытых PR в базе лежит нулевое время мержа.
+	if r.pr.Status != "MERGED" || r.pr.MergedAt.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: r.pr.MergedAt}
+}
+
+type reassignResolver struct {
+	reassigned *prDto.ReassignedRewiew
+}
+
+func (r *reassignResolver) PullRequest() *prResolver {
+	return &prResolver{pr: graph.PullRequest(r.reassigned.Pr)}
+}
+
+func (r *reassignResolver) ReplacedBy(ctx context.Context) (*userResolver, error) {
+	if r.reassigned.NewReviewer == "" {
+		return nil, nil
+	}
+	return loadUser(ctx, r.reassigned.NewReviewer)
+}
+
+func (r *reassignResolver) Escalated() bool {
+	return r.reassigned.Escalated
+}
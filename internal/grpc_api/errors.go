@@ -0,0 +1,53 @@
+package grpcapi
+
+import (
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+// errorDomain - домен в ErrorInfo, reason в нем совпадает с кодом ошибки REST API.
+const errorDomain = "pull_request_service"
+
+// toStatus переводит ошибку usecase в статус gRPC. Код ошибки берется из utils.ErrorCode, как в REST.
+// Текст неизвестных ошибок клиенту не показывается, он пишется в лог.
+func toStatus(err error) error {
+	reason, ok := utils.ErrorCode(err)
+	if !ok {
+		log.Printf("grpc internal error: %v", err)
+		return withReason(status.New(codes.Internal, "internal error"), utils.Internal)
+	}
+	return withReason(status.New(grpcCode(reason), err.Error()), reason)
+}
+
+// grpcCode - код gRPC для кода ошибки API. Остальные известные ошибки - нарушенные условия операции.
+func grpcCode(reason string) codes.Code {
+	switch reason {
+	case utils.NotFound, utils.UserNotFound:
+		return codes.NotFound
+	case utils.PrExists, utils.TeamExists, utils.UsernameTaken:
+		return codes.AlreadyExists
+	case utils.InvalidSettings, utils.InvalidWindow:
+		return codes.InvalidArgument
+	}
+	return codes.FailedPrecondition
+}
+
+func withReason(st *status.Status, reason string) error {
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func invalidArgument(msg string) error {
+	return status.Error(codes.InvalidArgument, msg)
+}
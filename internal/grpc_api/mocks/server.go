@@ -0,0 +1,211 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: server.go
+//
+// Generated by this command:
+//
+//	mockgen --source=server.go --destination=mocks/server.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pullrequests "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	teams "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	users "github.com/qwerty268/pull_request_service/internal/usecases/users"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPRUsecase is a mock of PRUsecase interface.
+type MockPRUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPRUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPRUsecaseMockRecorder is the mock recorder for MockPRUsecase.
+type MockPRUsecaseMockRecorder struct {
+	mock *MockPRUsecase
+}
+
+// NewMockPRUsecase creates a new mock instance.
+func NewMockPRUsecase(ctrl *gomock.Controller) *MockPRUsecase {
+	mock := &MockPRUsecase{ctrl: ctrl}
+	mock.recorder = &MockPRUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPRUsecase) EXPECT() *MockPRUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreatePR mocks base method.
+func (m *MockPRUsecase) CreatePR(ctx context.Context, pr pullrequests.CreatePROpst) (*pullrequests.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePR", ctx, pr)
+	ret0, _ := ret[0].(*pullrequests.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePR indicates an expected call of CreatePR.
+func (mr *MockPRUsecaseMockRecorder) CreatePR(ctx, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePR", reflect.TypeOf((*MockPRUsecase)(nil).CreatePR), ctx, pr)
+}
+
+// GetPR mocks base method.
+func (m *MockPRUsecase) GetPR(ctx context.Context, prID string) (*pullrequests.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPR", ctx, prID)
+	ret0, _ := ret[0].(*pullrequests.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPR indicates an expected call of GetPR.
+func (mr *MockPRUsecaseMockRecorder) GetPR(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPR", reflect.TypeOf((*MockPRUsecase)(nil).GetPR), ctx, prID)
+}
+
+// MergePR mocks base method.
+func (m *MockPRUsecase) MergePR(ctx context.Context, prID string) (*pullrequests.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePR", ctx, prID)
+	ret0, _ := ret[0].(*pullrequests.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergePR indicates an expected call of MergePR.
+func (mr *MockPRUsecaseMockRecorder) MergePR(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePR", reflect.TypeOf((*MockPRUsecase)(nil).MergePR), ctx, prID)
+}
+
+// ReassignReviewer mocks base method.
+func (m *MockPRUsecase) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*pullrequests.ReassignedRewiew, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignReviewer", ctx, prID, oldUserID)
+	ret0, _ := ret[0].(*pullrequests.ReassignedRewiew)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignReviewer indicates an expected call of ReassignReviewer.
+func (mr *MockPRUsecaseMockRecorder) ReassignReviewer(ctx, prID, oldUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignReviewer", reflect.TypeOf((*MockPRUsecase)(nil).ReassignReviewer), ctx, prID, oldUserID)
+}
+
+// MockTeamUsecase is a mock of TeamUsecase interface.
+type MockTeamUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamUsecaseMockRecorder is the mock recorder for MockTeamUsecase.
+type MockTeamUsecaseMockRecorder struct {
+	mock *MockTeamUsecase
+}
+
+// NewMockTeamUsecase creates a new mock instance.
+func NewMockTeamUsecase(ctrl *gomock.Controller) *MockTeamUsecase {
+	mock := &MockTeamUsecase{ctrl: ctrl}
+	mock.recorder = &MockTeamUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamUsecase) EXPECT() *MockTeamUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddTeam mocks base method.
+func (m *MockTeamUsecase) AddTeam(ctx context.Context, team teams.Team) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTeam", ctx, team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTeam indicates an expected call of AddTeam.
+func (mr *MockTeamUsecaseMockRecorder) AddTeam(ctx, team any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeam", reflect.TypeOf((*MockTeamUsecase)(nil).AddTeam), ctx, team)
+}
+
+// GetTeam mocks base method.
+func (m *MockTeamUsecase) GetTeam(ctx context.Context, teamName string) (*teams.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", ctx, teamName)
+	ret0, _ := ret[0].(*teams.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockTeamUsecaseMockRecorder) GetTeam(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockTeamUsecase)(nil).GetTeam), ctx, teamName)
+}
+
+// MockUserUsecase is a mock of UserUsecase interface.
+type MockUserUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUserUsecaseMockRecorder is the mock recorder for MockUserUsecase.
+type MockUserUsecaseMockRecorder struct {
+	mock *MockUserUsecase
+}
+
+// NewMockUserUsecase creates a new mock instance.
+func NewMockUserUsecase(ctrl *gomock.Controller) *MockUserUsecase {
+	mock := &MockUserUsecase{ctrl: ctrl}
+	mock.recorder = &MockUserUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserUsecase) EXPECT() *MockUserUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetUserReviewRequests mocks base method.
+func (m *MockUserUsecase) GetUserReviewRequests(ctx context.Context, userID string) ([]users.PullRequestShort, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReviewRequests", ctx, userID)
+	ret0, _ := ret[0].([]users.PullRequestShort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReviewRequests indicates an expected call of GetUserReviewRequests.
+func (mr *MockUserUsecaseMockRecorder) GetUserReviewRequests(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviewRequests", reflect.TypeOf((*MockUserUsecase)(nil).GetUserReviewRequests), ctx, userID)
+}
+
+// SetUserActive mocks base method.
+func (m *MockUserUsecase) SetUserActive(ctx context.Context, userID string, isActive bool) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActive", ctx, userID, isActive)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockUserUsecaseMockRecorder) SetUserActive(ctx, userID, isActive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockUserUsecase)(nil).SetUserActive), ctx, userID, isActive)
+}
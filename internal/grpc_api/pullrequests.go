@@ -0,0 +1,98 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/qwerty268/pull_request_service/internal/grpc_api/pb"
+	prDto "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+)
+
+type prServer struct {
+	pb.UnimplementedPullRequestServiceServer
+	usecase PRUsecase
+}
+
+func (s *prServer) CreatePullRequest(ctx context.Context, req *pb.CreatePullRequestRequest) (*pb.PullRequest, error) {
+	if req.GetPullRequestId() == "" || req.GetPullRequestName() == "" || req.GetAuthorId() == "" {
+		return nil, invalidArgument("pull_request_id, pull_request_name and author_id are required")
+	}
+
+	pr, err := s.usecase.CreatePR(ctx, prDto.CreatePROpst{
+		PullRequestID:   req.GetPullRequestId(),
+		PullRequestName: req.GetPullRequestName(),
+		AuthorID:        req.GetAuthorId(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbPullRequest(pr), nil
+}
+
+func (s *prServer) GetPullRequest(ctx context.Context, req *pb.GetPullRequestRequest) (*pb.PullRequest, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+
+	pr, err := s.usecase.GetPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbPullRequest(pr), nil
+}
+
+func (s *prServer) MergePullRequest(ctx context.Context, req *pb.MergePullRequestRequest) (*pb.PullRequest, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+
+	pr, err := s.usecase.MergePR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbPullRequest(pr), nil
+}
+
+func (s *prServer) ReassignReviewer(ctx context.Context, req *pb.ReassignReviewerRequest) (*pb.ReassignReviewerResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetOldUserId() == "" {
+		return nil, invalidArgument("pull_request_id and old_user_id are required")
+	}
+
+	reassigned, err := s.usecase.ReassignReviewer(ctx, req.GetPullRequestId(), req.GetOldUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ReassignReviewerResponse{
+		Pr:         toPbPullRequest(&reassigned.Pr),
+		ReplacedBy: reassigned.NewReviewer,
+		Escalated:  reassigned.Escalated,
+	}, nil
+}
+
+func toPbPullRequest(pr *prDto.PullRequest) *pb.PullRequest {
+	resp := &pb.PullRequest{
+		PullRequestId:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorID,
+		Status:            toPbStatus(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         timestamppb.New(pr.CreatedAt),
+	}
+	// У открытых PR в базе лежит нулевое время мержа.
+	if resp.Status == pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED && !pr.MergedAt.IsZero() {
+		resp.MergedAt = timestamppb.New(pr.MergedAt)
+	}
+	return resp
+}
+
+func toPbStatus(status string) pb.PullRequestStatus {
+	switch status {
+	case "OPEN":
+		return pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case "MERGED":
+		return pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	default:
+		return pb.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,41 @@
+//go:generate mockgen --source=server.go --destination=mocks/server.go -package=mocks
+//go:generate protoc --proto_path=proto --go_out=pb --go_opt=paths=source_relative --go-grpc_out=pb --go-grpc_opt=paths=source_relative pr_service.proto
+
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/qwerty268/pull_request_service/internal/grpc_api/pb"
+	prDto "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	teamDto "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	userDto "github.com/qwerty268/pull_request_service/internal/usecases/users"
+)
+
+type PRUsecase interface {
+	CreatePR(ctx context.Context, pr prDto.CreatePROpst) (*prDto.PullRequest, error)
+	GetPR(ctx context.Context, prID string) (*prDto.PullRequest, error)
+	MergePR(ctx context.Context, prID string) (*prDto.PullRequest, error)
+	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*prDto.ReassignedRewiew, error)
+}
+
+type TeamUsecase interface {
+	AddTeam(ctx context.Context, team teamDto.Team) error
+	GetTeam(ctx context.Context, teamName string) (*teamDto.Team, error)
+}
+
+type UserUsecase interface {
+	SetUserActive(ctx context.Context, userID string, isActive bool) (*userDto.User, error)
+	GetUserReviewRequests(ctx context.Context, userID string) ([]userDto.PullRequestShort, error)
+}
+
+// NewServer собирает gRPC-сервер поверх тех же usecase, что и REST API.
+func NewServer(prUsecase PRUsecase, teamUsecase TeamUsecase, userUsecase UserUsecase, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	pb.RegisterPullRequestServiceServer(s, &prServer{usecase: prUsecase})
+	pb.RegisterTeamServiceServer(s, &teamServer{usecase: teamUsecase})
+	pb.RegisterUserServiceServer(s, &userServer{usecase: userUsecase})
+	return s
+}
@@ -0,0 +1,271 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/qwerty268/pull_request_service/internal/grpc_api/mocks"
+	"github.com/qwerty268/pull_request_service/internal/grpc_api/pb"
+	prDto "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	teamDto "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	userDto "github.com/qwerty268/pull_request_service/internal/usecases/users"
+)
+
+type testServer struct {
+	pr   *mocks.MockPRUsecase
+	team *mocks.MockTeamUsecase
+	user *mocks.MockUserUsecase
+	conn *grpc.ClientConn
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ctrl := gomock.NewController(t)
+	ts := &testServer{
+		pr:   mocks.NewMockPRUsecase(ctrl),
+		team: mocks.NewMockTeamUsecase(ctrl),
+		user: mocks.NewMockUserUsecase(ctrl),
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(ts.pr, ts.team, ts.user)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	ts.conn = conn
+	return ts
+}
+
+func requireStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+	if reason == "" {
+		return
+	}
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, reason, info.GetReason())
+	assert.Equal(t, errorDomain, info.GetDomain())
+}
+
+func TestPullRequestService(t *testing.T) {
+	ts := newTestServer(t)
+	client := pb.NewPullRequestServiceClient(ts.conn)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(time.Hour)
+
+	t.Run("create", func(t *testing.T) {
+		ts.pr.EXPECT().
+			CreatePR(gomock.Any(), prDto.CreatePROpst{PullRequestID: "pr1", PullRequestName: "feat", AuthorID: "u1"}).
+			Return(&prDto.PullRequest{
+				PullRequestID:     "pr1",
+				PullRequestName:   "feat",
+				AuthorID:          "u1",
+				Status:            "OPEN",
+				AssignedReviewers: []string{"u2", "u3"},
+				CreatedAt:         createdAt,
+			}, nil)
+
+		pr, err := client.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+			PullRequestId:   "pr1",
+			PullRequestName: "feat",
+			AuthorId:        "u1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, pr.GetStatus())
+		assert.Equal(t, []string{"u2", "u3"}, pr.GetAssignedReviewers())
+		assert.True(t, createdAt.Equal(pr.GetCreatedAt().AsTime()))
+		assert.Nil(t, pr.GetMergedAt())
+	})
+
+	t.Run("create invalid", func(t *testing.T) {
+		_, err := client.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{PullRequestId: "pr1"})
+		requireStatus(t, err, codes.InvalidArgument, "")
+	})
+
+	t.Run("create exists", func(t *testing.T) {
+		ts.pr.EXPECT().
+			CreatePR(gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("failed to add pr: %w", prDto.ErrAlreadyExists))
+
+		_, err := client.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+			PullRequestId:   "pr1",
+			PullRequestName: "feat",
+			AuthorId:        "u1",
+		})
+		requireStatus(t, err, codes.AlreadyExists, "PR_EXISTS")
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		ts.pr.EXPECT().
+			GetPR(gomock.Any(), "pr404").
+			Return(nil, fmt.Errorf("failed to get pr from storage: %w", prDto.ErrNotFound))
+
+		_, err := client.GetPullRequest(ctx, &pb.GetPullRequestRequest{PullRequestId: "pr404"})
+		requireStatus(t, err, codes.NotFound, "NOT_FOUND")
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		ts.pr.EXPECT().
+			MergePR(gomock.Any(), "pr1").
+			Return(&prDto.PullRequest{PullRequestID: "pr1", Status: "MERGED", CreatedAt: createdAt, MergedAt: mergedAt}, nil)
+
+		pr, err := client.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "pr1"})
+		require.NoError(t, err)
+		assert.Equal(t, pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED, pr.GetStatus())
+		assert.True(t, mergedAt.Equal(pr.GetMergedAt().AsTime()))
+	})
+
+	t.Run("merge not enough reviewers", func(t *testing.T) {
+		ts.pr.EXPECT().
+			MergePR(gomock.Any(), "pr1").
+			Return(nil, fmt.Errorf("pr has 1 of 2 required reviewers: %w", prDto.ErrNotEnoughReviewers))
+
+		_, err := client.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "pr1"})
+		requireStatus(t, err, codes.FailedPrecondition, "NOT_ENOUGH_REVIEWERS")
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		ts.pr.EXPECT().
+			ReassignReviewer(gomock.Any(), "pr1", "u2").
+			Return(&prDto.ReassignedRewiew{
+				Pr:          prDto.PullRequest{PullRequestID: "pr1", Status: "OPEN", AssignedReviewers: []string{"u4"}},
+				NewReviewer: "u4",
+			}, nil)
+
+		resp, err := client.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr1", OldUserId: "u2"})
+		require.NoError(t, err)
+		assert.Equal(t, "u4", resp.GetReplacedBy())
+		assert.Equal(t, []string{"u4"}, resp.GetPr().GetAssignedReviewers())
+	})
+
+	t.Run("reassign merged", func(t *testing.T) {
+		ts.pr.EXPECT().
+			ReassignReviewer(gomock.Any(), "pr1", "u2").
+			Return(nil, fmt.Errorf("cannot reassign: %w", prDto.ErrPRMerged))
+
+		_, err := client.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr1", OldUserId: "u2"})
+		requireStatus(t, err, codes.FailedPrecondition, "PR_MERGED")
+	})
+
+	t.Run("internal", func(t *testing.T) {
+		ts.pr.EXPECT().
+			GetPR(gomock.Any(), "pr1").
+			Return(nil, fmt.Errorf("failed to get pr from storage: connection refused"))
+
+		_, err := client.GetPullRequest(ctx, &pb.GetPullRequestRequest{PullRequestId: "pr1"})
+		requireStatus(t, err, codes.Internal, "INTERNAL")
+		assert.Equal(t, "internal error", status.Convert(err).Message())
+	})
+}
+
+func TestTeamService(t *testing.T) {
+	ts := newTestServer(t)
+	client := pb.NewTeamServiceClient(ts.conn)
+	ctx := context.Background()
+
+	t.Run("add", func(t *testing.T) {
+		ts.team.EXPECT().
+			AddTeam(gomock.Any(), teamDto.Team{
+				TeamName: "backend",
+				Members:  []teamDto.TeamMember{{UserID: "u1", Username: "alice", IsActive: true, Role: "lead"}},
+			}).
+			Return(nil)
+
+		team, err := client.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{
+			TeamName: "backend",
+			Members:  []*pb.TeamMember{{UserId: "u1", Username: "alice", IsActive: true, Role: "lead"}},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "backend", team.GetTeamName())
+		assert.Len(t, team.GetMembers(), 1)
+	})
+
+	t.Run("add invalid role", func(t *testing.T) {
+		_, err := client.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{
+			TeamName: "backend",
+			Members:  []*pb.TeamMember{{UserId: "u1", Username: "alice", Role: "owner"}},
+		}})
+		requireStatus(t, err, codes.InvalidArgument, "")
+	})
+
+	t.Run("add exists", func(t *testing.T) {
+		ts.team.EXPECT().
+			AddTeam(gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("failed to add new teram: %w", teamDto.ErrAlreadyExists))
+
+		_, err := client.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{TeamName: "backend"}})
+		requireStatus(t, err, codes.AlreadyExists, "TEAM_EXISTS")
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		ts.team.EXPECT().
+			GetTeam(gomock.Any(), "ghost").
+			Return(nil, fmt.Errorf("failed to get team: %w", teamDto.ErrNotFound))
+
+		_, err := client.GetTeam(ctx, &pb.GetTeamRequest{TeamName: "ghost"})
+		requireStatus(t, err, codes.NotFound, "NOT_FOUND")
+	})
+}
+
+func TestUserService(t *testing.T) {
+	ts := newTestServer(t)
+	client := pb.NewUserServiceClient(ts.conn)
+	ctx := context.Background()
+
+	t.Run("set is active", func(t *testing.T) {
+		ts.user.EXPECT().
+			SetUserActive(gomock.Any(), "u1", false).
+			Return(&userDto.User{UserID: "u1", Username: "alice", TeamName: "backend"}, nil)
+
+		user, err := client.SetIsActive(ctx, &pb.SetIsActiveRequest{UserId: "u1"})
+		require.NoError(t, err)
+		assert.Equal(t, "backend", user.GetTeamName())
+		assert.False(t, user.GetIsActive())
+	})
+
+	t.Run("set is active not found", func(t *testing.T) {
+		ts.user.EXPECT().
+			SetUserActive(gomock.Any(), "ghost", true).
+			Return(nil, fmt.Errorf("failed to find user: %w", userDto.ErrNotFound))
+
+		_, err := client.SetIsActive(ctx, &pb.SetIsActiveRequest{UserId: "ghost", IsActive: true})
+		requireStatus(t, err, codes.NotFound, "USER_NOT_FOUND")
+	})
+
+	t.Run("get review", func(t *testing.T) {
+		ts.user.EXPECT().
+			GetUserReviewRequests(gomock.Any(), "u2").
+			Return([]userDto.PullRequestShort{{PullRequestID: "pr1", AuthorID: "u1", Status: "OPEN"}}, nil)
+
+		resp, err := client.GetReview(ctx, &pb.GetReviewRequest{UserId: "u2"})
+		require.NoError(t, err)
+		require.Len(t, resp.GetPullRequests(), 1)
+		assert.Equal(t, pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, resp.GetPullRequests()[0].GetStatus())
+	})
+}
@@ -0,0 +1,75 @@
+package grpcapi
+
+import (
+	"context"
+	"slices"
+
+	"github.com/qwerty268/pull_request_service/internal/grpc_api/pb"
+	teamDto "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+)
+
+var memberRoles = []string{"", "member", "lead", "maintainer"}
+
+type teamServer struct {
+	pb.UnimplementedTeamServiceServer
+	usecase TeamUsecase
+}
+
+func (s *teamServer) AddTeam(ctx context.Context, req *pb.AddTeamRequest) (*pb.Team, error) {
+	team := req.GetTeam()
+	if team.GetTeamName() == "" {
+		return nil, invalidArgument("team.team_name is required")
+	}
+
+	ucTeam := teamDto.Team{
+		TeamName: team.GetTeamName(),
+		Members:  make([]teamDto.TeamMember, len(team.GetMembers())),
+	}
+	for i, m := range team.GetMembers() {
+		if m.GetUserId() == "" || m.GetUsername() == "" {
+			return nil, invalidArgument("member user_id and username are required")
+		}
+		if !slices.Contains(memberRoles, m.GetRole()) {
+			return nil, invalidArgument("member role must be one of member, lead, maintainer")
+		}
+		ucTeam.Members[i] = teamDto.TeamMember{
+			UserID:   m.GetUserId(),
+			Username: m.GetUsername(),
+			IsActive: m.GetIsActive(),
+			Role:     m.GetRole(),
+		}
+	}
+
+	if err := s.usecase.AddTeam(ctx, ucTeam); err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbTeam(&ucTeam), nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	team, err := s.usecase.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbTeam(team), nil
+}
+
+func toPbTeam(team *teamDto.Team) *pb.Team {
+	resp := &pb.Team{
+		TeamName: team.TeamName,
+		Members:  make([]*pb.TeamMember, len(team.Members)),
+	}
+	for i, m := range team.Members {
+		resp.Members[i] = &pb.TeamMember{
+			UserId:   m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+			Role:     m.Role,
+		}
+	}
+	return resp
+}
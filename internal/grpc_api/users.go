@@ -0,0 +1,54 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/qwerty268/pull_request_service/internal/grpc_api/pb"
+)
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	usecase UserUsecase
+}
+
+func (s *userServer) SetIsActive(ctx context.Context, req *pb.SetIsActiveRequest) (*pb.User, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.usecase.SetUserActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.User{
+		UserId:   user.UserID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}, nil
+}
+
+func (s *userServer) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	prs, err := s.usecase.GetUserReviewRequests(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.GetReviewResponse{
+		UserId:       req.GetUserId(),
+		PullRequests: make([]*pb.PullRequestShort, len(prs)),
+	}
+	for i, pr := range prs {
+		resp.PullRequests[i] = &pb.PullRequestShort{
+			PullRequestId:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorId:        pr.AuthorID,
+			Status:          toPbStatus(pr.Status),
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultRefreshInterval - как часто пересчитываются гейджи по командам.
+const DefaultRefreshInterval = time.Minute
+
+type TeamActivity struct {
+	TeamName    string
+	OpenPRs     int
+	ActiveUsers int
+}
+
+// RunTeamGauges обновляет гейджи открытых PR и активных пользователей сразу и далее раз в interval,
+// пока не отменен ctx. Команды, пропавшие из выборки, удаляются из метрик.
+func RunTeamGauges(ctx context.Context, interval time.Duration, fetch func(ctx context.Context) ([]TeamActivity, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := refreshTeamGauges(ctx, fetch); err != nil {
+			log.Printf("failed to refresh team gauges: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func refreshTeamGauges(ctx context.Context, fetch func(ctx context.Context) ([]TeamActivity, error)) error {
+	teams, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	openPRs.Reset()
+	activeUsers.Reset()
+	for _, t := range teams {
+		openPRs.WithLabelValues(t.TeamName).Set(float64(t.OpenPRs))
+		activeUsers.WithLabelValues(t.TeamName).Set(float64(t.ActiveUsers))
+	}
+	return nil
+}
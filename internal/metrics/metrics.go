@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "pr_service"
+
+// Registry - реестр метрик сервиса, отдается на /metrics.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	prsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_created_total",
+		Help:      "Pull requests created.",
+	})
+
+	prsMerged = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_merged_total",
+		Help:      "Pull requests merged.",
+	})
+
+	reviewersReassigned = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviewers_reassigned_total",
+		Help:      "Reviewers reassigned on open pull requests.",
+	})
+
+	noCandidate = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviewer_no_candidate_total",
+		Help:      "Reassignments that failed because no candidate was found.",
+	})
+
+	dbDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Storage method latency.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"storage", "method"})
+
+	apiRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_version_requests_total",
+		Help:      "API requests by version and whether a deprecated root alias was used.",
+	}, []string{"version", "alias"})
+
+	specMismatches = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "spec_response_mismatch_total",
+		Help:      "Responses that do not match the OpenAPI spec, by method and route.",
+	}, []string{"method", "route"})
+
+	openPRs = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "open_pull_requests",
+		Help:      "Open pull requests by author team.",
+	}, []string{"team"})
+
+	activeUsers = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_users",
+		Help:      "Active users by team.",
+	}, []string{"team"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+func PRCreated() {
+	prsCreated.Inc()
+}
+
+func PRMerged() {
+	prsMerged.Inc()
+}
+
+func ReviewerReassigned() {
+	reviewersReassigned.Inc()
+}
+
+func NoCandidate() {
+	noCandidate.Inc()
+}
+
+// APIRequest считает запрос к версии API. alias - запрос пришел на устаревший путь без версии.
+func APIRequest(version string, alias bool) {
+	apiRequests.WithLabelValues(version, strconv.FormatBool(alias)).Inc()
+}
+
+// SpecResponseMismatch считает ответ, не совпавший со спецификацией.
+func SpecResponseMismatch(method, route string) {
+	specMismatches.WithLabelValues(method, route).Inc()
+}
+
+// ObserveQuery записывает длительность метода хранилища.
+// Использование: defer metrics.ObserveQuery("teams", "AddTeam", time.Now()).
+func ObserveQuery(storage, method string, start time.Time) {
+	dbDuration.WithLabelValues(storage, method).Observe(time.Since(start).Seconds())
+}
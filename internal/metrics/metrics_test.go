@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/team/get", func(c echo.Context) error {
+		if c.QueryParam("team_name") == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	RegisterHandlers(e)
+
+	for _, target := range []string{"/team/get?team_name=backend", "/team/get", "/team/get", "/nope"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/team/get", "200")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/team/get", "400")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, unknownRoute, "404")))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(),
+		`pr_service_http_requests_total{method="GET",route="/team/get",status="400"} 2`)
+}
+
+func TestRefreshTeamGauges(t *testing.T) {
+	ctx := context.Background()
+
+	err := refreshTeamGauges(ctx, func(context.Context) ([]TeamActivity, error) {
+		return []TeamActivity{
+			{TeamName: "backend", OpenPRs: 3, ActiveUsers: 5},
+			{TeamName: "frontend", OpenPRs: 1, ActiveUsers: 2},
+		}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, testutil.ToFloat64(openPRs.WithLabelValues("backend")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(activeUsers.WithLabelValues("frontend")))
+
+	// Удаленная команда пропадает из метрик.
+	err = refreshTeamGauges(ctx, func(context.Context) ([]TeamActivity, error) {
+		return []TeamActivity{{TeamName: "backend", OpenPRs: 0, ActiveUsers: 4}}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, testutil.CollectAndCount(openPRs))
+	assert.Equal(t, 4.0, testutil.ToFloat64(activeUsers.WithLabelValues("backend")))
+
+	// При ошибке прежние значения сохраняются.
+	err = refreshTeamGauges(ctx, func(context.Context) ([]TeamActivity, error) {
+		return nil, errors.New("db down")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 4.0, testutil.ToFloat64(activeUsers.WithLabelValues("backend")))
+}
+
+func TestObserveQuery(t *testing.T) {
+	ObserveQuery("teams", "GetTeam", time.Now())
+
+	count, err := testutil.GatherAndCount(Registry, "pr_service_db_query_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
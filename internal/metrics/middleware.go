@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unknownRoute - метка для запросов, не попавших ни в один маршрут, чтобы не плодить серии.
+const unknownRoute = "unknown"
+
+func RegisterHandlers(e *echo.Echo) {
+	e.GET("/metrics", echo.WrapHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})))
+}
+
+// Middleware считает запросы и их длительность по шаблону маршрута и статусу ответа.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				// Отдаем ошибку обработчику echo сразу, чтобы узнать итоговый статус.
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = unknownRoute
+			}
+			status := strconv.Itoa(c.Response().Status)
+			method := c.Request().Method
+
+			httpRequests.WithLabelValues(method, route, status).Inc()
+			httpDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+
+	"github.com/qwerty268/pull_request_service/internal/metrics"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+// Middleware проверяет запросы и ответы по спецификации.
+// Невалидный запрос получает 400 до вызова обработчика. Ответ не по спецификации с enforceResponses
+// заменяется на 500 (полная ошибка попадает в лог через HTTPErrorHandler), без него уходит клиенту как есть
+// с предупреждением в логе. В обоих случаях расхождение считается в метрике spec_response_mismatch_total.
+// У потоковых операций (x-streaming) проверяется только запрос.
+// Пути, которых нет в спецификации, пропускаются как есть: ими занимается роутер echo.
+func Middleware(doc *openapi3.T, enforceResponses bool) (echo.MiddlewareFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("build router: %v", err)
+	}
+
+	options := &openapi3filter.Options{
+		// Токен проверяет сам обработчик, здесь важна только форма запроса.
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: true,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			route, pathParams, err := router.FindRoute(req)
+			if err != nil {
+				if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+					return next(c)
+				}
+				return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+
+			requestInput := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(req.Context(), requestInput); err != nil {
+				return requestValidationError(err)
+			}
+
+			if streaming(route.Operation) {
+				return next(c)
+			}
+			return validateResponse(c, next, requestInput, enforceResponses)
+		}
+	}, nil
+}
+
+// streamingExtension помечает в спецификации потоковые операции (SSE, WebSocket). Их ответ не буферизуется
+// и не проверяется: он не заканчивается, пока клиент подключен.
+const streamingExtension = "x-streaming"
+
+func streaming(op *openapi3.Operation) bool {
+	v, _ := op.Extensions[streamingExtension].(bool)
+	return v
+}
+
+// requestValidationError переводит ошибку kin-openapi в ошибки полей, как у валидатора обработчиков.
+func requestValidationError(err error) error {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	field := paramName(reqErr)
+	rule := "schema"
+	message := reqErr.Reason
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(reqErr.Err, &schemaErr) {
+		if path := fieldPath(schemaErr.JSONPointer()); path != "" {
+			field = strings.TrimPrefix(field+"."+path, ".")
+		}
+		rule = schemaErr.SchemaField
+		message = schemaErr.Reason
+	} else if errors.Is(reqErr.Err, openapi3filter.ErrInvalidRequired) {
+		rule = "required"
+		message = "is required"
+	}
+
+	if field == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, reqErr.Error())
+	}
+	return &utils.ValidationError{Fields: []utils.FieldError{{Field: field, Rule: rule, Message: message}}}
+}
+
+// paramName - имя параметра, к которому относится ошибка. Для тела запроса пустое.
+func paramName(reqErr *openapi3filter.RequestError) string {
+	if reqErr.Parameter != nil {
+		return reqErr.Parameter.Name
+	}
+	return ""
+}
+
+// fieldPath переводит JSON pointer в путь вида members[0].user_id.
+func fieldPath(pointer []string) string {
+	var b strings.Builder
+	for _, p := range pointer {
+		if _, err := strconv.Atoi(p); err == nil {
+			b.WriteString("[" + p + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// bufferedWriter придерживает ответ, пока он не проверен.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func validateResponse(c echo.Context, next echo.HandlerFunc, requestInput *openapi3filter.RequestValidationInput, enforce bool) error {
+	resp := c.Response()
+	original := resp.Writer
+	buffered := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+	resp.Writer = buffered
+
+	if err := next(c); err != nil {
+		// Ошибку рендерим сразу, чтобы проверить и ее тело.
+		c.Error(err)
+	}
+	resp.Writer = original
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 buffered.status,
+		Header:                 resp.Header(),
+		Options:                requestInput.Options,
+	}
+	responseInput.SetBodyBytes(buffered.body.Bytes())
+
+	if err := openapi3filter.ValidateResponse(context.Background(), responseInput); err != nil {
+		metrics.SpecResponseMismatch(c.Request().Method, c.Path())
+		if enforce {
+			// Сбрасываем уже "отправленный" ответ и отдаем ошибку обработчику echo.
+			resp.Committed = false
+			resp.Status = http.StatusOK
+			resp.Size = 0
+			resp.Header().Del(echo.HeaderContentType)
+			resp.Header().Del(echo.HeaderContentLength)
+			return fmt.Errorf("response does not match spec: %w", err)
+		}
+		c.Logger().Warnf("response does not match spec: %s %s %d: %v", c.Request().Method, c.Path(), buffered.status, err)
+	}
+
+	original.WriteHeader(buffered.status)
+	_, err := io.Copy(original, &buffered.body)
+	return err
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+const mimeSCIM = "application/scim+json"
+
+// aliasedPrefix - версия, у которой в корне висят устаревшие синонимы.
+const aliasedPrefix = "/api/v1"
+
+func init() {
+	// В ошибках валидации достаточно пути до поля, схема целиком только мешает.
+	openapi3.SchemaErrorDetailsDisabled = true
+	openapi3filter.RegisterBodyDecoder(mimeSCIM, openapi3filter.JSONBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.FileBodyDecoder)
+}
+
+// Load разбирает и проверяет встроенную спецификацию.
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(specYAML)
+	if err != nil {
+		return nil, fmt.Errorf("load spec: %v", err)
+	}
+	addRootAliases(doc)
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("validate spec: %v", err)
+	}
+	return doc, nil
+}
+
+// addRootAliases дублирует пути v1 в корень с пометкой deprecated, чтобы валидатор пропускал
+// запросы старых клиентов, а в документации было видно, что синонимы уходят.
+func addRootAliases(doc *openapi3.T) {
+	for path, item := range doc.Paths.Map() {
+		aliasPath, ok := strings.CutPrefix(path, aliasedPrefix)
+		if !ok {
+			continue
+		}
+		alias := &openapi3.PathItem{
+			Summary:     item.Summary,
+			Description: item.Description,
+			Parameters:  item.Parameters,
+		}
+		for method, op := range item.Operations() {
+			aliasOp := *op
+			aliasOp.Deprecated = true
+			aliasOp.Description = strings.TrimSpace(fmt.Sprintf("Устаревший синоним %s %s.\n\n%s", method, path, op.Description))
+			alias.SetOperation(method, &aliasOp)
+		}
+		doc.Paths.Set(aliasPath, alias)
+	}
+}
+
+type Handlers struct {
+	doc *openapi3.T
+}
+
+func NewHandlers(doc *openapi3.T) *Handlers {
+	return &Handlers{
+		doc: doc,
+	}
+}
+
+func (h *Handlers) RegisterHandlers(e *echo.Echo) {
+	e.GET("/openapi.json", h.GetSpec)
+	e.GET("/docs", h.GetDocs)
+}
+
+// GetSpec отдает спецификацию в JSON
+func (h *Handlers) GetSpec(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.doc)
+}
+
+// GetDocs отдает Swagger UI, который читает /openapi.json
+func (h *Handlers) GetDocs(c echo.Context) error {
+	return c.HTML(http.StatusOK, swaggerUI)
+}
+
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>PR Reviewer Assignment Service</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	teamsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/teams"
+	teamsMocks "github.com/qwerty268/pull_request_service/internal/rest_api/teams/mocks"
+	"github.com/qwerty268/pull_request_service/internal/rest_api/versions"
+	ucTeams "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+func newTestServer(t *testing.T, usecase teamsHandlers.Usecase, enforceResponses bool) *echo.Echo {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	validator, err := Middleware(doc, enforceResponses)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Validator = utils.NewHTTPRequestValidator()
+	e.HTTPErrorHandler = utils.HTTPErrorHandler
+	e.Use(validator)
+	v1 := versions.Version{Name: "v1", Handlers: []versions.Registrar{teamsHandlers.NewHandlers(usecase)}}
+	v1.Mount(e)
+	v1.MountAliases(e, versions.Alias{})
+	NewHandlers(doc).RegisterHandlers(e)
+	return e
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("valid request and response", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := teamsMocks.NewMockUsecase(ctrl)
+		usecaseMock.EXPECT().
+			GetTeamSettings(gomock.Any(), "backend").
+			Return(&ucTeams.Settings{TeamName: "backend", ReviewersCount: 2, SelectionStrategy: "random"}, nil)
+
+		e := newTestServer(t, usecaseMock, true)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/team/settings?team_name=backend", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"selection_strategy":"random"`)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := newTestServer(t, teamsMocks.NewMockUsecase(ctrl), true)
+
+		body := `{"team_name":"backend","selection_strategy":"round_robin"}`
+		req := httptest.NewRequest(http.MethodPost, "/team/settings/update", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"VALIDATION_FAILED"`)
+		assert.Contains(t, rec.Body.String(), `"field":"selection_strategy","rule":"enum"`)
+	})
+
+	t.Run("response not matching spec", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := teamsMocks.NewMockUsecase(ctrl)
+		usecaseMock.EXPECT().
+			GetTeamSettings(gomock.Any(), "backend").
+			Return(&ucTeams.Settings{TeamName: "backend", SelectionStrategy: "round_robin"}, nil)
+
+		e := newTestServer(t, usecaseMock, true)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/settings?team_name=backend", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"INTERNAL"`)
+		assert.NotContains(t, rec.Body.String(), "round_robin")
+		assert.NotEmpty(t, rec.Header().Get(echo.HeaderXRequestID))
+	})
+
+	t.Run("response not matching spec is logged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := teamsMocks.NewMockUsecase(ctrl)
+		usecaseMock.EXPECT().
+			GetTeamSettings(gomock.Any(), "backend").
+			Return(&ucTeams.Settings{TeamName: "backend", SelectionStrategy: "round_robin"}, nil)
+
+		e := newTestServer(t, usecaseMock, false)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/settings?team_name=backend", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "round_robin")
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := newTestServer(t, teamsMocks.NewMockUsecase(ctrl), true)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/nope", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("spec", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := newTestServer(t, teamsMocks.NewMockUsecase(ctrl), true)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+		assert.Equal(t, "3.0.3", spec["openapi"])
+		paths := spec["paths"].(map[string]any)
+		alias := paths["/team/add"].(map[string]any)["post"].(map[string]any)
+		assert.Equal(t, true, alias["deprecated"])
+		assert.NotContains(t, paths["/api/v1/team/add"].(map[string]any)["post"], "deprecated")
+
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "swagger-ui")
+	})
+}
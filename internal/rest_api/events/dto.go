@@ -0,0 +1,9 @@
+package events
+
+// StreamRequest - фильтр подписки. last_event_id нужен для WebSocket, где нельзя передать заголовок;
+// для SSE браузер сам присылает заголовок Last-Event-ID при переподключении.
+type StreamRequest struct {
+	TeamName    string `query:"team_name"`
+	UserID      string `query:"user_id"`
+	LastEventID uint64 `query:"last_event_id"`
+}
@@ -0,0 +1,165 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+
+	ucEvents "github.com/qwerty268/pull_request_service/internal/events"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+// HeartbeatInterval - как часто в тихий поток уходит пинг, чтобы прокси не рвали соединение.
+var HeartbeatInterval = 15 * time.Second
+
+// retryMillis - через сколько клиент SSE переподключается после обрыва.
+const retryMillis = 3000
+
+type Subscriber interface {
+	Subscribe(filter ucEvents.Filter, lastEventID uint64) *ucEvents.Subscription
+}
+
+type Handlers struct {
+	subscriber Subscriber
+	upgrader   websocket.Upgrader
+	// shutdown закрывается при остановке сервера: потоки иначе держат Shutdown до таймаута.
+	shutdown <-chan struct{}
+}
+
+// NewHandlers создает обработчики потока событий. Все открытые потоки завершаются, когда закрывается shutdown.
+func NewHandlers(subscriber Subscriber, shutdown <-chan struct{}) *Handlers {
+	return &Handlers{
+		subscriber: subscriber,
+		shutdown:   shutdown,
+	}
+}
+
+func (h *Handlers) RegisterHandlers(r utils.Router) {
+	r.GET("/events", h.Stream)
+}
+
+// Stream отдает события по WebSocket, если клиент просит апгрейд, иначе как Server-Sent Events.
+func (h *Handlers) Stream(c echo.Context) error {
+	req := new(StreamRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if header := c.Request().Header.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			return &utils.ValidationError{Fields: []utils.FieldError{
+				{Field: "Last-Event-ID", Rule: "numeric", Message: "must be an event id"},
+			}}
+		}
+		req.LastEventID = id
+	}
+
+	filter := ucEvents.Filter{TeamName: req.TeamName, UserID: req.UserID}
+	if websocket.IsWebSocketUpgrade(c.Request()) {
+		return h.streamWebSocket(c, filter, req.LastEventID)
+	}
+	return h.streamSSE(c, filter, req.LastEventID)
+}
+
+func (h *Handlers) streamSSE(c echo.Context, filter ucEvents.Filter, lastEventID uint64) error {
+	sub := h.subscriber.Subscribe(filter, lastEventID)
+	defer sub.Close()
+
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set("Cache-Control", "no-cache")
+	resp.Header().Set("Connection", "keep-alive")
+	// nginx иначе буферизует поток.
+	resp.Header().Set("X-Accel-Buffering", "no")
+	resp.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(resp, "retry: %d\n\n", retryMillis); err != nil {
+		return nil
+	}
+	resp.Flush()
+
+	heartbeat := time.NewTicker(HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-h.shutdown:
+			// Клиент переподключится к другому экземпляру с Last-Event-ID.
+			return nil
+		case ev, ok := <-sub.Events():
+			if !ok {
+				// Подписчик отстал. Клиент переподключится с Last-Event-ID и дочитает пропущенное.
+				return nil
+			}
+			data, err := json.Marshal(ev)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(resp, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(resp, ": ping\n\n"); err != nil {
+				return nil
+			}
+		}
+		resp.Flush()
+	}
+}
+
+func (h *Handlers) streamWebSocket(c echo.Context, filter ucEvents.Filter, lastEventID uint64) error {
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// Upgrader уже ответил клиенту.
+		return nil
+	}
+	defer conn.Close()
+
+	sub := h.subscriber.Subscribe(filter, lastEventID)
+	defer sub.Close()
+
+	// Клиент ничего не шлет, читаем только чтобы заметить закрытие соединения.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return nil
+		case <-h.shutdown:
+			msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down, resume with last_event_id")
+			_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+			return nil
+		case ev, ok := <-sub.Events():
+			if !ok {
+				msg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber is too slow, resume with last_event_id")
+				_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+				return nil
+			}
+			if err := conn.WriteJSON(ev); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ucEvents "github.com/qwerty268/pull_request_service/internal/events"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+func newTestServer(t *testing.T, broker *ucEvents.Broker, shutdown <-chan struct{}) *httptest.Server {
+	e := echo.New()
+	e.HTTPErrorHandler = utils.HTTPErrorHandler
+	NewHandlers(broker, shutdown).RegisterHandlers(e)
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// readSSE читает одно сообщение потока до пустой строки.
+func readSSE(t *testing.T, r *bufio.Reader) map[string]string {
+	t.Helper()
+	msg := map[string]string{}
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return msg
+		}
+		key, value, _ := strings.Cut(line, ": ")
+		msg[key] = value
+	}
+}
+
+func openSSE(t *testing.T, url string, lastEventID string) *bufio.Reader {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+
+	r := bufio.NewReader(resp.Body)
+	assert.Equal(t, map[string]string{"retry": "3000"}, readSSE(t, r))
+	return r
+}
+
+// publish публикует событие и возвращает присвоенный брокером id.
+func publish(t *testing.T, broker *ucEvents.Broker, ev ucEvents.Event) uint64 {
+	t.Helper()
+	sub := broker.Subscribe(ucEvents.Filter{}, 0)
+	defer sub.Close()
+	broker.Publish(ev)
+	return (<-sub.Events()).ID
+}
+
+func TestHandlers_StreamSSE(t *testing.T) {
+	t.Run("filtered events", func(t *testing.T) {
+		broker := ucEvents.NewBroker(ucEvents.DefaultHistorySize)
+		srv := newTestServer(t, broker, nil)
+
+		r := openSSE(t, srv.URL+"/events?team_name=backend", "")
+		broker.Publish(ucEvents.Event{Type: ucEvents.TeamCreated, TeamName: "frontend"})
+		id := publish(t, broker, ucEvents.Event{
+			Type:     ucEvents.PRCreated,
+			TeamName: "backend",
+			UserIDs:  []string{"u1"},
+			Data:     ucEvents.PullRequest{PullRequestID: "pr1", Status: "OPEN"},
+		})
+
+		msg := readSSE(t, r)
+		assert.Equal(t, strconv.FormatUint(id, 10), msg["id"])
+		assert.Equal(t, ucEvents.PRCreated, msg["event"])
+
+		var ev struct {
+			ID   uint64               `json:"id"`
+			Type string               `json:"type"`
+			Data ucEvents.PullRequest `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(msg["data"]), &ev))
+		assert.Equal(t, id, ev.ID)
+		assert.Equal(t, "pr1", ev.Data.PullRequestID)
+	})
+
+	t.Run("resume with Last-Event-ID", func(t *testing.T) {
+		broker := ucEvents.NewBroker(ucEvents.DefaultHistorySize)
+		srv := newTestServer(t, broker, nil)
+		first := publish(t, broker, ucEvents.Event{Type: ucEvents.PRCreated, UserIDs: []string{"u1"}})
+		second := publish(t, broker, ucEvents.Event{Type: ucEvents.PRMerged, UserIDs: []string{"u1"}})
+
+		r := openSSE(t, srv.URL+"/events?user_id=u1", strconv.FormatUint(first, 10))
+		msg := readSSE(t, r)
+		assert.Equal(t, strconv.FormatUint(second, 10), msg["id"])
+		assert.Equal(t, ucEvents.PRMerged, msg["event"])
+	})
+
+	t.Run("resume with id from previous run", func(t *testing.T) {
+		broker := ucEvents.NewBroker(ucEvents.DefaultHistorySize)
+		srv := newTestServer(t, broker, nil)
+		last := publish(t, broker, ucEvents.Event{Type: ucEvents.PRCreated, UserIDs: []string{"u1"}})
+
+		r := openSSE(t, srv.URL+"/events", "2")
+		msg := readSSE(t, r)
+		assert.Equal(t, strconv.FormatUint(last, 10), msg["id"])
+		assert.Equal(t, ucEvents.Resync, msg["event"])
+	})
+
+	t.Run("heartbeat", func(t *testing.T) {
+		prev := HeartbeatInterval
+		HeartbeatInterval = 10 * time.Millisecond
+		t.Cleanup(func() { HeartbeatInterval = prev })
+
+		srv := newTestServer(t, ucEvents.NewBroker(ucEvents.DefaultHistorySize), nil)
+		r := openSSE(t, srv.URL+"/events", "")
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, ": ping\n", line)
+	})
+
+	t.Run("server shutdown closes stream", func(t *testing.T) {
+		shutdown := make(chan struct{})
+		srv := newTestServer(t, ucEvents.NewBroker(ucEvents.DefaultHistorySize), shutdown)
+		r := openSSE(t, srv.URL+"/events", "")
+
+		close(shutdown)
+		_, err := r.ReadString('\n')
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("invalid Last-Event-ID", func(t *testing.T) {
+		srv := newTestServer(t, ucEvents.NewBroker(ucEvents.DefaultHistorySize), nil)
+
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/events", nil)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", "abc")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		var body utils.ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, utils.ValidationFailed, body.Error.Code)
+		require.Len(t, body.Error.Fields, 1)
+		assert.Equal(t, "Last-Event-ID", body.Error.Fields[0].Field)
+	})
+}
+
+func TestHandlers_StreamWebSocket(t *testing.T) {
+	broker := ucEvents.NewBroker(ucEvents.DefaultHistorySize)
+	srv := newTestServer(t, broker, nil)
+	first := publish(t, broker, ucEvents.Event{Type: ucEvents.PRCreated, UserIDs: []string{"u2"}})
+	publish(t, broker, ucEvents.Event{Type: ucEvents.TeamCreated, UserIDs: []string{"u1"}})
+	last := publish(t, broker, ucEvents.Event{Type: ucEvents.UserDeactivated, UserIDs: []string{"u2"}})
+
+	// Заголовок в браузерном WebSocket не передать, поэтому возобновляем через last_event_id.
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/events?user_id=u2&last_event_id=" + strconv.FormatUint(first, 10)
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var ev ucEvents.Event
+	require.NoError(t, conn.ReadJSON(&ev))
+	assert.Equal(t, last, ev.ID)
+	assert.Equal(t, ucEvents.UserDeactivated, ev.Type)
+}
+
+func TestHandlers_StreamWebSocket_Shutdown(t *testing.T) {
+	shutdown := make(chan struct{})
+	srv := newTestServer(t, ucEvents.NewBroker(ucEvents.DefaultHistorySize), shutdown)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/events"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	close(shutdown)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), err)
+}
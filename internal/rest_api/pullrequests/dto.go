@@ -34,4 +34,9 @@ type ReassignReviewerRequest struct {
 type ReassignReviewerResponse struct {
 	PR         PullRequest `json:"pr"`
 	ReplacedBy string      `json:"replaced_by"`
+	Escalated  bool        `json:"escalated,omitempty"`
+}
+
+type GetPRRequest struct {
+	PullRequestID string `query:"pull_request_id" validate:"required"`
 }
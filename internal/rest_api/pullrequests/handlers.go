@@ -4,7 +4,6 @@ package pullrequests
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"time"
 
@@ -18,6 +17,7 @@ type PRCreator interface {
 	CreatePR(ctx context.Context, pr ucDto.CreatePROpst) (*ucDto.PullRequest, error)
 	MergePR(ctx context.Context, prID string) (*ucDto.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*ucDto.ReassignedRewiew, error)
+	GetPR(ctx context.Context, prID string) (*ucDto.PullRequest, error)
 }
 
 type PRHandlers struct {
@@ -30,10 +30,11 @@ func NewHandlers(prCreator PRCreator) *PRHandlers {
 	}
 }
 
-func (h *PRHandlers) RegisterHandlers(e *echo.Echo) {
-	e.POST("/pullRequest/create", h.CreatePR)
-	e.POST("/pullRequest/merge", h.MergePR)
-	e.POST("/pullRequest/reassign", h.ReassignReviewer)
+func (h *PRHandlers) RegisterHandlers(r utils.Router) {
+	r.POST("/pullRequest/create", h.CreatePR)
+	r.POST("/pullRequest/merge", h.MergePR)
+	r.POST("/pullRequest/reassign", h.ReassignReviewer)
+	r.GET("/pullRequest/get", h.GetPR)
 }
 
 // CreatePR создает PR и назначает ревьюверов
@@ -46,7 +47,7 @@ func (h *PRHandlers) CreatePR(c echo.Context) error {
 	}
 
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	ucReq := ucDto.CreatePROpst{
@@ -57,25 +58,7 @@ func (h *PRHandlers) CreatePR(c echo.Context) error {
 
 	pr, err := h.prUsecase.CreatePR(ctx, ucReq)
 	if err != nil {
-		if errors.Is(err, ucDto.ErrAlreadyExists) {
-			return utils.ReturnConflict(
-				c,
-				utils.ErrorDetail{
-					Code:    utils.PrExists,
-					Message: "PR уже существует",
-				},
-			)
-		}
-		if errors.Is(err, ucDto.ErrNotFound) {
-			return utils.ReturnNotFound(
-				c,
-				utils.ErrorDetail{
-					Code:    utils.NotFound,
-					Message: "Автор/команда не найдены",
-				},
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, responseFromPr(pr))
@@ -90,21 +73,12 @@ func (h *PRHandlers) MergePR(c echo.Context) error {
 	}
 
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	pr, err := h.prUsecase.MergePR(ctx, req.PullRequestID)
 	if err != nil {
-		if errors.Is(err, ucDto.ErrNotFound) {
-			return utils.ReturnNotFound(
-				c,
-				utils.ErrorDetail{
-					Code:    utils.NotFound,
-					Message: "pull request not found",
-				},
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, responseFromPr(pr))
@@ -120,56 +94,42 @@ func (h *PRHandlers) ReassignReviewer(c echo.Context) error {
 	}
 
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	reassignedPr, err := h.prUsecase.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID)
 	if err != nil {
-		if errors.Is(err, ucDto.ErrNotFound) {
-			return utils.ReturnNotFound(
-				c,
-				utils.ErrorDetail{
-					Code:    utils.NotFound,
-					Message: "PR or user not found",
-				},
-			)
-		}
-		if errors.Is(err, ucDto.ErrPRMerged) {
-			return utils.ReturnConflict(
-				c,
-				utils.ErrorDetail{
-					Code:    utils.PrMerged,
-					Message: "cannot reassign on merged PR",
-				},
-			)
-		}
-		if errors.Is(err, ucDto.ErrNotAssigned) {
-			return utils.ReturnConflict(
-				c,
-				utils.ErrorDetail{
-					Code:    utils.NotAssigned,
-					Message: "reviewer is not assigned to this PR",
-				},
-			)
-		}
-		if errors.Is(err, ucDto.ErrNoCandidate) {
-			return utils.ReturnConflict(
-				c,
-				utils.ErrorDetail{
-					Code:    utils.NoCandidate,
-					Message: "no active replacement candidate in team",
-				},
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, ReassignReviewerResponse{
 		PR:         responseFromPr(&reassignedPr.Pr),
 		ReplacedBy: reassignedPr.NewReviewer,
+		Escalated:  reassignedPr.Escalated,
 	})
 }
 
+// GetPR получает PR по id
+func (h *PRHandlers) GetPR(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(GetPRRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	pr, err := h.prUsecase.GetPR(ctx, req.PullRequestID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, responseFromPr(pr))
+}
+
 var emptyTime = time.Time{}
 
 func responseFromPr(ucPr *ucDto.PullRequest) PullRequest {
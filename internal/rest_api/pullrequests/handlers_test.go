@@ -11,6 +11,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/qwerty268/pull_request_service/internal/rest_api/pullrequests/mocks"
@@ -27,6 +28,7 @@ func Test_CreatePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prCreatorMock,
@@ -44,12 +46,10 @@ func Test_CreatePR(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		resp := "validate: Key: 'CreatePRRequest.PullRequestID' Error:Field validation for 'PullRequestID' failed on the 'required' tag"
-
 		err := h.CreatePR(c)
-		assert.Error(t, err)
-		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
-		assert.Equal(t, resp, err.(*echo.HTTPError).Message)
+		var validationErr *utils.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []utils.FieldError{{Field: "pull_request_id", Rule: "required", Message: "is required"}}, validationErr.Fields)
 	})
 
 	t.Run("success", func(t *testing.T) {
@@ -60,6 +60,7 @@ func Test_CreatePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prCreatorMock,
@@ -123,6 +124,7 @@ func Test_CreatePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prCreatorMock,
@@ -148,15 +150,14 @@ func Test_CreatePR(t *testing.T) {
 		expectedResponse := utils.ErrorResponse{
 			Error: utils.ErrorDetail{
 				Code:    utils.PrExists,
-				Message: "PR уже существует",
+				Message: "PR already exists",
 			},
 		}
 
-		err := h.CreatePR(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.CreatePR(c), c)
 
 		var response utils.ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 		assert.Equal(t, http.StatusConflict, rec.Code)
@@ -170,6 +171,7 @@ func Test_CreatePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prCreatorMock,
@@ -195,15 +197,14 @@ func Test_CreatePR(t *testing.T) {
 		expectedResponse := utils.ErrorResponse{
 			Error: utils.ErrorDetail{
 				Code:    utils.NotFound,
-				Message: "Автор/команда не найдены",
+				Message: "pull request, author or team not found",
 			},
 		}
 
-		err := h.CreatePR(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.CreatePR(c), c)
 
 		var response utils.ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 		assert.Equal(t, http.StatusNotFound, rec.Code)
@@ -217,6 +218,7 @@ func Test_CreatePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prCreatorMock,
@@ -241,7 +243,8 @@ func Test_CreatePR(t *testing.T) {
 
 		err := h.CreatePR(c)
 		assert.Error(t, err)
-		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
 }
 
@@ -254,6 +257,7 @@ func Test_MergePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -265,12 +269,10 @@ func Test_MergePR(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		resp := "validate: Key: 'MergePRRequest.PullRequestID' Error:Field validation for 'PullRequestID' failed on the 'required' tag"
-
 		err := h.MergePR(c)
-		assert.Error(t, err)
-		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
-		assert.Equal(t, resp, err.(*echo.HTTPError).Message)
+		var validationErr *utils.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []utils.FieldError{{Field: "pull_request_id", Rule: "required", Message: "is required"}}, validationErr.Fields)
 	})
 
 	t.Run("success", func(t *testing.T) {
@@ -281,6 +283,7 @@ func Test_MergePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -341,6 +344,7 @@ func Test_MergePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -364,16 +368,15 @@ func Test_MergePR(t *testing.T) {
 		expectedResponse := utils.ErrorResponse{
 			Error: utils.ErrorDetail{
 				Code:    utils.NotFound,
-				Message: "pull request not found",
+				Message: "pull request, author or team not found",
 			},
 		}
 
-		err := h.MergePR(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.MergePR(c), c)
 		assert.Equal(t, http.StatusNotFound, rec.Code)
 
 		var response utils.ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 	})
@@ -386,6 +389,7 @@ func Test_MergePR(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -408,7 +412,42 @@ func Test_MergePR(t *testing.T) {
 
 		err := h.MergePR(c)
 		assert.Error(t, err)
-		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("not_enough_approvals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prUsecaseMock := mocks.NewMockPRCreator(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &PRHandlers{
+			prUsecase: prUsecaseMock,
+		}
+
+		prUsecaseMock.EXPECT().
+			MergePR(gomock.Any(), "pr-1001").
+			Return(nil, ucDto.ErrNotEnoughReviewers).
+			Times(1)
+
+		reqBody, _ := json.Marshal(MergePRRequest{PullRequestID: "pr-1001"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.MergePR(c), c)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		var response utils.ErrorResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, utils.NotEnoughReviewers, response.Error.Code)
 	})
 }
 
@@ -421,6 +460,7 @@ func Test_ReassignReviewer(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -488,6 +528,7 @@ func Test_ReassignReviewer(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -504,12 +545,10 @@ func Test_ReassignReviewer(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		expectedError := "validate: Key: 'ReassignReviewerRequest.PullRequestID' Error:Field validation for 'PullRequestID' failed on the 'required' tag"
-
 		err := h.ReassignReviewer(c)
-		assert.Error(t, err)
-		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
-		assert.Equal(t, expectedError, err.(*echo.HTTPError).Message)
+		var validationErr *utils.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []utils.FieldError{{Field: "pull_request_id", Rule: "required", Message: "is required"}}, validationErr.Fields)
 	})
 
 	t.Run("pr_not_found", func(t *testing.T) {
@@ -520,6 +559,7 @@ func Test_ReassignReviewer(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -544,16 +584,15 @@ func Test_ReassignReviewer(t *testing.T) {
 		expectedResponse := utils.ErrorResponse{
 			Error: utils.ErrorDetail{
 				Code:    utils.NotFound,
-				Message: "PR or user not found",
+				Message: "pull request, author or team not found",
 			},
 		}
 
-		err := h.ReassignReviewer(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.ReassignReviewer(c), c)
 		assert.Equal(t, http.StatusNotFound, rec.Code)
 
 		var response utils.ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 	})
@@ -566,6 +605,7 @@ func Test_ReassignReviewer(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -590,16 +630,15 @@ func Test_ReassignReviewer(t *testing.T) {
 		expectedResponse := utils.ErrorResponse{
 			Error: utils.ErrorDetail{
 				Code:    utils.PrMerged,
-				Message: "cannot reassign on merged PR",
+				Message: "cannot change merged PR",
 			},
 		}
 
-		err := h.ReassignReviewer(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.ReassignReviewer(c), c)
 		assert.Equal(t, http.StatusConflict, rec.Code)
 
 		var response utils.ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 	})
@@ -612,6 +651,7 @@ func Test_ReassignReviewer(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -640,12 +680,11 @@ func Test_ReassignReviewer(t *testing.T) {
 			},
 		}
 
-		err := h.ReassignReviewer(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.ReassignReviewer(c), c)
 		assert.Equal(t, http.StatusConflict, rec.Code)
 
 		var response utils.ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 	})
@@ -658,6 +697,7 @@ func Test_ReassignReviewer(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -686,12 +726,11 @@ func Test_ReassignReviewer(t *testing.T) {
 			},
 		}
 
-		err := h.ReassignReviewer(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.ReassignReviewer(c), c)
 		assert.Equal(t, http.StatusConflict, rec.Code)
 
 		var response utils.ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 	})
@@ -704,6 +743,7 @@ func Test_ReassignReviewer(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &PRHandlers{
 			prUsecase: prUsecaseMock,
@@ -727,6 +767,79 @@ func Test_ReassignReviewer(t *testing.T) {
 
 		err := h.ReassignReviewer(c)
 		assert.Error(t, err)
-		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func Test_GetPR(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prUsecaseMock := mocks.NewMockPRCreator(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &PRHandlers{
+			prUsecase: prUsecaseMock,
+		}
+
+		prUsecaseMock.EXPECT().
+			GetPR(gomock.Any(), "pr-1001").
+			Return(&ucDto.PullRequest{
+				PullRequestID:     "pr-1001",
+				PullRequestName:   "Add search",
+				AuthorID:          "u1",
+				Status:            "OPEN",
+				AssignedReviewers: []string{"u2"},
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1001", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetPR(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actualPR PullRequest
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actualPR))
+		assert.Equal(t, PullRequest{
+			PullRequestID:     "pr-1001",
+			PullRequestName:   "Add search",
+			AuthorID:          "u1",
+			Status:            "OPEN",
+			AssignedReviewers: []string{"u2"},
+		}, actualPR)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prUsecaseMock := mocks.NewMockPRCreator(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &PRHandlers{
+			prUsecase: prUsecaseMock,
+		}
+
+		prUsecaseMock.EXPECT().
+			GetPR(gomock.Any(), "pr-404").
+			Return(nil, ucDto.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id=pr-404", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.GetPR(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"NOT_FOUND"`)
 	})
 }
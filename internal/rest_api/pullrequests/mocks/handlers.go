@@ -56,6 +56,21 @@ func (mr *MockPRCreatorMockRecorder) CreatePR(ctx, pr any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePR", reflect.TypeOf((*MockPRCreator)(nil).CreatePR), ctx, pr)
 }
 
+// GetPR mocks base method.
+func (m *MockPRCreator) GetPR(ctx context.Context, prID string) (*pullrequests.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPR", ctx, prID)
+	ret0, _ := ret[0].(*pullrequests.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPR indicates an expected call of GetPR.
+func (mr *MockPRCreatorMockRecorder) GetPR(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPR", reflect.TypeOf((*MockPRCreator)(nil).GetPR), ctx, prID)
+}
+
 // MergePR mocks base method.
 func (m *MockPRCreator) MergePR(ctx context.Context, prID string) (*pullrequests.PullRequest, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+
+	graphqlapi "github.com/qwerty268/pull_request_service/internal/graphql_api"
+	"github.com/qwerty268/pull_request_service/internal/metrics"
+	"github.com/qwerty268/pull_request_service/internal/openapi"
+	eventsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/events"
+	prHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/pullrequests"
+	scimHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/scim"
+	statsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/stats"
+	teamsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/teams"
+	userHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/users"
+	"github.com/qwerty268/pull_request_service/internal/rest_api/versions"
+	webhooksHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/webhooks"
+)
+
+// v1Alias - пути без версии оставлены для старых клиентов до даты Sunset.
+var v1Alias = versions.Alias{
+	DeprecatedAt: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC),
+	Sunset:       time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// Deps - зависимости обработчиков HTTP API.
+type Deps struct {
+	PullRequests prHandlers.PRCreator
+	Teams        teamsHandlers.Usecase
+	Users        userHandlers.UserGetter
+	// AdminTokens - токены администраторов, без них удаление пользователей не монтируется.
+	AdminTokens userHandlers.AdminTokens
+	Stats       statsHandlers.Usecase
+	Graph       graphqlapi.Reader
+	Events      eventsHandlers.Subscriber
+	// EventsShutdown закрывается при остановке сервера и завершает открытые потоки событий.
+	EventsShutdown <-chan struct{}
+	SCIM           scimHandlers.Usecase
+	// SCIMToken - bearer-токен IdP, без него эндпоинты SCIM не монтируются.
+	SCIMToken string
+	Webhooks  webhooksHandlers.Usecase
+	// WebhooksConfig - секреты вебхуков, без них доставки отклоняются.
+	WebhooksConfig webhooksHandlers.Config
+	Spec           *openapi3.T
+}
+
+// Register монтирует все HTTP-маршруты сервиса. Тест покрытия спецификацией вызывает его же,
+// поэтому новый маршрут не пройдет мимо проверки.
+func Register(e *echo.Echo, deps Deps) {
+	apiV1 := versions.Version{
+		Name: "v1",
+		Handlers: []versions.Registrar{
+			prHandlers.NewHandlers(deps.PullRequests),
+			teamsHandlers.NewHandlers(deps.Teams),
+			userHandlers.NewUserHandlers(deps.Users, deps.AdminTokens),
+			statsHandlers.NewHandlers(deps.Stats),
+			graphqlapi.NewHandlers(deps.Graph, deps.PullRequests),
+			eventsHandlers.NewHandlers(deps.Events, deps.EventsShutdown),
+		},
+	}
+	apiV1.Mount(e)
+	apiV1.MountAliases(e, v1Alias)
+
+	scimHandlers.NewHandlers(deps.SCIM, deps.SCIMToken).RegisterHandlers(e)
+	webhooksHandlers.NewHandlers(deps.Webhooks, deps.WebhooksConfig).RegisterHandlers(e)
+	metrics.RegisterHandlers(e)
+	openapi.NewHandlers(deps.Spec).RegisterHandlers(e)
+}
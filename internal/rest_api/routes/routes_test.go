@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qwerty268/pull_request_service/internal/openapi"
+	userHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/users"
+)
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// TestSpecCoversRoutes падает, если зарегистрированного маршрута нет в спецификации.
+func TestSpecCoversRoutes(t *testing.T) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	e := echo.New()
+	Register(e, Deps{SCIMToken: "token", AdminTokens: userHandlers.AdminTokens{"token": "admin"}, Spec: doc})
+
+	for _, route := range e.Routes() {
+		// Группы с middleware регистрируют служебные маршруты для 404.
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		item := doc.Paths.Find(path)
+		if !assert.NotNil(t, item, "route %s %s is missing in spec", route.Method, route.Path) {
+			continue
+		}
+		assert.NotNil(t, item.GetOperation(route.Method), "route %s %s is missing in spec", route.Method, route.Path)
+	}
+}
@@ -0,0 +1,281 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/scim"
+)
+
+// memoryUsecase - хранилище в памяти с той же семантикой, что у ucDto.Usecase.
+type memoryUsecase struct {
+	users  map[string]*ucDto.User
+	groups map[string][]string
+}
+
+func newMemoryUsecase() *memoryUsecase {
+	return &memoryUsecase{
+		users:  make(map[string]*ucDto.User),
+		groups: make(map[string][]string),
+	}
+}
+
+func (m *memoryUsecase) CreateUser(_ context.Context, user ucDto.User) (*ucDto.User, error) {
+	if user.UserID == "" {
+		user.UserID = fmt.Sprintf("gen-%d", len(m.users)+1)
+	}
+	for _, u := range m.users {
+		if u.UserID == user.UserID || u.Username == user.Username {
+			return nil, ucDto.ErrAlreadyExists
+		}
+	}
+	m.users[user.UserID] = &user
+	return &user, nil
+}
+
+func (m *memoryUsecase) GetUser(_ context.Context, userID string) (*ucDto.User, error) {
+	user, ok := m.users[userID]
+	if !ok {
+		return nil, ucDto.ErrNotFound
+	}
+	res := *user
+	return &res, nil
+}
+
+func (m *memoryUsecase) ListUsers(_ context.Context, filter ucDto.UserFilter) (*ucDto.UsersPage, error) {
+	users := make([]ucDto.User, 0)
+	for _, u := range m.users {
+		if filter.UserID != "" && u.UserID != filter.UserID ||
+			filter.Username != "" && u.Username != filter.Username ||
+			filter.IsActive != nil && u.IsActive != *filter.IsActive {
+			continue
+		}
+		users = append(users, *u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+	return &ucDto.UsersPage{Users: users, Total: len(users)}, nil
+}
+
+func (m *memoryUsecase) PatchUser(ctx context.Context, userID string, patch ucDto.UserPatch) (*ucDto.User, error) {
+	user, ok := m.users[userID]
+	if !ok {
+		return nil, ucDto.ErrNotFound
+	}
+	if patch.Username != nil {
+		user.Username = *patch.Username
+	}
+	if patch.IsActive != nil {
+		user.IsActive = *patch.IsActive
+	}
+	return m.GetUser(ctx, userID)
+}
+
+func (m *memoryUsecase) DeleteUser(_ context.Context, userID string) error {
+	user, ok := m.users[userID]
+	if !ok {
+		return ucDto.ErrNotFound
+	}
+	if user.TeamName != "" {
+		m.groups[user.TeamName] = slices.DeleteFunc(m.groups[user.TeamName], func(id string) bool { return id == userID })
+	}
+	delete(m.users, userID)
+	return nil
+}
+
+func (m *memoryUsecase) CreateGroup(ctx context.Context, group ucDto.Group) (*ucDto.Group, error) {
+	if _, ok := m.groups[group.Name]; ok {
+		return nil, ucDto.ErrAlreadyExists
+	}
+	m.groups[group.Name] = make([]string, 0)
+	return m.PatchGroup(ctx, group.Name, ucDto.GroupPatch{AddMembers: group.Members})
+}
+
+func (m *memoryUsecase) GetGroup(_ context.Context, name string) (*ucDto.Group, error) {
+	members, ok := m.groups[name]
+	if !ok {
+		return nil, ucDto.ErrNotFound
+	}
+	return &ucDto.Group{Name: name, Members: slices.Clone(members)}, nil
+}
+
+func (m *memoryUsecase) ListGroups(ctx context.Context, filter ucDto.GroupFilter) (*ucDto.GroupsPage, error) {
+	groups := make([]ucDto.Group, 0)
+	for name := range m.groups {
+		if filter.Name != "" && name != filter.Name {
+			continue
+		}
+		group, _ := m.GetGroup(ctx, name)
+		groups = append(groups, *group)
+	}
+	return &ucDto.GroupsPage{Groups: groups, Total: len(groups)}, nil
+}
+
+func (m *memoryUsecase) PatchGroup(ctx context.Context, name string, patch ucDto.GroupPatch) (*ucDto.Group, error) {
+	if _, ok := m.groups[name]; !ok {
+		return nil, ucDto.ErrNotFound
+	}
+	if patch.SetMembers != nil {
+		patch.RemoveMembers = append(patch.RemoveMembers, m.groups[name]...)
+		patch.AddMembers = append(patch.AddMembers, *patch.SetMembers...)
+	}
+	for _, id := range patch.RemoveMembers {
+		if user, ok := m.users[id]; ok && user.TeamName == name {
+			user.TeamName = ""
+		}
+		m.groups[name] = slices.DeleteFunc(m.groups[name], func(v string) bool { return v == id })
+	}
+	for _, id := range patch.AddMembers {
+		user, ok := m.users[id]
+		if !ok {
+			return nil, ucDto.ErrNotFound
+		}
+		if user.TeamName != "" {
+			m.groups[user.TeamName] = slices.DeleteFunc(m.groups[user.TeamName], func(v string) bool { return v == id })
+		}
+		user.TeamName = name
+		m.groups[name] = append(m.groups[name], id)
+	}
+	return m.GetGroup(ctx, name)
+}
+
+func (m *memoryUsecase) DeleteGroup(_ context.Context, name string) error {
+	members, ok := m.groups[name]
+	if !ok {
+		return ucDto.ErrNotFound
+	}
+	for _, id := range members {
+		m.users[id].TeamName = ""
+	}
+	delete(m.groups, name)
+	return nil
+}
+
+// fakeIdP повторяет запросы, которые шлет IdP при провижининге.
+type fakeIdP struct {
+	t       *testing.T
+	baseURL string
+	token   string
+}
+
+func (p *fakeIdP) do(method, path string, body any, out any) int {
+	p.t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(p.t, err)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, p.baseURL+path, reader)
+	require.NoError(p.t, err)
+	req.Header.Set(echo.HeaderContentType, mimeSCIM)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+p.token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(p.t, err)
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < http.StatusBadRequest {
+		require.Equal(p.t, mimeSCIM, resp.Header.Get(echo.HeaderContentType))
+		require.NoError(p.t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func patchOp(op, path string, value any) PatchRequest {
+	raw, _ := json.Marshal(value)
+	if value == nil {
+		raw = nil
+	}
+	return PatchRequest{
+		Schemas:    []string{SchemaPatchOp},
+		Operations: []PatchOperation{{Op: op, Path: path, Value: raw}},
+	}
+}
+
+func Test_FakeIdPProvisioning(t *testing.T) {
+	e := echo.New()
+	NewHandlers(newMemoryUsecase(), "idp-token").RegisterHandlers(e)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	idp := &fakeIdP{t: t, baseURL: server.URL + basePath, token: "idp-token"}
+
+	// Заводим пользователей.
+	for _, u := range []UserResource{
+		{Schemas: []string{SchemaUser}, ExternalID: "u1", UserName: "alice"},
+		{Schemas: []string{SchemaUser}, ExternalID: "u2", UserName: "bob"},
+	} {
+		var created UserResource
+		require.Equal(t, http.StatusCreated, idp.do(http.MethodPost, "/Users", u, &created))
+		require.Equal(t, u.ExternalID, created.ID)
+		require.True(t, *created.Active)
+	}
+	require.Equal(t, http.StatusConflict, idp.do(http.MethodPost, "/Users",
+		UserResource{ExternalID: "u1", UserName: "alice"}, nil))
+
+	// Заводим группу с участником и добавляем второго.
+	var group GroupResource
+	require.Equal(t, http.StatusCreated, idp.do(http.MethodPost, "/Groups", GroupResource{
+		Schemas:     []string{SchemaGroup},
+		DisplayName: "backend",
+		Members:     []MemberRef{{Value: "u1"}},
+	}, &group))
+	require.Equal(t, []MemberRef{{Value: "u1"}}, group.Members)
+
+	require.Equal(t, http.StatusOK, idp.do(http.MethodPatch, "/Groups/backend",
+		patchOp("add", "members", []MemberRef{{Value: "u2"}}), &group))
+	require.Len(t, group.Members, 2)
+
+	// Деактивация через active=false.
+	var user UserResource
+	require.Equal(t, http.StatusOK, idp.do(http.MethodPatch, "/Users/u2",
+		patchOp("replace", "", map[string]any{"active": false}), &user))
+	require.False(t, *user.Active)
+	require.Equal(t, []MemberRef{{Value: "backend", Display: "backend"}}, user.Groups)
+
+	// Поиск по фильтру.
+	var list struct {
+		TotalResults int            `json:"totalResults"`
+		Resources    []UserResource `json:"Resources"`
+	}
+	query := url.Values{"filter": {`active eq "false"`}}.Encode()
+	require.Equal(t, http.StatusOK, idp.do(http.MethodGet, "/Users?"+query, nil, &list))
+	require.Equal(t, 1, list.TotalResults)
+	require.Equal(t, "u2", list.Resources[0].ID)
+
+	var groups struct {
+		TotalResults int             `json:"totalResults"`
+		Resources    []GroupResource `json:"Resources"`
+	}
+	query = url.Values{"filter": {`displayName eq "backend"`}}.Encode()
+	require.Equal(t, http.StatusOK, idp.do(http.MethodGet, "/Groups?"+query, nil, &groups))
+	require.Equal(t, 1, groups.TotalResults)
+
+	// Удаление участника фильтром и удаление пользователя.
+	require.Equal(t, http.StatusOK, idp.do(http.MethodPatch, "/Groups/backend",
+		patchOp("remove", `members[value eq "u1"]`, nil), &group))
+	require.Equal(t, []MemberRef{{Value: "u2"}}, group.Members)
+
+	require.Equal(t, http.StatusNoContent, idp.do(http.MethodDelete, "/Users/u1", nil, nil))
+	require.Equal(t, http.StatusNotFound, idp.do(http.MethodGet, "/Users/u1", nil, nil))
+
+	require.Equal(t, http.StatusNoContent, idp.do(http.MethodDelete, "/Groups/backend", nil, nil))
+	var orphan UserResource
+	require.Equal(t, http.StatusOK, idp.do(http.MethodGet, "/Users/u2", nil, &orphan))
+	require.Empty(t, orphan.Groups)
+}
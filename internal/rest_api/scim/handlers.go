@@ -0,0 +1,426 @@
+//go:generate mockgen --source=handlers.go --destination=mocks/handlers.go -package=mocks
+
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/scim"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+const (
+	mimeSCIM = "application/scim+json"
+	basePath = "/scim/v2"
+
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeMutability    = "mutability"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+type Usecase interface {
+	CreateUser(ctx context.Context, user ucDto.User) (*ucDto.User, error)
+	GetUser(ctx context.Context, userID string) (*ucDto.User, error)
+	ListUsers(ctx context.Context, filter ucDto.UserFilter) (*ucDto.UsersPage, error)
+	PatchUser(ctx context.Context, userID string, patch ucDto.UserPatch) (*ucDto.User, error)
+	DeleteUser(ctx context.Context, userID string) error
+
+	CreateGroup(ctx context.Context, group ucDto.Group) (*ucDto.Group, error)
+	GetGroup(ctx context.Context, name string) (*ucDto.Group, error)
+	ListGroups(ctx context.Context, filter ucDto.GroupFilter) (*ucDto.GroupsPage, error)
+	PatchGroup(ctx context.Context, name string, patch ucDto.GroupPatch) (*ucDto.Group, error)
+	DeleteGroup(ctx context.Context, name string) error
+}
+
+type Handlers struct {
+	usecase Usecase
+	// token - bearer-токен IdP.
+	token string
+}
+
+func NewHandlers(usecase Usecase, token string) *Handlers {
+	return &Handlers{
+		usecase: usecase,
+		token:   token,
+	}
+}
+
+// RegisterHandlers монтирует эндпоинты SCIM. Без токена они не монтируются:
+// иначе кто угодно мог бы заводить и удалять пользователей.
+func (h *Handlers) RegisterHandlers(e *echo.Echo) {
+	if h.token == "" {
+		return
+	}
+	g := e.Group(basePath, h.authenticate)
+
+	g.POST("/Users", h.CreateUser)
+	g.GET("/Users", h.ListUsers)
+	g.GET("/Users/:id", h.GetUser)
+	g.PATCH("/Users/:id", h.PatchUser)
+	g.DELETE("/Users/:id", h.DeleteUser)
+
+	g.POST("/Groups", h.CreateGroup)
+	g.GET("/Groups", h.ListGroups)
+	g.GET("/Groups/:id", h.GetGroup)
+	g.PATCH("/Groups/:id", h.PatchGroup)
+	g.DELETE("/Groups/:id", h.DeleteGroup)
+}
+
+// authenticate проверяет bearer-токен IdP
+func (h *Handlers) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			return returnError(c, http.StatusUnauthorized, "", "invalid bearer token")
+		}
+		return next(c)
+	}
+}
+
+// CreateUser заводит пользователя. id берется из externalId, иначе генерируется.
+func (h *Handlers) CreateUser(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(UserResource)
+	if err := decode(c, req); err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidSyntax, "bad request")
+	}
+	if req.UserName == "" {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidValue, "userName is required")
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	user, err := h.usecase.CreateUser(ctx, ucDto.User{
+		UserID:   req.ExternalID,
+		Username: req.UserName,
+		IsActive: active,
+	})
+	if err != nil {
+		if errors.Is(err, ucDto.ErrAlreadyExists) {
+			return returnError(c, http.StatusConflict, scimTypeUniqueness, "user already exists")
+		}
+		return internalError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, userLocation(user.UserID))
+	return returnResource(c, http.StatusCreated, toUserResource(user))
+}
+
+func (h *Handlers) GetUser(c echo.Context) error {
+	ctx := context.Background()
+
+	user, err := h.usecase.GetUser(ctx, c.Param("id"))
+	if err != nil {
+		return h.userError(c, err)
+	}
+	return returnResource(c, http.StatusOK, toUserResource(user))
+}
+
+// ListUsers выдает страницу пользователей. Поддерживается filter вида `userName eq "x"`.
+func (h *Handlers) ListUsers(c echo.Context) error {
+	ctx := context.Background()
+
+	req, err := bindList(c)
+	if err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidValue, "bad request")
+	}
+
+	expr, err := parseFilter(req.Filter)
+	if err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+	}
+	filter, err := toUserFilter(expr)
+	if err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+	}
+	filter.Limit = req.Count
+	filter.Offset = req.StartIndex - 1
+
+	page, err := h.usecase.ListUsers(ctx, filter)
+	if err != nil {
+		return internalError(c, err)
+	}
+
+	resources := make([]any, len(page.Users))
+	for i := range page.Users {
+		resources[i] = toUserResource(&page.Users[i])
+	}
+	return returnResource(c, http.StatusOK, newListResponse(resources, page.Total, req.StartIndex))
+}
+
+// PatchUser применяет PatchOp. active=false деактивирует пользователя.
+func (h *Handlers) PatchUser(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(PatchRequest)
+	if err := decode(c, req); err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidSyntax, "bad request")
+	}
+
+	patch, err := toUserPatch(req.Operations)
+	if err != nil {
+		return patchError(c, err)
+	}
+
+	user, err := h.usecase.PatchUser(ctx, c.Param("id"), patch)
+	if err != nil {
+		if errors.Is(err, ucDto.ErrAlreadyExists) {
+			return returnError(c, http.StatusConflict, scimTypeUniqueness, "userName already taken")
+		}
+		return h.userError(c, err)
+	}
+	return returnResource(c, http.StatusOK, toUserResource(user))
+}
+
+func (h *Handlers) DeleteUser(c echo.Context) error {
+	ctx := context.Background()
+
+	if err := h.usecase.DeleteUser(ctx, c.Param("id")); err != nil {
+		if errors.Is(err, ucDto.ErrConflict) {
+			return returnError(c, http.StatusConflict, "", "user has pull requests, deactivate instead")
+		}
+		return h.userError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreateGroup создает команду с участниками. id группы совпадает с displayName.
+func (h *Handlers) CreateGroup(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(GroupResource)
+	if err := decode(c, req); err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidSyntax, "bad request")
+	}
+	if req.DisplayName == "" {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidValue, "displayName is required")
+	}
+
+	members := make([]string, len(req.Members))
+	for i, m := range req.Members {
+		members[i] = m.Value
+	}
+
+	group, err := h.usecase.CreateGroup(ctx, ucDto.Group{
+		Name:    req.DisplayName,
+		Members: members,
+	})
+	if err != nil {
+		if errors.Is(err, ucDto.ErrAlreadyExists) {
+			return returnError(c, http.StatusConflict, scimTypeUniqueness, "group already exists")
+		}
+		if errors.Is(err, ucDto.ErrNotFound) {
+			return returnError(c, http.StatusBadRequest, scimTypeInvalidValue, "member not found")
+		}
+		return internalError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, groupLocation(group.Name))
+	return returnResource(c, http.StatusCreated, toGroupResource(group))
+}
+
+func (h *Handlers) GetGroup(c echo.Context) error {
+	ctx := context.Background()
+
+	group, err := h.usecase.GetGroup(ctx, c.Param("id"))
+	if err != nil {
+		return h.groupError(c, err)
+	}
+	return returnResource(c, http.StatusOK, toGroupResource(group))
+}
+
+// ListGroups выдает страницу команд. Поддерживается filter вида `displayName eq "x"`.
+func (h *Handlers) ListGroups(c echo.Context) error {
+	ctx := context.Background()
+
+	req, err := bindList(c)
+	if err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidValue, "bad request")
+	}
+
+	expr, err := parseFilter(req.Filter)
+	if err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+	}
+	filter, err := toGroupFilter(expr)
+	if err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+	}
+	filter.Limit = req.Count
+	filter.Offset = req.StartIndex - 1
+
+	page, err := h.usecase.ListGroups(ctx, filter)
+	if err != nil {
+		return internalError(c, err)
+	}
+
+	resources := make([]any, len(page.Groups))
+	for i := range page.Groups {
+		resources[i] = toGroupResource(&page.Groups[i])
+	}
+	return returnResource(c, http.StatusOK, newListResponse(resources, page.Total, req.StartIndex))
+}
+
+// PatchGroup добавляет и удаляет участников команды
+func (h *Handlers) PatchGroup(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(PatchRequest)
+	if err := decode(c, req); err != nil {
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidSyntax, "bad request")
+	}
+
+	patch, err := toGroupPatch(req.Operations)
+	if err != nil {
+		return patchError(c, err)
+	}
+
+	group, err := h.usecase.PatchGroup(ctx, c.Param("id"), patch)
+	if err != nil {
+		return h.groupError(c, err)
+	}
+	return returnResource(c, http.StatusOK, toGroupResource(group))
+}
+
+func (h *Handlers) DeleteGroup(c echo.Context) error {
+	ctx := context.Background()
+
+	if err := h.usecase.DeleteGroup(ctx, c.Param("id")); err != nil {
+		return h.groupError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handlers) userError(c echo.Context, err error) error {
+	if errors.Is(err, ucDto.ErrNotFound) {
+		return returnError(c, http.StatusNotFound, "", "user not found")
+	}
+	return internalError(c, err)
+}
+
+func (h *Handlers) groupError(c echo.Context, err error) error {
+	if errors.Is(err, ucDto.ErrNotFound) {
+		return returnError(c, http.StatusNotFound, "", "group or member not found")
+	}
+	return internalError(c, err)
+}
+
+func patchError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, errInvalidPath):
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidPath, err.Error())
+	case errors.Is(err, errMutability):
+		return returnError(c, http.StatusBadRequest, scimTypeMutability, err.Error())
+	default:
+		return returnError(c, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+	}
+}
+
+// decode читает тело запроса. c.Bind не подходит: IdP присылают Content-Type application/scim+json.
+func decode(c echo.Context, v any) error {
+	return json.NewDecoder(c.Request().Body).Decode(v)
+}
+
+func bindList(c echo.Context) (*ListRequest, error) {
+	req := new(ListRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return nil, err
+	}
+	if req.StartIndex < 1 {
+		req.StartIndex = 1
+	}
+	if req.Count < 0 {
+		req.Count = 0
+	}
+	return req, nil
+}
+
+func newListResponse(resources []any, total, startIndex int) ListResponse {
+	return ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+func toUserResource(user *ucDto.User) UserResource {
+	active := user.IsActive
+	res := UserResource{
+		Schemas:    []string{SchemaUser},
+		ID:         user.UserID,
+		ExternalID: user.UserID,
+		UserName:   user.Username,
+		Active:     &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Location:     userLocation(user.UserID),
+		},
+	}
+	if user.TeamName != "" {
+		res.Groups = []MemberRef{{Value: user.TeamName, Display: user.TeamName}}
+	}
+	return res
+}
+
+func toGroupResource(group *ucDto.Group) GroupResource {
+	members := make([]MemberRef, len(group.Members))
+	for i, id := range group.Members {
+		members[i] = MemberRef{Value: id}
+	}
+	return GroupResource{
+		Schemas:     []string{SchemaGroup},
+		ID:          group.Name,
+		DisplayName: group.Name,
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Location:     groupLocation(group.Name),
+		},
+	}
+}
+
+func userLocation(id string) string {
+	return basePath + "/Users/" + id
+}
+
+func groupLocation(name string) string {
+	return basePath + "/Groups/" + name
+}
+
+func returnResource(c echo.Context, status int, v any) error {
+	c.Response().Header().Set(echo.HeaderContentType, mimeSCIM)
+	return c.JSON(status, v)
+}
+
+// internalError пишет ошибку в лог с correlation_id, клиенту отдается только он.
+func internalError(c echo.Context, err error) error {
+	id := utils.CorrelationID(c)
+	c.Logger().Errorf("correlation_id=%s %s %s: %v", id, c.Request().Method, c.Request().URL.Path, err)
+	return returnError(c, http.StatusInternalServerError, "", "internal error, correlation_id="+id)
+}
+
+func returnError(c echo.Context, status int, scimType, detail string) error {
+	return returnResource(c, status, ErrorResponse{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
@@ -0,0 +1,333 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/rest_api/scim/mocks"
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/scim"
+)
+
+func newRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, mimeSCIM)
+	return req
+}
+
+func Test_CreateUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			CreateUser(gomock.Any(), ucDto.User{UserID: "u1", Username: "alice", IsActive: true}).
+			Return(&ucDto.User{UserID: "u1", Username: "alice", IsActive: true}, nil)
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(http.MethodPost, "/scim/v2/Users",
+			`{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"externalId":"u1","userName":"alice"}`), rec)
+
+		err := h.CreateUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, mimeSCIM, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "/scim/v2/Users/u1", rec.Header().Get(echo.HeaderLocation))
+
+		var resp UserResource
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "u1", resp.ID)
+		assert.Equal(t, "alice", resp.UserName)
+		assert.True(t, *resp.Active)
+	})
+
+	t.Run("already_exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			CreateUser(gomock.Any(), gomock.Any()).
+			Return(nil, ucDto.ErrAlreadyExists)
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(http.MethodPost, "/scim/v2/Users", `{"userName":"alice"}`), rec)
+
+		err := h.CreateUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		var resp ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "409", resp.Status)
+		assert.Equal(t, scimTypeUniqueness, resp.ScimType)
+	})
+
+	t.Run("internal_error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			CreateUser(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("pq: connection refused"))
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		req := newRequest(http.MethodPost, "/scim/v2/Users", `{"userName":"alice"}`)
+		req.Header.Set(echo.HeaderXRequestID, "req-1")
+		c := e.NewContext(req, rec)
+
+		err := h.CreateUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "req-1", rec.Header().Get(echo.HeaderXRequestID))
+
+		var resp ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "internal error, correlation_id=req-1", resp.Detail)
+		assert.NotContains(t, rec.Body.String(), "pq:")
+	})
+
+	t.Run("missing_username", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		h := &Handlers{usecase: mocks.NewMockUsecase(ctrl)}
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(http.MethodPost, "/scim/v2/Users", `{"externalId":"u1"}`), rec)
+
+		err := h.CreateUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func Test_PatchUser(t *testing.T) {
+	for name, body := range map[string]string{
+		"path":       `{"Operations":[{"op":"replace","path":"active","value":false}]}`,
+		"value_map":  `{"Operations":[{"op":"Replace","value":{"active":false}}]}`,
+		"string_val": `{"Operations":[{"op":"replace","path":"active","value":"False"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecaseMock := mocks.NewMockUsecase(ctrl)
+			h := &Handlers{usecase: usecaseMock}
+
+			isActive := false
+			usecaseMock.EXPECT().
+				PatchUser(gomock.Any(), "u1", ucDto.UserPatch{IsActive: &isActive}).
+				Return(&ucDto.User{UserID: "u1", Username: "alice", IsActive: false}, nil)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(newRequest(http.MethodPatch, "/scim/v2/Users/u1", body), rec)
+			c.SetParamNames("id")
+			c.SetParamValues("u1")
+
+			err := h.PatchUser(c)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var resp UserResource
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.False(t, *resp.Active)
+		})
+	}
+
+	t.Run("unknown_path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		h := &Handlers{usecase: mocks.NewMockUsecase(ctrl)}
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(http.MethodPatch, "/scim/v2/Users/u1",
+			`{"Operations":[{"op":"replace","path":"emails","value":[]}]}`), rec)
+		c.SetParamNames("id")
+		c.SetParamValues("u1")
+
+		err := h.PatchUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), scimTypeInvalidPath)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			PatchUser(gomock.Any(), "u1", gomock.Any()).
+			Return(nil, ucDto.ErrNotFound)
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(http.MethodPatch, "/scim/v2/Users/u1",
+			`{"Operations":[{"op":"replace","path":"active","value":true}]}`), rec)
+		c.SetParamNames("id")
+		c.SetParamValues("u1")
+
+		err := h.PatchUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func Test_ListUsers(t *testing.T) {
+	t.Run("filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			ListUsers(gomock.Any(), ucDto.UserFilter{Username: "alice", Limit: 10, Offset: 4}).
+			Return(&ucDto.UsersPage{
+				Users: []ucDto.User{{UserID: "u1", Username: "alice", TeamName: "backend", IsActive: true}},
+				Total: 1,
+			}, nil)
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		target := `/scim/v2/Users?filter=userName+eq+%22alice%22&startIndex=5&count=10`
+		c := e.NewContext(newRequest(http.MethodGet, target, ""), rec)
+
+		err := h.ListUsers(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp struct {
+			TotalResults int            `json:"totalResults"`
+			StartIndex   int            `json:"startIndex"`
+			Resources    []UserResource `json:"Resources"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.TotalResults)
+		assert.Equal(t, 5, resp.StartIndex)
+		assert.Equal(t, []MemberRef{{Value: "backend", Display: "backend"}}, resp.Resources[0].Groups)
+	})
+
+	t.Run("invalid_filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		h := &Handlers{usecase: mocks.NewMockUsecase(ctrl)}
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(http.MethodGet, `/scim/v2/Users?filter=userName+co+%22al%22`, ""), rec)
+
+		err := h.ListUsers(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), scimTypeInvalidFilter)
+	})
+}
+
+func Test_PatchGroup(t *testing.T) {
+	t.Run("add_and_remove", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			PatchGroup(gomock.Any(), "backend", ucDto.GroupPatch{
+				AddMembers:    []string{"u3"},
+				RemoveMembers: []string{"u1"},
+			}).
+			Return(&ucDto.Group{Name: "backend", Members: []string{"u2", "u3"}}, nil)
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(http.MethodPatch, "/scim/v2/Groups/backend", `{"Operations":[
+			{"op":"add","path":"members","value":[{"value":"u3"}]},
+			{"op":"remove","path":"members[value eq \"u1\"]"}
+		]}`), rec)
+		c.SetParamNames("id")
+		c.SetParamValues("backend")
+
+		err := h.PatchGroup(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		h := &Handlers{usecase: mocks.NewMockUsecase(ctrl)}
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(http.MethodPatch, "/scim/v2/Groups/backend",
+			`{"Operations":[{"op":"replace","value":{"displayName":"platform"}}]}`), rec)
+		c.SetParamNames("id")
+		c.SetParamValues("backend")
+
+		err := h.PatchGroup(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), scimTypeMutability)
+	})
+}
+
+func Test_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecaseMock := mocks.NewMockUsecase(ctrl)
+	e := echo.New()
+	NewHandlers(usecaseMock, "secret").RegisterHandlers(e)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newRequest(http.MethodGet, "/scim/v2/Users/u1", ""))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	usecaseMock.EXPECT().
+		GetUser(gomock.Any(), "u1").
+		Return(&ucDto.User{UserID: "u1", Username: "alice"}, nil)
+
+	req := newRequest(http.MethodGet, "/scim/v2/Users/u1", "")
+	req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func Test_RegisterHandlers_NoToken(t *testing.T) {
+	e := echo.New()
+	NewHandlers(nil, "").RegisterHandlers(e)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newRequest(http.MethodGet, "/scim/v2/Users/u1", ""))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, e.Routes())
+}
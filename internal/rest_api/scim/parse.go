@@ -0,0 +1,242 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/scim"
+)
+
+var (
+	errInvalidFilter = errors.New("invalid filter")
+	errInvalidPath   = errors.New("invalid path")
+	errInvalidValue  = errors.New("invalid value")
+	errMutability    = errors.New("attribute is immutable")
+)
+
+// filterExpr - выражение вида `attr eq "value"`. Другие операторы IdP для провижининга не используют.
+type filterExpr struct {
+	Attr  string
+	Value string
+}
+
+func parseFilter(filter string) (*filterExpr, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(filter, " ", 3)
+	if len(parts) != 3 || !strings.EqualFold(parts[1], "eq") {
+		return nil, fmt.Errorf("%q: %w", filter, errInvalidFilter)
+	}
+
+	value := strings.TrimSpace(parts[2])
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	return &filterExpr{Attr: parts[0], Value: value}, nil
+}
+
+func toUserFilter(expr *filterExpr) (ucDto.UserFilter, error) {
+	var filter ucDto.UserFilter
+	if expr == nil {
+		return filter, nil
+	}
+
+	switch strings.ToLower(expr.Attr) {
+	case "username":
+		filter.Username = expr.Value
+	case "id", "externalid":
+		filter.UserID = expr.Value
+	case "active":
+		active, err := strconv.ParseBool(expr.Value)
+		if err != nil {
+			return filter, fmt.Errorf("active: %w", errInvalidFilter)
+		}
+		filter.IsActive = &active
+	default:
+		return filter, fmt.Errorf("unsupported attribute %q: %w", expr.Attr, errInvalidFilter)
+	}
+	return filter, nil
+}
+
+func toGroupFilter(expr *filterExpr) (ucDto.GroupFilter, error) {
+	var filter ucDto.GroupFilter
+	if expr == nil {
+		return filter, nil
+	}
+
+	switch strings.ToLower(expr.Attr) {
+	case "displayname", "id":
+		filter.Name = expr.Value
+	default:
+		return filter, fmt.Errorf("unsupported attribute %q: %w", expr.Attr, errInvalidFilter)
+	}
+	return filter, nil
+}
+
+// parseBool принимает и JSON bool, и строку: часть IdP шлет "False".
+func parseBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false, errInvalidValue
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errInvalidValue
+	}
+	return b, nil
+}
+
+func parseString(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", errInvalidValue
+	}
+	return s, nil
+}
+
+func parseMembers(raw json.RawMessage) ([]string, error) {
+	var refs []MemberRef
+	if err := json.Unmarshal(raw, &refs); err != nil {
+		return nil, errInvalidValue
+	}
+
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		if ref.Value == "" {
+			return nil, errInvalidValue
+		}
+		ids[i] = ref.Value
+	}
+	return ids, nil
+}
+
+// toUserPatch переводит операции PATCH в изменение пользователя.
+// Поддерживаются path=active/userName и форма без path, где value - объект атрибутов.
+func toUserPatch(ops []PatchOperation) (ucDto.UserPatch, error) {
+	var patch ucDto.UserPatch
+
+	apply := func(path string, raw json.RawMessage) error {
+		switch strings.ToLower(path) {
+		case "active":
+			active, err := parseBool(raw)
+			if err != nil {
+				return fmt.Errorf("active: %w", err)
+			}
+			patch.IsActive = &active
+		case "username":
+			username, err := parseString(raw)
+			if err != nil || username == "" {
+				return fmt.Errorf("userName: %w", errInvalidValue)
+			}
+			patch.Username = &username
+		default:
+			return fmt.Errorf("%q: %w", path, errInvalidPath)
+		}
+		return nil
+	}
+
+	for _, op := range ops {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		default:
+			return patch, fmt.Errorf("op %q: %w", op.Op, errInvalidValue)
+		}
+
+		if op.Path != "" {
+			if err := apply(op.Path, op.Value); err != nil {
+				return patch, err
+			}
+			continue
+		}
+
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return patch, fmt.Errorf("value: %w", errInvalidValue)
+		}
+		for path, raw := range attrs {
+			if err := apply(path, raw); err != nil {
+				return patch, err
+			}
+		}
+	}
+	return patch, nil
+}
+
+// toGroupPatch переводит операции PATCH в изменение состава команды. Переименование не поддерживается:
+// team_name - первичный ключ и на него ссылаются PR.
+func toGroupPatch(ops []PatchOperation) (ucDto.GroupPatch, error) {
+	var patch ucDto.GroupPatch
+
+	for _, op := range ops {
+		path := op.Path
+		opName := strings.ToLower(op.Op)
+
+		// remove с фильтром: members[value eq "u1"]
+		if opName == "remove" && strings.HasPrefix(strings.ToLower(path), "members[") && strings.HasSuffix(path, "]") {
+			expr, err := parseFilter(path[len("members[") : len(path)-1])
+			if err != nil || expr == nil || !strings.EqualFold(expr.Attr, "value") {
+				return patch, fmt.Errorf("%q: %w", path, errInvalidPath)
+			}
+			patch.RemoveMembers = append(patch.RemoveMembers, expr.Value)
+			continue
+		}
+
+		if path == "" {
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				return patch, fmt.Errorf("value: %w", errInvalidValue)
+			}
+			if _, ok := attrs["displayName"]; ok {
+				return patch, fmt.Errorf("displayName: %w", errMutability)
+			}
+			raw, ok := attrs["members"]
+			if !ok {
+				continue
+			}
+			path, op.Value = "members", raw
+		}
+
+		switch strings.ToLower(path) {
+		case "members":
+		case "displayname":
+			return patch, fmt.Errorf("displayName: %w", errMutability)
+		default:
+			return patch, fmt.Errorf("%q: %w", path, errInvalidPath)
+		}
+
+		// remove без value очищает состав целиком.
+		if opName == "remove" && len(op.Value) == 0 {
+			empty := make([]string, 0)
+			patch.SetMembers = &empty
+			continue
+		}
+
+		members, err := parseMembers(op.Value)
+		if err != nil {
+			return patch, fmt.Errorf("members: %w", err)
+		}
+
+		switch opName {
+		case "add":
+			patch.AddMembers = append(patch.AddMembers, members...)
+		case "remove":
+			patch.RemoveMembers = append(patch.RemoveMembers, members...)
+		case "replace":
+			patch.SetMembers = &members
+		default:
+			return patch, fmt.Errorf("op %q: %w", op.Op, errInvalidValue)
+		}
+	}
+	return patch, nil
+}
@@ -0,0 +1,103 @@
+package stats
+
+import "time"
+
+// WindowRequest - период статистики в RFC 3339. По умолчанию последние 30 дней.
+type WindowRequest struct {
+	From     time.Time `query:"from"`
+	To       time.Time `query:"to"`
+	TeamName string    `query:"team_name"`
+}
+
+type WindowResponse struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	TeamName string    `json:"team_name,omitempty"`
+}
+
+type ReviewerStatsResponse struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	Assigned       int    `json:"assigned"`
+	ReassignedAway int    `json:"reassigned_away"`
+	Authored       int    `json:"authored"`
+}
+
+type ReviewersResponse struct {
+	Window    WindowResponse          `json:"window"`
+	Reviewers []ReviewerStatsResponse `json:"reviewers"`
+}
+
+type TeamStatsResponse struct {
+	TeamName       string `json:"team_name"`
+	MembersCount   int    `json:"members_count"`
+	Assigned       int    `json:"assigned"`
+	ReassignedAway int    `json:"reassigned_away"`
+	Authored       int    `json:"authored"`
+}
+
+type TeamsResponse struct {
+	Window WindowResponse      `json:"window"`
+	Teams  []TeamStatsResponse `json:"teams"`
+}
+
+// LatencyRequest - окно статистики и формат ответа: json (по умолчанию) или csv.
+type LatencyRequest struct {
+	From     time.Time `query:"from"`
+	To       time.Time `query:"to"`
+	TeamName string    `query:"team_name"`
+	Format   string    `query:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// PercentilesResponse - перцентили в секундах.
+type PercentilesResponse struct {
+	P50Seconds int64 `json:"p50_seconds"`
+	P90Seconds int64 `json:"p90_seconds"`
+	P99Seconds int64 `json:"p99_seconds"`
+}
+
+type TeamLatencyResponse struct {
+	TeamName    string              `json:"team_name"`
+	Merged      int                 `json:"merged"`
+	TimeToMerge PercentilesResponse `json:"time_to_merge"`
+}
+
+type AuthorLatencyResponse struct {
+	UserID      string              `json:"user_id"`
+	Username    string              `json:"username"`
+	TeamName    string              `json:"team_name"`
+	Merged      int                 `json:"merged"`
+	TimeToMerge PercentilesResponse `json:"time_to_merge"`
+}
+
+type LatencyResponse struct {
+	Window  WindowResponse          `json:"window"`
+	Teams   []TeamLatencyResponse   `json:"teams"`
+	Authors []AuthorLatencyResponse `json:"authors"`
+}
+
+type FairnessRequest struct {
+	From     time.Time `query:"from"`
+	To       time.Time `query:"to"`
+	TeamName string    `query:"team_name" validate:"required"`
+}
+
+type MemberFairnessResponse struct {
+	UserID      string  `json:"user_id"`
+	Username    string  `json:"username"`
+	Assigned    int     `json:"assigned"`
+	ActiveShare float64 `json:"active_share"`
+	IdealShare  float64 `json:"ideal_share"`
+	ActualShare float64 `json:"actual_share"`
+	Expected    float64 `json:"expected"`
+}
+
+type FairnessResponse struct {
+	Window         WindowResponse           `json:"window"`
+	TotalAssigned  int                      `json:"total_assigned"`
+	Gini           float64                  `json:"gini"`
+	Members        []MemberFairnessResponse `json:"members"`
+	MostOverloaded *MemberFairnessResponse  `json:"most_overloaded,omitempty"`
+	MostUnderused  *MemberFairnessResponse  `json:"most_underused,omitempty"`
+}
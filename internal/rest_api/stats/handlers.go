@@ -0,0 +1,230 @@
+//go:generate mockgen --source=handlers.go --destination=mocks/handlers.go -package=mocks
+
+package stats
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/stats"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+type Usecase interface {
+	GetReviewerStats(ctx context.Context, window ucDto.Window) (*ucDto.ReviewerReport, error)
+	GetTeamStats(ctx context.Context, window ucDto.Window) (*ucDto.TeamReport, error)
+	GetMergeLatency(ctx context.Context, window ucDto.Window) (*ucDto.LatencyReport, error)
+	GetFairness(ctx context.Context, window ucDto.Window) (*ucDto.FairnessReport, error)
+}
+
+type Handlers struct {
+	usecase Usecase
+}
+
+func NewHandlers(usecase Usecase) *Handlers {
+	return &Handlers{
+		usecase: usecase,
+	}
+}
+
+func (h *Handlers) RegisterHandlers(r utils.Router) {
+	r.GET("/stats/reviewers", h.GetReviewerStats)
+	r.GET("/stats/teams", h.GetTeamStats)
+	r.GET("/stats/latency", h.GetMergeLatency)
+	r.GET("/stats/fairness", h.GetFairness)
+}
+
+// GetReviewerStats выдает статистику ревью по пользователям за период
+func (h *Handlers) GetReviewerStats(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(WindowRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	report, err := h.usecase.GetReviewerStats(ctx, ucDto.Window(*req))
+	if err != nil {
+		return err
+	}
+
+	resp := ReviewersResponse{
+		Window:    WindowResponse(report.Window),
+		Reviewers: make([]ReviewerStatsResponse, len(report.Reviewers)),
+	}
+	for i, v := range report.Reviewers {
+		resp.Reviewers[i] = ReviewerStatsResponse(v)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetTeamStats выдает статистику ревью по командам за период
+func (h *Handlers) GetTeamStats(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(WindowRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	report, err := h.usecase.GetTeamStats(ctx, ucDto.Window(*req))
+	if err != nil {
+		return err
+	}
+
+	resp := TeamsResponse{
+		Window: WindowResponse(report.Window),
+		Teams:  make([]TeamStatsResponse, len(report.Teams)),
+	}
+	for i, v := range report.Teams {
+		resp.Teams[i] = TeamStatsResponse(v)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetMergeLatency выдает перцентили времени до мержа по командам и авторам в JSON или CSV
+func (h *Handlers) GetMergeLatency(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(LatencyRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	report, err := h.usecase.GetMergeLatency(ctx, ucDto.Window{
+		From:     req.From,
+		To:       req.To,
+		TeamName: req.TeamName,
+	})
+	if err != nil {
+		return err
+	}
+
+	if req.Format == formatCSV {
+		return writeLatencyCSV(c, report)
+	}
+
+	resp := LatencyResponse{
+		Window:  WindowResponse(report.Window),
+		Teams:   make([]TeamLatencyResponse, len(report.Teams)),
+		Authors: make([]AuthorLatencyResponse, len(report.Authors)),
+	}
+	for i, v := range report.Teams {
+		resp.Teams[i] = TeamLatencyResponse{
+			TeamName:    v.TeamName,
+			Merged:      v.Merged,
+			TimeToMerge: toPercentilesResponse(v.TimeToMerge),
+		}
+	}
+	for i, v := range report.Authors {
+		resp.Authors[i] = AuthorLatencyResponse{
+			UserID:      v.UserID,
+			Username:    v.Username,
+			TeamName:    v.TeamName,
+			Merged:      v.Merged,
+			TimeToMerge: toPercentilesResponse(v.TimeToMerge),
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetFairness выдает распределение назначений на ревью в команде относительно справедливых долей
+func (h *Handlers) GetFairness(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(FairnessRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	report, err := h.usecase.GetFairness(ctx, ucDto.Window(*req))
+	if err != nil {
+		return err
+	}
+
+	resp := FairnessResponse{
+		Window:        WindowResponse(report.Window),
+		TotalAssigned: report.TotalAssigned,
+		Gini:          report.Gini,
+		Members:       make([]MemberFairnessResponse, len(report.Members)),
+	}
+	for i, v := range report.Members {
+		resp.Members[i] = MemberFairnessResponse(v)
+	}
+	if report.MostOverloaded != nil {
+		m := MemberFairnessResponse(*report.MostOverloaded)
+		resp.MostOverloaded = &m
+	}
+	if report.MostUnderused != nil {
+		m := MemberFairnessResponse(*report.MostUnderused)
+		resp.MostUnderused = &m
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+const formatCSV = "csv"
+
+var latencyCSVHeader = []string{
+	"scope", "team_name", "user_id", "username", "merged",
+	"time_to_merge_p50_seconds", "time_to_merge_p90_seconds", "time_to_merge_p99_seconds",
+}
+
+// writeLatencyCSV отдает отчет одним CSV: сначала строки команд (scope=team), затем авторов (scope=author).
+func writeLatencyCSV(c echo.Context, report *ucDto.LatencyReport) error {
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	resp.Header().Set(echo.HeaderContentDisposition, `attachment; filename="latency.csv"`)
+	resp.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(resp)
+	rows := [][]string{latencyCSVHeader}
+	for _, v := range report.Teams {
+		rows = append(rows, latencyCSVRow("team", v.TeamName, "", "", v.Merged, v.TimeToMerge))
+	}
+	for _, v := range report.Authors {
+		rows = append(rows, latencyCSVRow("author", v.TeamName, v.UserID, v.Username, v.Merged, v.TimeToMerge))
+	}
+	return w.WriteAll(rows)
+}
+
+func latencyCSVRow(scope, teamName, userID, username string, merged int, p ucDto.Percentiles) []string {
+	return []string{
+		scope, teamName, userID, username, strconv.Itoa(merged),
+		strconv.FormatInt(int64(p.P50/time.Second), 10),
+		strconv.FormatInt(int64(p.P90/time.Second), 10),
+		strconv.FormatInt(int64(p.P99/time.Second), 10),
+	}
+}
+
+func toPercentilesResponse(p ucDto.Percentiles) PercentilesResponse {
+	return PercentilesResponse{
+		P50Seconds: int64(p.P50 / time.Second),
+		P90Seconds: int64(p.P90 / time.Second),
+		P99Seconds: int64(p.P99 / time.Second),
+	}
+}
@@ -0,0 +1,355 @@
+package stats
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/rest_api/stats/mocks"
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/stats"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+func Test_GetReviewerStats(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("error_bind", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: mocks.NewMockUsecase(ctrl)}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/reviewers?from=yesterday", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetReviewerStats(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: usecaseMock}
+
+		window := ucDto.Window{From: from, To: to, TeamName: "backend"}
+		usecaseMock.EXPECT().
+			GetReviewerStats(gomock.Any(), window).
+			Return(&ucDto.ReviewerReport{
+				Window: window,
+				Reviewers: []ucDto.ReviewerStats{
+					{UserID: "u1", Username: "Alice", TeamName: "backend", Assigned: 4, ReassignedAway: 1, Authored: 3},
+				},
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/stats/reviewers?from=2025-03-01T00:00:00Z&to=2025-04-01T00:00:00Z&team_name=backend", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetReviewerStats(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response ReviewersResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, ReviewersResponse{
+			Window: WindowResponse{From: from, To: to, TeamName: "backend"},
+			Reviewers: []ReviewerStatsResponse{
+				{UserID: "u1", Username: "Alice", TeamName: "backend", Assigned: 4, ReassignedAway: 1, Authored: 3},
+			},
+		}, response)
+	})
+
+	t.Run("invalid_window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			GetReviewerStats(gomock.Any(), gomock.Any()).
+			Return(nil, ucDto.ErrInvalidWindow).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/reviewers?from=2025-04-01T00:00:00Z&to=2025-03-01T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetReviewerStats(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func Test_GetTeamStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecaseMock := mocks.NewMockUsecase(ctrl)
+
+	e := echo.New()
+	e.Validator = utils.NewHTTPRequestValidator()
+	e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+	h := &Handlers{usecase: usecaseMock}
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	usecaseMock.EXPECT().
+		GetTeamStats(gomock.Any(), ucDto.Window{}).
+		Return(&ucDto.TeamReport{
+			Window: ucDto.Window{From: from, To: to},
+			Teams:  []ucDto.TeamStats{{TeamName: "backend", MembersCount: 3, Assigned: 9, Authored: 4}},
+		}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/teams", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.GetTeamStats(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response TeamsResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, TeamsResponse{
+		Window: WindowResponse{From: from, To: to},
+		Teams:  []TeamStatsResponse{{TeamName: "backend", MembersCount: 3, Assigned: 9, Authored: 4}},
+	}, response)
+}
+
+func Test_GetMergeLatency(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	window := ucDto.Window{From: from, To: to}
+	percentiles := ucDto.Percentiles{P50: time.Hour, P90: 2 * time.Hour, P99: 24 * time.Hour}
+	report := &ucDto.LatencyReport{
+		Window:  window,
+		Teams:   []ucDto.TeamLatency{{TeamName: "backend", Merged: 4, TimeToMerge: percentiles}},
+		Authors: []ucDto.AuthorLatency{{UserID: "u1", Username: "Alice", TeamName: "backend", Merged: 4, TimeToMerge: percentiles}},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			GetMergeLatency(gomock.Any(), window).
+			Return(report, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/latency?from=2025-03-01T00:00:00Z&to=2025-04-01T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetMergeLatency(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response LatencyResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		expected := PercentilesResponse{P50Seconds: 3600, P90Seconds: 7200, P99Seconds: 86400}
+		assert.Equal(t, LatencyResponse{
+			Window:  WindowResponse{From: from, To: to},
+			Teams:   []TeamLatencyResponse{{TeamName: "backend", Merged: 4, TimeToMerge: expected}},
+			Authors: []AuthorLatencyResponse{{UserID: "u1", Username: "Alice", TeamName: "backend", Merged: 4, TimeToMerge: expected}},
+		}, response)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			GetMergeLatency(gomock.Any(), window).
+			Return(report, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/stats/latency?from=2025-03-01T00:00:00Z&to=2025-04-01T00:00:00Z&format=csv", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetMergeLatency(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t,
+			"scope,team_name,user_id,username,merged,time_to_merge_p50_seconds,time_to_merge_p90_seconds,time_to_merge_p99_seconds\n"+
+				"team,backend,,,4,3600,7200,86400\n"+
+				"author,backend,u1,Alice,4,3600,7200,86400\n",
+			rec.Body.String())
+	})
+
+	t.Run("unknown_format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: mocks.NewMockUsecase(ctrl)}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/latency?format=xml", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetMergeLatency(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func Test_GetFairness(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	window := ucDto.Window{From: from, To: to, TeamName: "backend"}
+
+	t.Run("team_required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: mocks.NewMockUsecase(ctrl)}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/fairness", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetFairness(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: usecaseMock}
+
+		alice := ucDto.MemberFairness{UserID: "u1", Username: "Alice", Assigned: 3, ActiveShare: 1, IdealShare: 0.5, ActualShare: 0.75, Expected: 2}
+		bob := ucDto.MemberFairness{UserID: "u2", Username: "Bob", Assigned: 1, ActiveShare: 1, IdealShare: 0.5, ActualShare: 0.25, Expected: 2}
+		usecaseMock.EXPECT().
+			GetFairness(gomock.Any(), window).
+			Return(&ucDto.FairnessReport{
+				Window:         window,
+				TotalAssigned:  4,
+				Gini:           0.25,
+				Members:        []ucDto.MemberFairness{alice, bob},
+				MostOverloaded: &alice,
+				MostUnderused:  &bob,
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/stats/fairness?from=2025-03-01T00:00:00Z&to=2025-04-01T00:00:00Z&team_name=backend", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetFairness(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response FairnessResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		aliceResp := MemberFairnessResponse(alice)
+		bobResp := MemberFairnessResponse(bob)
+		assert.Equal(t, FairnessResponse{
+			Window:         WindowResponse{From: from, To: to, TeamName: "backend"},
+			TotalAssigned:  4,
+			Gini:           0.25,
+			Members:        []MemberFairnessResponse{aliceResp, bobResp},
+			MostOverloaded: &aliceResp,
+			MostUnderused:  &bobResp,
+		}, response)
+	})
+
+	t.Run("team_not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{usecase: usecaseMock}
+
+		usecaseMock.EXPECT().
+			GetFairness(gomock.Any(), gomock.Any()).
+			Return(nil, ucDto.ErrTeamNotFound).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/fairness?team_name=ghosts", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.GetFairness(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handlers.go
+//
+// Generated by this command:
+//
+//	mockgen --source=handlers.go --destination=mocks/handlers.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	stats "github.com/qwerty268/pull_request_service/internal/usecases/stats"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetFairness mocks base method.
+func (m *MockUsecase) GetFairness(ctx context.Context, window stats.Window) (*stats.FairnessReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFairness", ctx, window)
+	ret0, _ := ret[0].(*stats.FairnessReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFairness indicates an expected call of GetFairness.
+func (mr *MockUsecaseMockRecorder) GetFairness(ctx, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFairness", reflect.TypeOf((*MockUsecase)(nil).GetFairness), ctx, window)
+}
+
+// GetMergeLatency mocks base method.
+func (m *MockUsecase) GetMergeLatency(ctx context.Context, window stats.Window) (*stats.LatencyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergeLatency", ctx, window)
+	ret0, _ := ret[0].(*stats.LatencyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergeLatency indicates an expected call of GetMergeLatency.
+func (mr *MockUsecaseMockRecorder) GetMergeLatency(ctx, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergeLatency", reflect.TypeOf((*MockUsecase)(nil).GetMergeLatency), ctx, window)
+}
+
+// GetReviewerStats mocks base method.
+func (m *MockUsecase) GetReviewerStats(ctx context.Context, window stats.Window) (*stats.ReviewerReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewerStats", ctx, window)
+	ret0, _ := ret[0].(*stats.ReviewerReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewerStats indicates an expected call of GetReviewerStats.
+func (mr *MockUsecaseMockRecorder) GetReviewerStats(ctx, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewerStats", reflect.TypeOf((*MockUsecase)(nil).GetReviewerStats), ctx, window)
+}
+
+// GetTeamStats mocks base method.
+func (m *MockUsecase) GetTeamStats(ctx context.Context, window stats.Window) (*stats.TeamReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamStats", ctx, window)
+	ret0, _ := ret[0].(*stats.TeamReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamStats indicates an expected call of GetTeamStats.
+func (mr *MockUsecaseMockRecorder) GetTeamStats(ctx, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamStats", reflect.TypeOf((*MockUsecase)(nil).GetTeamStats), ctx, window)
+}
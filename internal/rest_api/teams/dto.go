@@ -6,21 +6,12 @@ type GetTeamRequest struct {
 	TeamName string `query:"team_name" validate:"required"`
 }
 
-type ErrorDetail struct {
-	Code    string `json:"code" validate:"required,oneof=TEAM_EXISTS PR_EXISTS PR_MERGED NOT_ASSIGNED NO_CANDIDATE NOT_FOUND"`
-	Message string `json:"message" validate:"required"`
-}
-
-// ErrorResponse - структура для ошибок API
-type ErrorResponse struct {
-	Error ErrorDetail `json:"error" validate:"required"`
-}
-
 // TeamMemberRequest - участник команды
 type TeamMemberRequest struct {
 	UserID   string `json:"user_id" validate:"required"`
 	Username string `json:"username" validate:"required"`
 	IsActive *bool  `json:"is_active" validate:"required"`
+	Role     string `json:"role,omitempty" validate:"omitempty,oneof=member lead maintainer"`
 }
 
 // AddTeamRequest - команда с участниками
@@ -33,9 +24,87 @@ type TeamMemberResponse struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role"`
 }
 
 type TeamResponse struct {
 	TeamName string               `json:"team_name"`
 	Members  []TeamMemberResponse `json:"members"`
 }
+
+// GetTeamSettingsRequest - параметр запроса для имени команды
+type GetTeamSettingsRequest struct {
+	TeamName string `query:"team_name" validate:"required"`
+}
+
+// UpdateTeamSettingsRequest - изменение настроек команды. Незаданные поля не меняются.
+type UpdateTeamSettingsRequest struct {
+	TeamName          string  `json:"team_name" validate:"required"`
+	ReviewersCount    *int    `json:"reviewers_count" validate:"omitempty,min=0,max=10"`
+	SelectionStrategy *string `json:"selection_strategy" validate:"omitempty,oneof=random least_loaded"`
+	MinReviewers      *int    `json:"min_reviewers" validate:"omitempty,min=0,max=10"`
+}
+
+type TeamSettingsResponse struct {
+	TeamName          string `json:"team_name"`
+	ReviewersCount    int    `json:"reviewers_count"`
+	SelectionStrategy string `json:"selection_strategy"`
+	MinReviewers      int    `json:"min_reviewers"`
+}
+
+// ListTeamsRequest - фильтр и пагинация списка команд
+type ListTeamsRequest struct {
+	NamePrefix string `query:"name_prefix"`
+	Limit      int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset     int    `query:"offset" validate:"omitempty,min=0"`
+}
+
+type TeamSummaryResponse struct {
+	TeamName           string `json:"team_name"`
+	MembersCount       int    `json:"members_count"`
+	ActiveMembersCount int    `json:"active_members_count"`
+	OpenPRsCount       int    `json:"open_prs_count"`
+}
+
+type ListTeamsResponse struct {
+	Teams  []TeamSummaryResponse `json:"teams"`
+	Total  int                   `json:"total"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+// SetMemberRoleRequest - смена роли участника команды
+type SetMemberRoleRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	UserID   string `json:"user_id" validate:"required"`
+	Role     string `json:"role" validate:"required,oneof=member lead maintainer"`
+}
+
+type SetMemberRoleResponse struct {
+	TeamName string             `json:"team_name"`
+	Member   TeamMemberResponse `json:"member"`
+}
+
+// ImportTeamsRequest - параметры импорта. Сам файл передается телом запроса.
+type ImportTeamsRequest struct {
+	Format string `query:"format" validate:"required,oneof=csv yaml"`
+	DryRun bool   `query:"dry_run"`
+}
+
+type RosterChangeResponse struct {
+	Kind     string `json:"kind"`
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id,omitempty"`
+	Details  string `json:"details,omitempty"`
+}
+
+type RowErrorResponse struct {
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+type ImportTeamsResponse struct {
+	Applied bool                   `json:"applied"`
+	Changes []RosterChangeResponse `json:"changes"`
+	Errors  []RowErrorResponse     `json:"errors"`
+}
@@ -5,6 +5,7 @@ package restapi
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -16,8 +17,16 @@ import (
 type Usecase interface {
 	AddTeam(ctx context.Context, team ucDto.Team) error
 	GetTeam(ctx context.Context, teamName string) (*ucDto.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*ucDto.Settings, error)
+	UpdateTeamSettings(ctx context.Context, update ucDto.SettingsUpdate) (*ucDto.Settings, error)
+	ListTeams(ctx context.Context, filter ucDto.ListTeamsFilter) (*ucDto.TeamsPage, error)
+	SetMemberRole(ctx context.Context, teamName, userID, role string) (*ucDto.TeamMember, error)
+	ImportRoster(ctx context.Context, rows []ucDto.RosterRow, dryRun bool) (*ucDto.ImportResult, error)
 }
 
+// maxRosterSize - ограничение на размер импортируемого файла.
+const maxRosterSize = 10 << 20
+
 type Handlers struct {
 	getter Usecase
 }
@@ -28,9 +37,14 @@ func NewHandlers(getter Usecase) *Handlers {
 	}
 }
 
-func (h *Handlers) RegisterHandlers(e *echo.Echo) {
-	e.POST("/team/add", h.AddTeam)
-	e.GET("/team/get", h.GetTeam)
+func (h *Handlers) RegisterHandlers(r utils.Router) {
+	r.POST("/team/add", h.AddTeam)
+	r.GET("/team/get", h.GetTeam)
+	r.GET("/team/list", h.ListTeams)
+	r.GET("/team/settings", h.GetTeamSettings)
+	r.POST("/team/settings/update", h.UpdateTeamSettings)
+	r.POST("/team/setRole", h.SetMemberRole)
+	r.POST("/team/import", h.ImportTeams)
 }
 
 func (h *Handlers) AddTeam(c echo.Context) error {
@@ -42,21 +56,12 @@ func (h *Handlers) AddTeam(c echo.Context) error {
 	}
 
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	err := h.getter.AddTeam(ctx, addTeamrequestToUcDto(req))
 	if err != nil {
-		if errors.Is(err, ucDto.ErrAlreadyExists) {
-			return returnBadRequest(
-				c,
-				ErrorDetail{
-					Code:    utils.TeamExists,
-					Message: "team_name already exists",
-				},
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, req)
@@ -72,26 +77,159 @@ func (h *Handlers) GetTeam(c echo.Context) error {
 	}
 
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	team, err := h.getter.GetTeam(ctx, req.TeamName)
 	if err != nil {
-		if errors.Is(err, ucDto.ErrNotFound) {
-			return returnNotFound(
-				c,
-				ErrorDetail{
-					Code:    utils.NotFound,
-					Message: "team not found",
-				},
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, ucDtoToTeamResponse(team))
 }
 
+// ListTeams выдает страницу команд со счетчиками участников и открытых PR
+func (h *Handlers) ListTeams(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(ListTeamsRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if req.Limit == 0 {
+		req.Limit = ucDto.DefaultListLimit
+	}
+
+	page, err := h.getter.ListTeams(ctx, ucDto.ListTeamsFilter(*req))
+	if err != nil {
+		return err
+	}
+
+	resp := ListTeamsResponse{
+		Teams:  make([]TeamSummaryResponse, len(page.Teams)),
+		Total:  page.Total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for i, v := range page.Teams {
+		resp.Teams[i] = TeamSummaryResponse(v)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetTeamSettings выдает действующие настройки команды
+func (h *Handlers) GetTeamSettings(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(GetTeamSettingsRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	settings, err := h.getter.GetTeamSettings(ctx, req.TeamName)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, TeamSettingsResponse(*settings))
+}
+
+// UpdateTeamSettings обновляет настройки команды
+func (h *Handlers) UpdateTeamSettings(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(UpdateTeamSettingsRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	settings, err := h.getter.UpdateTeamSettings(ctx, ucDto.SettingsUpdate(*req))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, TeamSettingsResponse(*settings))
+}
+
+// SetMemberRole назначает участнику команды роль
+func (h *Handlers) SetMemberRole(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(SetMemberRoleRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	member, err := h.getter.SetMemberRole(ctx, req.TeamName, req.UserID, req.Role)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, SetMemberRoleResponse{
+		TeamName: req.TeamName,
+		Member:   TeamMemberResponse(*member),
+	})
+}
+
+// ImportTeams импортирует команды из CSV или YAML файла
+func (h *Handlers) ImportTeams(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(ImportTeamsRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	rows, err := ucDto.ParseRoster(req.Format, io.LimitReader(c.Request().Body, maxRosterSize))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	result, err := h.getter.ImportRoster(ctx, rows, req.DryRun)
+	if err != nil && !errors.Is(err, ucDto.ErrInvalidRoster) {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resp := ImportTeamsResponse{
+		Applied: result.Applied,
+		Changes: make([]RosterChangeResponse, len(result.Changes)),
+		Errors:  make([]RowErrorResponse, len(result.Errors)),
+	}
+	for i, v := range result.Changes {
+		resp.Changes[i] = RosterChangeResponse(v)
+	}
+	for i, v := range result.Errors {
+		resp.Errors[i] = RowErrorResponse(v)
+	}
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, resp)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
 func ucDtoToTeamResponse(team *ucDto.Team) *TeamResponse {
 	if team == nil {
 		return nil
@@ -120,22 +258,9 @@ func addTeamrequestToUcDto(req *AddTeamRequest) ucDto.Team {
 			UserID:   v.UserID,
 			Username: v.Username,
 			IsActive: *v.IsActive,
+			Role:     v.Role,
 		}
 	}
 
 	return team
 }
-
-func returnBadRequest(c echo.Context, err ErrorDetail) error {
-	return c.JSON(
-		http.StatusBadRequest,
-		ErrorResponse{err},
-	)
-}
-
-func returnNotFound(c echo.Context, err ErrorDetail) error {
-	return c.JSON(
-		http.StatusNotFound,
-		ErrorResponse{err},
-	)
-}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/qwerty268/pull_request_service/internal/rest_api/teams/mocks"
@@ -24,10 +25,11 @@ func Test_AddTeam(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		getterMock := mocks.NewMockGetter(ctrl)
+		getterMock := mocks.NewMockUsecase(ctrl)
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &Handlers{
 			getter: getterMock,
@@ -50,22 +52,21 @@ func Test_AddTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		resp := "validate: Key: 'AddTeamRequest.TeamName' Error:Field validation for 'TeamName' failed on the 'required' tag"
-
 		err := h.AddTeam(c)
-		assert.Error(t, err)
-		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
-		assert.Equal(t, resp, err.(*echo.HTTPError).Message)
+		var validationErr *utils.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []utils.FieldError{{Field: "team_name", Rule: "required", Message: "is required"}}, validationErr.Fields)
 	})
 
 	t.Run("success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		getterMock := mocks.NewMockGetter(ctrl)
+		getterMock := mocks.NewMockUsecase(ctrl)
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &Handlers{
 			getter: getterMock,
@@ -108,10 +109,11 @@ func Test_AddTeam(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		getterMock := mocks.NewMockGetter(ctrl)
+		getterMock := mocks.NewMockUsecase(ctrl)
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &Handlers{
 			getter: getterMock,
@@ -139,18 +141,17 @@ func Test_AddTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		expectedResponse := ErrorResponse{
-			Error: ErrorDetail{
+		expectedResponse := utils.ErrorResponse{
+			Error: utils.ErrorDetail{
 				Code:    utils.TeamExists,
 				Message: "team_name already exists",
 			},
 		}
 
-		err := h.AddTeam(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.AddTeam(c), c)
 
-		var response ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var response utils.ErrorResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 	})
@@ -159,10 +160,11 @@ func Test_AddTeam(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		getterMock := mocks.NewMockGetter(ctrl)
+		getterMock := mocks.NewMockUsecase(ctrl)
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &Handlers{
 			getter: getterMock,
@@ -192,7 +194,8 @@ func Test_AddTeam(t *testing.T) {
 
 		err := h.AddTeam(c)
 		assert.Error(t, err)
-		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
 }
 
@@ -201,10 +204,11 @@ func Test_GetTeam(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		getterMock := mocks.NewMockGetter(ctrl)
+		getterMock := mocks.NewMockUsecase(ctrl)
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &Handlers{
 			getter: getterMock,
@@ -215,22 +219,21 @@ func Test_GetTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		resp := "validate: Key: 'GetTeamRequest.TeamName' Error:Field validation for 'TeamName' failed on the 'required' tag"
-
 		err := h.GetTeam(c)
-		assert.Error(t, err)
-		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
-		assert.Equal(t, resp, err.(*echo.HTTPError).Message)
+		var validationErr *utils.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []utils.FieldError{{Field: "team_name", Rule: "required", Message: "is required"}}, validationErr.Fields)
 	})
 
 	t.Run("success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		getterMock := mocks.NewMockGetter(ctrl)
+		getterMock := mocks.NewMockUsecase(ctrl)
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &Handlers{
 			getter: getterMock,
@@ -272,10 +275,11 @@ func Test_GetTeam(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		getterMock := mocks.NewMockGetter(ctrl)
+		getterMock := mocks.NewMockUsecase(ctrl)
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &Handlers{
 			getter: getterMock,
@@ -290,18 +294,17 @@ func Test_GetTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		expectedResponse := ErrorResponse{
-			Error: ErrorDetail{
+		expectedResponse := utils.ErrorResponse{
+			Error: utils.ErrorDetail{
 				Code:    utils.NotFound,
-				Message: "team not found",
+				Message: "team or team member not found",
 			},
 		}
 
-		err := h.GetTeam(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.GetTeam(c), c)
 
-		var response ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var response utils.ErrorResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 		assert.Equal(t, http.StatusNotFound, rec.Code)
@@ -311,10 +314,11 @@ func Test_GetTeam(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		getterMock := mocks.NewMockGetter(ctrl)
+		getterMock := mocks.NewMockUsecase(ctrl)
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &Handlers{
 			getter: getterMock,
@@ -331,6 +335,469 @@ func Test_GetTeam(t *testing.T) {
 
 		err := h.GetTeam(c)
 		assert.Error(t, err)
-		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func Test_GetTeamSettings(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		settings := &ucDto.Settings{
+			TeamName:          "backend",
+			ReviewersCount:    2,
+			SelectionStrategy: ucDto.StrategyRandom,
+			MinReviewers:      1,
+		}
+
+		getterMock.EXPECT().
+			GetTeamSettings(gomock.Any(), "backend").
+			Return(settings, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/team/settings?team_name=backend", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetTeamSettings(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response TeamSettingsResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TeamSettingsResponse(*settings), response)
+	})
+
+	t.Run("team_not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		getterMock.EXPECT().
+			GetTeamSettings(gomock.Any(), "unknown").
+			Return(nil, ucDto.ErrNotFound).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/team/settings?team_name=unknown", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.GetTeamSettings(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func Test_UpdateTeamSettings(t *testing.T) {
+	t.Run("error_validate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		reqBody := []byte(`{"team_name": "backend", "selection_strategy": "round_robin"}`)
+		req := httptest.NewRequest(http.MethodPost, "/team/settings/update", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.UpdateTeamSettings(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		reviewers := 3
+		getterMock.EXPECT().
+			UpdateTeamSettings(gomock.Any(), ucDto.SettingsUpdate{TeamName: "backend", ReviewersCount: &reviewers}).
+			Return(&ucDto.Settings{TeamName: "backend", ReviewersCount: 3}, nil).
+			Times(1)
+
+		reqBody := []byte(`{"team_name": "backend", "reviewers_count": 3}`)
+		req := httptest.NewRequest(http.MethodPost, "/team/settings/update", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.UpdateTeamSettings(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response TeamSettingsResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, response.ReviewersCount)
+	})
+
+	t.Run("invalid_settings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		getterMock.EXPECT().
+			UpdateTeamSettings(gomock.Any(), gomock.Any()).
+			Return(nil, ucDto.ErrInvalidSettings).
+			Times(1)
+
+		reqBody := []byte(`{"team_name": "backend", "min_reviewers": 5}`)
+		req := httptest.NewRequest(http.MethodPost, "/team/settings/update", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.UpdateTeamSettings(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func Test_ListTeams(t *testing.T) {
+	t.Run("error_validate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/team/list?limit=1000", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.ListTeams(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		getterMock.EXPECT().
+			ListTeams(gomock.Any(), ucDto.ListTeamsFilter{NamePrefix: "back", Limit: ucDto.DefaultListLimit, Offset: 10}).
+			Return(&ucDto.TeamsPage{
+				Teams: []ucDto.TeamSummary{
+					{TeamName: "backend", MembersCount: 3, ActiveMembersCount: 2, OpenPRsCount: 1},
+				},
+				Total: 11,
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/team/list?name_prefix=back&offset=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.ListTeams(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response ListTeamsResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, ListTeamsResponse{
+			Teams: []TeamSummaryResponse{
+				{TeamName: "backend", MembersCount: 3, ActiveMembersCount: 2, OpenPRsCount: 1},
+			},
+			Total:  11,
+			Limit:  ucDto.DefaultListLimit,
+			Offset: 10,
+		}, response)
+	})
+
+	t.Run("internal_error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		getterMock.EXPECT().
+			ListTeams(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("internal error")).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/team/list", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.ListTeams(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func Test_SetMemberRole(t *testing.T) {
+	t.Run("error_validate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		reqBody := []byte(`{"team_name": "backend", "user_id": "u1", "role": "owner"}`)
+		req := httptest.NewRequest(http.MethodPost, "/team/setRole", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.SetMemberRole(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		getterMock.EXPECT().
+			SetMemberRole(gomock.Any(), "backend", "u1", ucDto.RoleLead).
+			Return(&ucDto.TeamMember{UserID: "u1", Username: "Alice", IsActive: true, Role: ucDto.RoleLead}, nil).
+			Times(1)
+
+		reqBody := []byte(`{"team_name": "backend", "user_id": "u1", "role": "lead"}`)
+		req := httptest.NewRequest(http.MethodPost, "/team/setRole", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.SetMemberRole(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response SetMemberRoleResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, SetMemberRoleResponse{
+			TeamName: "backend",
+			Member:   TeamMemberResponse{UserID: "u1", Username: "Alice", IsActive: true, Role: ucDto.RoleLead},
+		}, response)
+	})
+
+	t.Run("member_not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		getterMock.EXPECT().
+			SetMemberRole(gomock.Any(), "backend", "u9", ucDto.RoleMaintainer).
+			Return(nil, ucDto.ErrNotFound).
+			Times(1)
+
+		reqBody := []byte(`{"team_name": "backend", "user_id": "u9", "role": "maintainer"}`)
+		req := httptest.NewRequest(http.MethodPost, "/team/setRole", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.SetMemberRole(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func Test_ImportTeams(t *testing.T) {
+	roster := "team_name,user_id,username,is_active\nbackend,u1,Alice,true\n"
+
+	t.Run("error_validate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/team/import?format=xml", bytes.NewReader([]byte(roster)))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.ImportTeams(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("dry_run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		getterMock.EXPECT().
+			ImportRoster(gomock.Any(), []ucDto.RosterRow{
+				{Location: "line 2", TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: "true"},
+			}, true).
+			Return(&ucDto.ImportResult{
+				Changes: []ucDto.RosterChange{{Kind: ucDto.ChangeCreateTeam, TeamName: "backend"}},
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/team/import?format=csv&dry_run=true", bytes.NewReader([]byte(roster)))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.ImportTeams(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response ImportTeamsResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, ImportTeamsResponse{
+			Changes: []RosterChangeResponse{{Kind: ucDto.ChangeCreateTeam, TeamName: "backend"}},
+			Errors:  []RowErrorResponse{},
+		}, response)
+	})
+
+	t.Run("invalid_rows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		getterMock := mocks.NewMockUsecase(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &Handlers{
+			getter: getterMock,
+		}
+
+		getterMock.EXPECT().
+			ImportRoster(gomock.Any(), gomock.Any(), false).
+			Return(&ucDto.ImportResult{
+				Errors: []ucDto.RowError{{Location: "line 2", Message: "unknown role"}},
+			}, ucDto.ErrInvalidRoster).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/team/import?format=csv", bytes.NewReader([]byte(roster)))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.ImportTeams(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+		var response ImportTeamsResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []RowErrorResponse{{Location: "line 2", Message: "unknown role"}}, response.Errors)
 	})
 }
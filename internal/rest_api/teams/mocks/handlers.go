@@ -17,32 +17,32 @@ import (
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockGetter is a mock of Getter interface.
-type MockGetter struct {
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
 	ctrl     *gomock.Controller
-	recorder *MockGetterMockRecorder
+	recorder *MockUsecaseMockRecorder
 	isgomock struct{}
 }
 
-// MockGetterMockRecorder is the mock recorder for MockGetter.
-type MockGetterMockRecorder struct {
-	mock *MockGetter
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
 }
 
-// NewMockGetter creates a new mock instance.
-func NewMockGetter(ctrl *gomock.Controller) *MockGetter {
-	mock := &MockGetter{ctrl: ctrl}
-	mock.recorder = &MockGetterMockRecorder{mock}
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockGetter) EXPECT() *MockGetterMockRecorder {
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 	return m.recorder
 }
 
 // AddTeam mocks base method.
-func (m *MockGetter) AddTeam(ctx context.Context, team teams.Team) error {
+func (m *MockUsecase) AddTeam(ctx context.Context, team teams.Team) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddTeam", ctx, team)
 	ret0, _ := ret[0].(error)
@@ -50,13 +50,13 @@ func (m *MockGetter) AddTeam(ctx context.Context, team teams.Team) error {
 }
 
 // AddTeam indicates an expected call of AddTeam.
-func (mr *MockGetterMockRecorder) AddTeam(ctx, team any) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) AddTeam(ctx, team any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeam", reflect.TypeOf((*MockGetter)(nil).AddTeam), ctx, team)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeam", reflect.TypeOf((*MockUsecase)(nil).AddTeam), ctx, team)
 }
 
 // GetTeam mocks base method.
-func (m *MockGetter) GetTeam(ctx context.Context, teamName string) (*teams.Team, error) {
+func (m *MockUsecase) GetTeam(ctx context.Context, teamName string) (*teams.Team, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTeam", ctx, teamName)
 	ret0, _ := ret[0].(*teams.Team)
@@ -65,7 +65,82 @@ func (m *MockGetter) GetTeam(ctx context.Context, teamName string) (*teams.Team,
 }
 
 // GetTeam indicates an expected call of GetTeam.
-func (mr *MockGetterMockRecorder) GetTeam(ctx, teamName any) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) GetTeam(ctx, teamName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockGetter)(nil).GetTeam), ctx, teamName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockUsecase)(nil).GetTeam), ctx, teamName)
+}
+
+// GetTeamSettings mocks base method.
+func (m *MockUsecase) GetTeamSettings(ctx context.Context, teamName string) (*teams.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamSettings", ctx, teamName)
+	ret0, _ := ret[0].(*teams.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamSettings indicates an expected call of GetTeamSettings.
+func (mr *MockUsecaseMockRecorder) GetTeamSettings(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamSettings", reflect.TypeOf((*MockUsecase)(nil).GetTeamSettings), ctx, teamName)
+}
+
+// ImportRoster mocks base method.
+func (m *MockUsecase) ImportRoster(ctx context.Context, rows []teams.RosterRow, dryRun bool) (*teams.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportRoster", ctx, rows, dryRun)
+	ret0, _ := ret[0].(*teams.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportRoster indicates an expected call of ImportRoster.
+func (mr *MockUsecaseMockRecorder) ImportRoster(ctx, rows, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportRoster", reflect.TypeOf((*MockUsecase)(nil).ImportRoster), ctx, rows, dryRun)
+}
+
+// ListTeams mocks base method.
+func (m *MockUsecase) ListTeams(ctx context.Context, filter teams.ListTeamsFilter) (*teams.TeamsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", ctx, filter)
+	ret0, _ := ret[0].(*teams.TeamsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockUsecaseMockRecorder) ListTeams(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockUsecase)(nil).ListTeams), ctx, filter)
+}
+
+// SetMemberRole mocks base method.
+func (m *MockUsecase) SetMemberRole(ctx context.Context, teamName, userID, role string) (*teams.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMemberRole", ctx, teamName, userID, role)
+	ret0, _ := ret[0].(*teams.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMemberRole indicates an expected call of SetMemberRole.
+func (mr *MockUsecaseMockRecorder) SetMemberRole(ctx, teamName, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRole", reflect.TypeOf((*MockUsecase)(nil).SetMemberRole), ctx, teamName, userID, role)
+}
+
+// UpdateTeamSettings mocks base method.
+func (m *MockUsecase) UpdateTeamSettings(ctx context.Context, update teams.SettingsUpdate) (*teams.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamSettings", ctx, update)
+	ret0, _ := ret[0].(*teams.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamSettings indicates an expected call of UpdateTeamSettings.
+func (mr *MockUsecaseMockRecorder) UpdateTeamSettings(ctx, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamSettings", reflect.TypeOf((*MockUsecase)(nil).UpdateTeamSettings), ctx, update)
 }
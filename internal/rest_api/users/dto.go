@@ -1,15 +1,23 @@
 package users
 
+import "time"
+
 type SetUserActiveRequest struct {
 	UserID   string `json:"user_id" validate:"required"`
 	IsActive *bool  `json:"is_active" validate:"required"`
 }
 
 type SetUserActiveResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name"`
+	IsActive    bool   `json:"is_active"`
+	Email       string `json:"email,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+	ChatHandle  string `json:"chat_handle,omitempty"`
+	VCSLogin    string `json:"vcs_login,omitempty"`
+	GitLabID    string `json:"gitlab_user_id,omitempty"`
 }
 
 type GetUserReviewRequestsRequest struct {
@@ -28,12 +36,142 @@ type GetUserReviewRequestsResponse struct {
 	PullRequests []PullRequestShort `json:"pull_requests"`
 }
 
-type ErrorDetail struct {
-	Code    string `json:"code" validate:"required,oneof=TEAM_EXISTS PR_EXISTS PR_MERGED NOT_ASSIGNED NO_CANDIDATE NOT_FOUND"`
-	Message string `json:"message" validate:"required"`
+// MoveTeamRequest - перевод пользователя в другую команду
+type MoveTeamRequest struct {
+	UserID          string `json:"user_id" validate:"required"`
+	TeamName        string `json:"team_name" validate:"required"`
+	HandoverReviews bool   `json:"handover_reviews"`
+}
+
+type ReviewHandover struct {
+	PullRequestID string `json:"pull_request_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+type MoveTeamResponse struct {
+	User        SetUserActiveResponse `json:"user"`
+	HandedOver  []ReviewHandover      `json:"handed_over"`
+	KeptReviews []string              `json:"kept_reviews"`
+}
+
+type GetUserRequest struct {
+	UserID string `query:"user_id" validate:"required"`
+}
+
+type UserResponse struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name"`
+	IsActive    bool   `json:"is_active"`
+	Email       string `json:"email,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+	ChatHandle  string `json:"chat_handle,omitempty"`
+	VCSLogin    string `json:"vcs_login,omitempty"`
+	GitLabID    string `json:"gitlab_user_id,omitempty"`
+}
+
+// UpdateUserRequest - изменение профиля. Отсутствующие поля не меняются, пустая строка очищает поле.
+type UpdateUserRequest struct {
+	UserID      string  `json:"user_id" validate:"required"`
+	Username    *string `json:"username" validate:"omitempty,min=1,max=255"`
+	Email       *string `json:"email" validate:"omitempty,eq=|email"`
+	DisplayName *string `json:"display_name" validate:"omitempty,max=255"`
+	Timezone    *string `json:"timezone" validate:"omitempty,eq=|timezone"`
+	ChatHandle  *string `json:"chat_handle" validate:"omitempty,max=255"`
+	VCSLogin    *string `json:"vcs_login" validate:"omitempty,max=255"`
+	GitLabID    *string `json:"gitlab_user_id" validate:"omitempty,eq=|number"`
+}
+
+// ListUsersRequest - фильтр и пагинация списка пользователей
+type ListUsersRequest struct {
+	TeamName string `query:"team_name"`
+	IsActive *bool  `query:"is_active"`
+	Limit    int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset   int    `query:"offset" validate:"omitempty,min=0"`
+}
+
+type ListUsersResponse struct {
+	Users  []UserResponse `json:"users"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// DeleteUserRequest - удаление пользователя. reassign передает его открытые ревью сокомандникам.
+type DeleteUserRequest struct {
+	UserID   string `query:"user_id" validate:"required"`
+	Reassign bool   `query:"reassign"`
+}
+
+type DeleteUserResponse struct {
+	UserID     string           `json:"user_id"`
+	HandedOver []ReviewHandover `json:"handed_over"`
+}
+
+// ReviewHistoryRequest - фильтр истории ревью. from и to - время назначения в RFC 3339.
+type ReviewHistoryRequest struct {
+	UserID string     `query:"user_id" validate:"required"`
+	Status string     `query:"status" validate:"omitempty,oneof=ACTIVE MERGED UNASSIGNED"`
+	From   *time.Time `query:"from"`
+	To     *time.Time `query:"to"`
+	Limit  int        `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int        `query:"offset" validate:"omitempty,min=0"`
+}
+
+type ReviewHistoryEntry struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          string     `json:"status"`
+	AssignedAt      time.Time  `json:"assigned_at"`
+	UnassignedAt    *time.Time `json:"unassigned_at,omitempty"`
+	Reason          string     `json:"reason,omitempty"`
+}
+
+type ReviewHistoryResponse struct {
+	UserID  string               `json:"user_id"`
+	Entries []ReviewHistoryEntry `json:"entries"`
+	Total   int                  `json:"total"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
+
+// DashboardRequest - персональная панель. merged_days - за сколько дней показывать смерженные PR.
+type DashboardRequest struct {
+	UserID     string `query:"user_id" validate:"required"`
+	MergedDays int    `query:"merged_days" validate:"omitempty,min=1,max=90"`
+}
+
+type DashboardPR struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Reviewers       []string   `json:"reviewers"`
+	Status          string     `json:"status"`
+	CreatedAt       time.Time  `json:"created_at"`
+	MergedAt        *time.Time `json:"merged_at,omitempty"`
+	// WaitSeconds - сколько PR ждет с создания, для смерженных - сколько ждал до мержа.
+	WaitSeconds int64 `json:"wait_seconds"`
+}
+
+type DashboardResponse struct {
+	UserID         string        `json:"user_id"`
+	Authored       []DashboardPR `json:"authored"`
+	AwaitingReview []DashboardPR `json:"awaiting_review"`
+	RecentlyMerged []DashboardPR `json:"recently_merged"`
+}
+
+// EraseUserRequest - удаление персональных данных. Reason и имя администратора из токена попадают в журнал.
+type EraseUserRequest struct {
+	UserID          string `json:"user_id" validate:"required"`
+	Reason          string `json:"reason" validate:"max=500"`
+	ReassignReviews bool   `json:"reassign_reviews"`
 }
 
-// ErrorResponse - структура для ошибок API
-type ErrorResponse struct {
-	Error ErrorDetail `json:"error" validate:"required"`
+type EraseUserResponse struct {
+	PseudonymID       string           `json:"pseudonym_id"`
+	AuthoredPRs       int              `json:"authored_prs"`
+	ReviewAssignments int              `json:"review_assignments"`
+	HandedOver        []ReviewHandover `json:"handed_over"`
 }
@@ -4,36 +4,55 @@ package users
 
 import (
 	"context"
-	"errors"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
 	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/users"
-)
-
-const (
-	userNotFound = "USER_NOT_FOUND"
+	"github.com/qwerty268/pull_request_service/internal/utils"
 )
 
 type UserGetter interface {
 	SetUserActive(ctx context.Context, userID string, isActive bool) (*ucDto.User, error)
 	GetUserReviewRequests(ctx context.Context, userID string) ([]ucDto.PullRequestShort, error)
+	MoveUserToTeam(ctx context.Context, opts ucDto.MoveTeamOpts) (*ucDto.MoveTeamResult, error)
+	GetUser(ctx context.Context, userID string) (*ucDto.User, error)
+	UpdateProfile(ctx context.Context, userID string, update ucDto.ProfileUpdate) (*ucDto.User, error)
+	GetReviewHistory(ctx context.Context, filter ucDto.ReviewHistoryFilter) (*ucDto.ReviewHistoryPage, error)
+	GetDashboard(ctx context.Context, userID string, mergedWindow time.Duration) (*ucDto.Dashboard, error)
+	ListUsers(ctx context.Context, filter ucDto.ListUsersFilter) (*ucDto.UsersPage, error)
+	DeleteUser(ctx context.Context, opts ucDto.DeleteUserOpts) (*ucDto.DeleteUserResult, error)
+	EraseUser(ctx context.Context, opts ucDto.EraseUserOpts) (*ucDto.EraseUserResult, error)
 }
 
 type UserHandlers struct {
-	userGetter UserGetter
+	userGetter  UserGetter
+	adminTokens AdminTokens
 }
 
-func NewUserHandlers(userGetter UserGetter) *UserHandlers {
+func NewUserHandlers(userGetter UserGetter, adminTokens AdminTokens) *UserHandlers {
 	return &UserHandlers{
-		userGetter: userGetter,
+		userGetter:  userGetter,
+		adminTokens: adminTokens,
 	}
 }
 
-func (h *UserHandlers) RegisterHandlers(e *echo.Echo) {
-	e.POST("/users/setIsActive", h.SetUserActive)
-	e.GET("/users/getReview", h.GetUserReviewRequests)
+func (h *UserHandlers) RegisterHandlers(r utils.Router) {
+	r.POST("/users/setIsActive", h.SetUserActive)
+	r.GET("/users/getReview", h.GetUserReviewRequests)
+	r.POST("/users/moveTeam", h.MoveUserToTeam)
+	r.GET("/users/get", h.GetUser)
+	r.GET("/users/list", h.ListUsers)
+	r.PATCH("/users/update", h.UpdateUser)
+	r.GET("/users/reviewHistory", h.GetReviewHistory)
+	r.GET("/users/dashboard", h.GetDashboard)
+
+	// Удаление пользователей доступно только администраторам, без токенов маршруты не монтируются.
+	if len(h.adminTokens) > 0 {
+		r.DELETE("/users/delete", h.DeleteUser, h.authenticateAdmin)
+		r.POST("/users/erase", h.EraseUser, h.authenticateAdmin)
+	}
 }
 
 // SetUserActive устанавливает флаг активности пользователя
@@ -46,31 +65,14 @@ func (h *UserHandlers) SetUserActive(c echo.Context) error {
 	}
 
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	user, err := h.userGetter.SetUserActive(ctx, req.UserID, *req.IsActive)
 	if err != nil {
-		if errors.Is(err, ucDto.ErrNotFound) {
-			return returnNotFound(
-				c,
-				ErrorDetail{
-					Code:    userNotFound,
-					Message: "user not found",
-				},
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	return c.JSON(
-		http.StatusOK,
-		SetUserActiveResponse{
-			UserID:   user.UserID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
-		},
-	)
+	return c.JSON(http.StatusOK, SetUserActiveResponse(*user))
 }
 
 // GetUserReviewRequests получает PR, где пользователь назначен ревьювером
@@ -83,21 +85,12 @@ func (h *UserHandlers) GetUserReviewRequests(c echo.Context) error {
 	}
 
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	prs, err := h.userGetter.GetUserReviewRequests(ctx, req.UserID)
 	if err != nil {
-		if errors.Is(err, ucDto.ErrNotFound) {
-			return returnNotFound(
-				c,
-				ErrorDetail{
-					Code:    userNotFound,
-					Message: "user not found",
-				},
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, GetUserReviewRequestsResponse{
@@ -106,6 +99,255 @@ func (h *UserHandlers) GetUserReviewRequests(c echo.Context) error {
 	})
 }
 
+// GetReviewHistory выдает историю назначений пользователя на ревью
+func (h *UserHandlers) GetReviewHistory(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(ReviewHistoryRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return echo.NewHTTPError(http.StatusBadRequest, "from must be before to")
+	}
+
+	if req.Limit == 0 {
+		req.Limit = ucDto.DefaultListLimit
+	}
+
+	page, err := h.userGetter.GetReviewHistory(ctx, ucDto.ReviewHistoryFilter(*req))
+	if err != nil {
+		return err
+	}
+
+	resp := ReviewHistoryResponse{
+		UserID:  req.UserID,
+		Entries: make([]ReviewHistoryEntry, len(page.Entries)),
+		Total:   page.Total,
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	}
+	for i, v := range page.Entries {
+		resp.Entries[i] = ReviewHistoryEntry(v)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetDashboard выдает персональную панель пользователя
+func (h *UserHandlers) GetDashboard(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(DashboardRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	dashboard, err := h.userGetter.GetDashboard(ctx, req.UserID, time.Duration(req.MergedDays)*24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, DashboardResponse{
+		UserID:         dashboard.UserID,
+		Authored:       dashboardPRsToResponse(dashboard.Authored),
+		AwaitingReview: dashboardPRsToResponse(dashboard.AwaitingReview),
+		RecentlyMerged: dashboardPRsToResponse(dashboard.RecentlyMerged),
+	})
+}
+
+// MoveUserToTeam переводит пользователя в другую команду
+func (h *UserHandlers) MoveUserToTeam(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(MoveTeamRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	result, err := h.userGetter.MoveUserToTeam(ctx, ucDto.MoveTeamOpts(*req))
+	if err != nil {
+		return err
+	}
+
+	resp := MoveTeamResponse{
+		User:        SetUserActiveResponse(result.User),
+		HandedOver:  make([]ReviewHandover, len(result.HandedOver)),
+		KeptReviews: result.KeptReviews,
+	}
+	for i, v := range result.HandedOver {
+		resp.HandedOver[i] = ReviewHandover(v)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetUser получает пользователя по id
+func (h *UserHandlers) GetUser(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(GetUserRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	user, err := h.userGetter.GetUser(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, UserResponse(*user))
+}
+
+// UpdateUser меняет профиль пользователя
+func (h *UserHandlers) UpdateUser(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(UpdateUserRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	user, err := h.userGetter.UpdateProfile(ctx, req.UserID, ucDto.ProfileUpdate{
+		Username:    req.Username,
+		Email:       req.Email,
+		DisplayName: req.DisplayName,
+		Timezone:    req.Timezone,
+		ChatHandle:  req.ChatHandle,
+		VCSLogin:    req.VCSLogin,
+		GitLabID:    req.GitLabID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, UserResponse(*user))
+}
+
+// ListUsers выдает страницу пользователей с фильтром по команде и активности
+func (h *UserHandlers) ListUsers(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(ListUsersRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if req.Limit == 0 {
+		req.Limit = ucDto.DefaultListLimit
+	}
+
+	page, err := h.userGetter.ListUsers(ctx, ucDto.ListUsersFilter(*req))
+	if err != nil {
+		return err
+	}
+
+	resp := ListUsersResponse{
+		Users:  make([]UserResponse, len(page.Users)),
+		Total:  page.Total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	for i, v := range page.Users {
+		resp.Users[i] = UserResponse(v)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteUser удаляет пользователя
+func (h *UserHandlers) DeleteUser(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(DeleteUserRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	result, err := h.userGetter.DeleteUser(ctx, ucDto.DeleteUserOpts{
+		UserID:          req.UserID,
+		ReassignReviews: req.Reassign,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp := DeleteUserResponse{
+		UserID:     result.UserID,
+		HandedOver: make([]ReviewHandover, len(result.HandedOver)),
+	}
+	for i, v := range result.HandedOver {
+		resp.HandedOver[i] = ReviewHandover(v)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// EraseUser удаляет персональные данные пользователя, сохраняя историю PR под псевдонимом
+func (h *UserHandlers) EraseUser(c echo.Context) error {
+	ctx := context.Background()
+
+	req := new(EraseUserRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	result, err := h.userGetter.EraseUser(ctx, ucDto.EraseUserOpts{
+		UserID:          req.UserID,
+		RequestedBy:     adminActor(c),
+		Reason:          req.Reason,
+		ReassignReviews: req.ReassignReviews,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp := EraseUserResponse{
+		PseudonymID:       result.PseudonymID,
+		AuthoredPRs:       result.AuthoredPRs,
+		ReviewAssignments: result.ReviewAssignments,
+		HandedOver:        make([]ReviewHandover, len(result.HandedOver)),
+	}
+	for i, v := range result.HandedOver {
+		resp.HandedOver[i] = ReviewHandover(v)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 func prsToPrsResponse(ucPrs []ucDto.PullRequestShort) []PullRequestShort {
 	prs := make([]PullRequestShort, len(ucPrs))
 
@@ -115,9 +357,20 @@ func prsToPrsResponse(ucPrs []ucDto.PullRequestShort) []PullRequestShort {
 	return prs
 }
 
-func returnNotFound(c echo.Context, err ErrorDetail) error {
-	return c.JSON(
-		http.StatusNotFound,
-		ErrorResponse{err},
-	)
+func dashboardPRsToResponse(ucPrs []ucDto.DashboardPR) []DashboardPR {
+	prs := make([]DashboardPR, len(ucPrs))
+
+	for i, v := range ucPrs {
+		prs[i] = DashboardPR{
+			PullRequestID:   v.PullRequestID,
+			PullRequestName: v.PullRequestName,
+			AuthorID:        v.AuthorID,
+			Reviewers:       v.Reviewers,
+			Status:          v.Status,
+			CreatedAt:       v.CreatedAt,
+			MergedAt:        v.MergedAt,
+			WaitSeconds:     int64(v.Wait / time.Second),
+		}
+	}
+	return prs
 }
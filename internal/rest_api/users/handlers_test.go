@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/qwerty268/pull_request_service/internal/rest_api/users/mocks"
@@ -27,6 +29,7 @@ func Test_SetUserActive(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &UserHandlers{
 			userGetter: userGetterMock,
@@ -43,12 +46,10 @@ func Test_SetUserActive(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		resp := "validate: Key: 'SetUserActiveRequest.UserID' Error:Field validation for 'UserID' failed on the 'required' tag"
-
 		err := h.SetUserActive(c)
-		assert.Error(t, err)
-		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
-		assert.Equal(t, resp, err.(*echo.HTTPError).Message)
+		var validationErr *utils.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []utils.FieldError{{Field: "user_id", Rule: "required", Message: "is required"}}, validationErr.Fields)
 	})
 
 	t.Run("success", func(t *testing.T) {
@@ -59,6 +60,7 @@ func Test_SetUserActive(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &UserHandlers{
 			userGetter: userGetterMock,
@@ -70,10 +72,14 @@ func Test_SetUserActive(t *testing.T) {
 		}
 
 		expectedUser := &ucDto.User{
-			UserID:   "u1",
-			Username: "Alice",
-			TeamName: "backend",
-			IsActive: false,
+			UserID:      "u1",
+			Username:    "Alice",
+			TeamName:    "backend",
+			IsActive:    false,
+			Email:       "alice@example.com",
+			DisplayName: "Alice Liddell",
+			Timezone:    "Europe/Berlin",
+			VCSLogin:    "alice",
 		}
 
 		userGetterMock.EXPECT().
@@ -107,6 +113,7 @@ func Test_SetUserActive(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &UserHandlers{
 			userGetter: userGetterMock,
@@ -129,18 +136,17 @@ func Test_SetUserActive(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		expectedResponse := ErrorResponse{
-			Error: ErrorDetail{
-				Code:    userNotFound,
+		expectedResponse := utils.ErrorResponse{
+			Error: utils.ErrorDetail{
+				Code:    utils.UserNotFound,
 				Message: "user not found",
 			},
 		}
 
-		err := h.SetUserActive(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.SetUserActive(c), c)
 
-		var response ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var response utils.ErrorResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 		assert.Equal(t, http.StatusNotFound, rec.Code)
@@ -154,6 +160,7 @@ func Test_SetUserActive(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &UserHandlers{
 			userGetter: userGetterMock,
@@ -177,7 +184,8 @@ func Test_SetUserActive(t *testing.T) {
 
 		err := h.SetUserActive(c)
 		assert.Error(t, err)
-		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
 }
 
@@ -190,6 +198,7 @@ func Test_GetUserReviewRequests(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &UserHandlers{
 			userGetter: userGetterMock,
@@ -200,12 +209,10 @@ func Test_GetUserReviewRequests(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		resp := "validate: Key: 'GetUserReviewRequestsRequest.UserID' Error:Field validation for 'UserID' failed on the 'required' tag"
-
 		err := h.GetUserReviewRequests(c)
-		assert.Error(t, err)
-		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
-		assert.Equal(t, resp, err.(*echo.HTTPError).Message)
+		var validationErr *utils.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []utils.FieldError{{Field: "user_id", Rule: "required", Message: "is required"}}, validationErr.Fields)
 	})
 
 	t.Run("success", func(t *testing.T) {
@@ -216,6 +223,7 @@ func Test_GetUserReviewRequests(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &UserHandlers{
 			userGetter: userGetterMock,
@@ -259,6 +267,7 @@ func Test_GetUserReviewRequests(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &UserHandlers{
 			userGetter: userGetterMock,
@@ -273,18 +282,17 @@ func Test_GetUserReviewRequests(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		expectedResponse := ErrorResponse{
-			Error: ErrorDetail{
-				Code:    userNotFound,
+		expectedResponse := utils.ErrorResponse{
+			Error: utils.ErrorDetail{
+				Code:    utils.UserNotFound,
 				Message: "user not found",
 			},
 		}
 
-		err := h.GetUserReviewRequests(c)
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(h.GetUserReviewRequests(c), c)
 
-		var response ErrorResponse
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var response utils.ErrorResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, response)
 		assert.Equal(t, http.StatusNotFound, rec.Code)
@@ -298,6 +306,7 @@ func Test_GetUserReviewRequests(t *testing.T) {
 
 		e := echo.New()
 		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
 
 		h := &UserHandlers{
 			userGetter: userGetterMock,
@@ -314,6 +323,813 @@ func Test_GetUserReviewRequests(t *testing.T) {
 
 		err := h.GetUserReviewRequests(c)
 		assert.Error(t, err)
-		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func Test_MoveUserToTeam(t *testing.T) {
+	t.Run("error_validate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		reqBody := []byte(`{"user_id": "u1"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.MoveUserToTeam(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			MoveUserToTeam(gomock.Any(), ucDto.MoveTeamOpts{UserID: "u1", TeamName: "frontend", HandoverReviews: true}).
+			Return(&ucDto.MoveTeamResult{
+				User:        ucDto.User{UserID: "u1", Username: "Alice", TeamName: "frontend", IsActive: true},
+				HandedOver:  []ucDto.ReviewHandover{{PullRequestID: "pr-1", NewReviewerID: "u2"}},
+				KeptReviews: []string{"pr-2"},
+			}, nil).
+			Times(1)
+
+		reqBody := []byte(`{"user_id": "u1", "team_name": "frontend", "handover_reviews": true}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.MoveUserToTeam(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response MoveTeamResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, MoveTeamResponse{
+			User:        SetUserActiveResponse{UserID: "u1", Username: "Alice", TeamName: "frontend", IsActive: true},
+			HandedOver:  []ReviewHandover{{PullRequestID: "pr-1", NewReviewerID: "u2"}},
+			KeptReviews: []string{"pr-2"},
+		}, response)
+	})
+
+	t.Run("team_not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			MoveUserToTeam(gomock.Any(), gomock.Any()).
+			Return(nil, ucDto.ErrTeamNotFound).
+			Times(1)
+
+		reqBody := []byte(`{"user_id": "u1", "team_name": "nope"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.MoveUserToTeam(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		var response utils.ErrorResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, utils.NotFound, response.Error.Code)
+	})
+}
+
+func Test_GetUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			GetUser(gomock.Any(), "u1").
+			Return(&ucDto.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/get?user_id=u1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response UserResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, UserResponse{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}, response)
+	})
+
+	t.Run("user_not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			GetUser(gomock.Any(), "u9").
+			Return(nil, ucDto.ErrNotFound).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/get?user_id=u9", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.GetUser(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func Test_ListUsers(t *testing.T) {
+	t.Run("error_validate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: mocks.NewMockUserGetter(ctrl),
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/users/list?limit=1000", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.ListUsers(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		isActive := false
+		userGetterMock.EXPECT().
+			ListUsers(gomock.Any(), ucDto.ListUsersFilter{TeamName: "backend", IsActive: &isActive, Limit: ucDto.DefaultListLimit, Offset: 5}).
+			Return(&ucDto.UsersPage{
+				Users: []ucDto.User{{UserID: "u2", Username: "Bob", TeamName: "backend"}},
+				Total: 6,
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/list?team_name=backend&is_active=false&offset=5", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.ListUsers(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response ListUsersResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, ListUsersResponse{
+			Users:  []UserResponse{{UserID: "u2", Username: "Bob", TeamName: "backend"}},
+			Total:  6,
+			Limit:  ucDto.DefaultListLimit,
+			Offset: 5,
+		}, response)
+	})
+}
+
+func Test_DeleteUser(t *testing.T) {
+	t.Run("success_with_reassign", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			DeleteUser(gomock.Any(), ucDto.DeleteUserOpts{UserID: "u1", ReassignReviews: true}).
+			Return(&ucDto.DeleteUserResult{
+				UserID:     "u1",
+				HandedOver: []ucDto.ReviewHandover{{PullRequestID: "pr-1", NewReviewerID: "u2"}},
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/users/delete?user_id=u1&reassign=true", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.DeleteUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response DeleteUserResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []ReviewHandover{{PullRequestID: "pr-1", NewReviewerID: "u2"}}, response.HandedOver)
+	})
+
+	for name, tc := range map[string]struct {
+		err  error
+		code string
+	}{
+		"has_open_prs":     {err: ucDto.ErrHasOpenPRs, code: utils.HasOpenPRs},
+		"has_open_reviews": {err: ucDto.ErrHasOpenReviews, code: utils.HasOpenReviews},
+		"has_history":      {err: ucDto.ErrHasHistory, code: utils.HasHistory},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+			e := echo.New()
+			e.Validator = utils.NewHTTPRequestValidator()
+			e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+			h := &UserHandlers{
+				userGetter: userGetterMock,
+			}
+
+			userGetterMock.EXPECT().
+				DeleteUser(gomock.Any(), ucDto.DeleteUserOpts{UserID: "u1"}).
+				Return(nil, tc.err).
+				Times(1)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/delete?user_id=u1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			e.HTTPErrorHandler(h.DeleteUser(c), c)
+			assert.Equal(t, http.StatusConflict, rec.Code)
+
+			var response utils.ErrorResponse
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.code, response.Error.Code)
+		})
+	}
+}
+
+func Test_UpdateUser(t *testing.T) {
+	for name, body := range map[string]string{
+		"invalid_email":    `{"user_id": "u1", "email": "not-an-email"}`,
+		"invalid_timezone": `{"user_id": "u1", "timezone": "Mars/Olympus"}`,
+		"missing_user_id":  `{"email": "alice@example.com"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := echo.New()
+			e.Validator = utils.NewHTTPRequestValidator()
+			e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+			h := &UserHandlers{
+				userGetter: mocks.NewMockUserGetter(ctrl),
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, "/users/update", bytes.NewReader([]byte(body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := h.UpdateUser(c)
+			assert.Error(t, err)
+			e.HTTPErrorHandler(err, c)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		email := "alice@example.com"
+		timezone := "Europe/Moscow"
+		empty := ""
+		userGetterMock.EXPECT().
+			UpdateProfile(gomock.Any(), "u1", ucDto.ProfileUpdate{Email: &email, Timezone: &timezone, ChatHandle: &empty}).
+			Return(&ucDto.User{
+				UserID:   "u1",
+				Username: "Alice",
+				IsActive: true,
+				Email:    email,
+				Timezone: timezone,
+			}, nil).
+			Times(1)
+
+		reqBody := []byte(`{"user_id": "u1", "email": "alice@example.com", "timezone": "Europe/Moscow", "chat_handle": ""}`)
+		req := httptest.NewRequest(http.MethodPatch, "/users/update", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.UpdateUser(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response UserResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, UserResponse{
+			UserID:   "u1",
+			Username: "Alice",
+			IsActive: true,
+			Email:    email,
+			Timezone: timezone,
+		}, response)
+	})
+
+	t.Run("user_not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			UpdateProfile(gomock.Any(), "u9", gomock.Any()).
+			Return(nil, ucDto.ErrNotFound).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPatch, "/users/update", bytes.NewReader([]byte(`{"user_id": "u9", "display_name": "Nobody"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.UpdateUser(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("invalid_gitlab_user_id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: mocks.NewMockUserGetter(ctrl),
+		}
+
+		req := httptest.NewRequest(http.MethodPatch, "/users/update", bytes.NewReader([]byte(`{"user_id": "u1", "gitlab_user_id": "bob"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.UpdateUser(c), c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var resp utils.ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, utils.ValidationFailed, resp.Error.Code)
+	})
+}
+
+func Test_GetReviewHistory(t *testing.T) {
+	t.Run("error_validate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: mocks.NewMockUserGetter(ctrl),
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/users/reviewHistory?user_id=u1&status=DONE", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetReviewHistory(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		assignedAt := from.Add(time.Hour)
+		userGetterMock.EXPECT().
+			GetReviewHistory(gomock.Any(), ucDto.ReviewHistoryFilter{
+				UserID: "u1",
+				Status: ucDto.HistoryStatusActive,
+				From:   &from,
+				Limit:  10,
+			}).
+			Return(&ucDto.ReviewHistoryPage{
+				Entries: []ucDto.ReviewHistoryEntry{{
+					PullRequestID:   "pr-1",
+					PullRequestName: "Add search",
+					AuthorID:        "u2",
+					Status:          ucDto.HistoryStatusActive,
+					AssignedAt:      assignedAt,
+				}},
+				Total: 1,
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/reviewHistory?user_id=u1&status=ACTIVE&from=2025-03-01T00:00:00Z&limit=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetReviewHistory(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response ReviewHistoryResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, ReviewHistoryResponse{
+			UserID: "u1",
+			Entries: []ReviewHistoryEntry{{
+				PullRequestID:   "pr-1",
+				PullRequestName: "Add search",
+				AuthorID:        "u2",
+				Status:          "ACTIVE",
+				AssignedAt:      assignedAt,
+			}},
+			Total: 1,
+			Limit: 10,
+		}, response)
+	})
+
+	t.Run("user_not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			GetReviewHistory(gomock.Any(), gomock.Any()).
+			Return(nil, ucDto.ErrNotFound).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/reviewHistory?user_id=u9", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.GetReviewHistory(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
 	})
 }
+
+func Test_GetDashboard(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		createdAt := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+		userGetterMock.EXPECT().
+			GetDashboard(gomock.Any(), "u1", 7*24*time.Hour).
+			Return(&ucDto.Dashboard{
+				UserID: "u1",
+				AwaitingReview: []ucDto.DashboardPR{{
+					PullRequestID: "pr-1",
+					AuthorID:      "u2",
+					Reviewers:     []string{"u1"},
+					Status:        "OPEN",
+					CreatedAt:     createdAt,
+					Wait:          90 * time.Minute,
+				}},
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/dashboard?user_id=u1&merged_days=7", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetDashboard(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response DashboardResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, DashboardResponse{
+			UserID:   "u1",
+			Authored: []DashboardPR{},
+			AwaitingReview: []DashboardPR{{
+				PullRequestID: "pr-1",
+				AuthorID:      "u2",
+				Reviewers:     []string{"u1"},
+				Status:        "OPEN",
+				CreatedAt:     createdAt,
+				WaitSeconds:   5400,
+			}},
+			RecentlyMerged: []DashboardPR{},
+		}, response)
+	})
+
+	t.Run("user_not_found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			GetDashboard(gomock.Any(), "u9", time.Duration(0)).
+			Return(nil, ucDto.ErrNotFound).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/dashboard?user_id=u9", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.GetDashboard(c), c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func Test_EraseUser(t *testing.T) {
+	t.Run("error_validate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: mocks.NewMockUserGetter(ctrl),
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/users/erase", bytes.NewReader([]byte(`{"reason": "gdpr"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.EraseUser(c)
+		assert.Error(t, err)
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter:  userGetterMock,
+			adminTokens: AdminTokens{"secret": "dpo"},
+		}
+		h.RegisterHandlers(e)
+
+		// requested_by берется из токена, поле в теле игнорируется.
+		userGetterMock.EXPECT().
+			EraseUser(gomock.Any(), ucDto.EraseUserOpts{UserID: "u1", RequestedBy: "dpo", Reason: "gdpr"}).
+			Return(&ucDto.EraseUserResult{PseudonymID: "erased-1", AuthoredPRs: 2, ReviewAssignments: 5}, nil).
+			Times(1)
+
+		reqBody := []byte(`{"user_id": "u1", "requested_by": "someone", "reason": "gdpr"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/erase", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response EraseUserResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, EraseUserResponse{
+			PseudonymID:       "erased-1",
+			AuthoredPRs:       2,
+			ReviewAssignments: 5,
+			HandedOver:        []ReviewHandover{},
+		}, response)
+	})
+
+	t.Run("has_open_reviews", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userGetterMock := mocks.NewMockUserGetter(ctrl)
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		h := &UserHandlers{
+			userGetter: userGetterMock,
+		}
+
+		userGetterMock.EXPECT().
+			EraseUser(gomock.Any(), gomock.Any()).
+			Return(nil, ucDto.ErrHasOpenReviews).
+			Times(1)
+
+		reqBody := []byte(`{"user_id": "u1"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/erase", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		e.HTTPErrorHandler(h.EraseUser(c), c)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func Test_AdminRoutes(t *testing.T) {
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodDelete, "/users/delete?user_id=u1"},
+		{http.MethodPost, "/users/erase"},
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		e.Validator = utils.NewHTTPRequestValidator()
+		e.HTTPErrorHandler = utils.HTTPErrorHandler
+
+		// Обработчики не вызываются: у мока нет ожиданий.
+		NewUserHandlers(mocks.NewMockUserGetter(ctrl), AdminTokens{"secret": "dpo"}).RegisterHandlers(e)
+
+		for _, route := range routes {
+			for _, auth := range []string{"", "Bearer wrong", "secret"} {
+				req := httptest.NewRequest(route.method, route.path, bytes.NewReader([]byte(`{"user_id": "u1"}`)))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				if auth != "" {
+					req.Header.Set(echo.HeaderAuthorization, auth)
+				}
+				rec := httptest.NewRecorder()
+
+				e.ServeHTTP(rec, req)
+				assert.Equal(t, http.StatusUnauthorized, rec.Code, "%s %s with %q", route.method, route.path, auth)
+			}
+		}
+	})
+
+	t.Run("not_mounted_without_tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := echo.New()
+		NewUserHandlers(mocks.NewMockUserGetter(ctrl), nil).RegisterHandlers(e)
+
+		for _, route := range routes {
+			req := httptest.NewRequest(route.method, route.path, nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusNotFound, rec.Code, "%s %s", route.method, route.path)
+		}
+	})
+}
+
+func Test_ParseAdminTokens(t *testing.T) {
+	tokens, err := ParseAdminTokens(" dpo:t1, ops:t2 ,")
+	require.NoError(t, err)
+	assert.Equal(t, AdminTokens{"t1": "dpo", "t2": "ops"}, tokens)
+
+	tokens, err = ParseAdminTokens("")
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+
+	for _, s := range []string{"dpo", "dpo:", ":t1", "dpo:t1,ops:t1"} {
+		_, err := ParseAdminTokens(s)
+		assert.Error(t, err, s)
+	}
+}
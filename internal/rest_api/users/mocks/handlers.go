@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	users "github.com/qwerty268/pull_request_service/internal/usecases/users"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,81 @@ func (m *MockUserGetter) EXPECT() *MockUserGetterMockRecorder {
 	return m.recorder
 }
 
+// DeleteUser mocks base method.
+func (m *MockUserGetter) DeleteUser(ctx context.Context, opts users.DeleteUserOpts) (*users.DeleteUserResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, opts)
+	ret0, _ := ret[0].(*users.DeleteUserResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserGetterMockRecorder) DeleteUser(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserGetter)(nil).DeleteUser), ctx, opts)
+}
+
+// EraseUser mocks base method.
+func (m *MockUserGetter) EraseUser(ctx context.Context, opts users.EraseUserOpts) (*users.EraseUserResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, opts)
+	ret0, _ := ret[0].(*users.EraseUserResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockUserGetterMockRecorder) EraseUser(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockUserGetter)(nil).EraseUser), ctx, opts)
+}
+
+// GetDashboard mocks base method.
+func (m *MockUserGetter) GetDashboard(ctx context.Context, userID string, mergedWindow time.Duration) (*users.Dashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDashboard", ctx, userID, mergedWindow)
+	ret0, _ := ret[0].(*users.Dashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDashboard indicates an expected call of GetDashboard.
+func (mr *MockUserGetterMockRecorder) GetDashboard(ctx, userID, mergedWindow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboard", reflect.TypeOf((*MockUserGetter)(nil).GetDashboard), ctx, userID, mergedWindow)
+}
+
+// GetReviewHistory mocks base method.
+func (m *MockUserGetter) GetReviewHistory(ctx context.Context, filter users.ReviewHistoryFilter) (*users.ReviewHistoryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewHistory", ctx, filter)
+	ret0, _ := ret[0].(*users.ReviewHistoryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewHistory indicates an expected call of GetReviewHistory.
+func (mr *MockUserGetterMockRecorder) GetReviewHistory(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewHistory", reflect.TypeOf((*MockUserGetter)(nil).GetReviewHistory), ctx, filter)
+}
+
+// GetUser mocks base method.
+func (m *MockUserGetter) GetUser(ctx context.Context, userID string) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserGetterMockRecorder) GetUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserGetter)(nil).GetUser), ctx, userID)
+}
+
 // GetUserReviewRequests mocks base method.
 func (m *MockUserGetter) GetUserReviewRequests(ctx context.Context, userID string) ([]users.PullRequestShort, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +132,36 @@ func (mr *MockUserGetterMockRecorder) GetUserReviewRequests(ctx, userID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviewRequests", reflect.TypeOf((*MockUserGetter)(nil).GetUserReviewRequests), ctx, userID)
 }
 
+// ListUsers mocks base method.
+func (m *MockUserGetter) ListUsers(ctx context.Context, filter users.ListUsersFilter) (*users.UsersPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter)
+	ret0, _ := ret[0].(*users.UsersPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserGetterMockRecorder) ListUsers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserGetter)(nil).ListUsers), ctx, filter)
+}
+
+// MoveUserToTeam mocks base method.
+func (m *MockUserGetter) MoveUserToTeam(ctx context.Context, opts users.MoveTeamOpts) (*users.MoveTeamResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveUserToTeam", ctx, opts)
+	ret0, _ := ret[0].(*users.MoveTeamResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveUserToTeam indicates an expected call of MoveUserToTeam.
+func (mr *MockUserGetterMockRecorder) MoveUserToTeam(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveUserToTeam", reflect.TypeOf((*MockUserGetter)(nil).MoveUserToTeam), ctx, opts)
+}
+
 // SetUserActive mocks base method.
 func (m *MockUserGetter) SetUserActive(ctx context.Context, userID string, isActive bool) (*users.User, error) {
 	m.ctrl.T.Helper()
@@ -70,3 +176,18 @@ func (mr *MockUserGetterMockRecorder) SetUserActive(ctx, userID, isActive any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockUserGetter)(nil).SetUserActive), ctx, userID, isActive)
 }
+
+// UpdateProfile mocks base method.
+func (m *MockUserGetter) UpdateProfile(ctx context.Context, userID string, update users.ProfileUpdate) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, userID, update)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserGetterMockRecorder) UpdateProfile(ctx, userID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserGetter)(nil).UpdateProfile), ctx, userID, update)
+}
@@ -0,0 +1,118 @@
+package versions
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/qwerty268/pull_request_service/internal/metrics"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+// Prefix - общий префикс версионированных маршрутов, дальше идет имя версии.
+const Prefix = "/api/"
+
+// Registrar - набор обработчиков, который можно смонтировать под любым префиксом.
+type Registrar interface {
+	RegisterHandlers(r utils.Router)
+}
+
+// Version - набор обработчиков одной версии API. Когда в v2 ломается контракт домена,
+// под него заводится новый пакет обработчиков, а неизменившиеся пакеты переиспользуются.
+type Version struct {
+	Name     string
+	Handlers []Registrar
+}
+
+// Alias - устаревшие синонимы версии без префикса.
+type Alias struct {
+	// DeprecatedAt уходит в заголовок Deprecation (RFC 9745).
+	DeprecatedAt time.Time
+	// Sunset - после этой даты синонимы удаляются, уходит в заголовок Sunset (RFC 8594).
+	Sunset time.Time
+}
+
+// Mount вешает обработчики версии под /api/<Name>.
+func (v Version) Mount(e *echo.Echo) {
+	r := router{
+		e:      e,
+		prefix: Prefix + v.Name,
+		mw:     []echo.MiddlewareFunc{countUsage(v.Name, false)},
+	}
+	for _, h := range v.Handlers {
+		h.RegisterHandlers(r)
+	}
+}
+
+// MountAliases вешает те же обработчики в корень. Ответы помечаются заголовками Deprecation и Sunset
+// и ссылкой на путь с версией.
+func (v Version) MountAliases(e *echo.Echo, alias Alias) {
+	r := router{
+		e: e,
+		mw: []echo.MiddlewareFunc{
+			countUsage(v.Name, true),
+			deprecate(Prefix+v.Name, alias),
+		},
+	}
+	for _, h := range v.Handlers {
+		h.RegisterHandlers(r)
+	}
+}
+
+func countUsage(version string, alias bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			metrics.APIRequest(version, alias)
+			return next(c)
+		}
+	}
+}
+
+func deprecate(successorPrefix string, alias Alias) echo.MiddlewareFunc {
+	deprecation := fmt.Sprintf("@%d", alias.DeprecatedAt.Unix())
+	sunset := alias.Sunset.UTC().Format(http.TimeFormat)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			h := c.Response().Header()
+			h.Set("Deprecation", deprecation)
+			h.Set("Sunset", sunset)
+			h.Add("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successorPrefix, c.Path()))
+			return next(c)
+		}
+	}
+}
+
+// router добавляет префикс версии и middleware к каждому маршруту. Группа echo не подходит:
+// для пустого префикса она перехватила бы 404 всего сервера.
+type router struct {
+	e      *echo.Echo
+	prefix string
+	mw     []echo.MiddlewareFunc
+}
+
+func (r router) with(m []echo.MiddlewareFunc) []echo.MiddlewareFunc {
+	return append(append([]echo.MiddlewareFunc{}, r.mw...), m...)
+}
+
+func (r router) GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.e.GET(r.prefix+path, h, r.with(m)...)
+}
+
+func (r router) POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.e.POST(r.prefix+path, h, r.with(m)...)
+}
+
+func (r router) PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.e.PUT(r.prefix+path, h, r.with(m)...)
+}
+
+func (r router) PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.e.PATCH(r.prefix+path, h, r.with(m)...)
+}
+
+func (r router) DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.e.DELETE(r.prefix+path, h, r.with(m)...)
+}
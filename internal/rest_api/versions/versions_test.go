@@ -0,0 +1,63 @@
+package versions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qwerty268/pull_request_service/internal/metrics"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+type pingHandlers struct{}
+
+func (pingHandlers) RegisterHandlers(r utils.Router) {
+	r.GET("/team/get", func(c echo.Context) error {
+		return c.String(http.StatusOK, "pong")
+	})
+}
+
+func TestVersion(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	e := echo.New()
+	v1 := Version{Name: "v1", Handlers: []Registrar{pingHandlers{}}}
+	v1.Mount(e)
+	v1.MountAliases(e, Alias{DeprecatedAt: deprecatedAt, Sunset: sunset})
+	metrics.RegisterHandlers(e)
+
+	t.Run("versioned path", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/team/get", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Deprecation"))
+		assert.Empty(t, rec.Header().Get("Sunset"))
+	})
+
+	t.Run("deprecated alias", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "@1792281600", rec.Header().Get("Deprecation"))
+		assert.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", rec.Header().Get("Sunset"))
+		assert.Equal(t, `</api/v1/team/get>; rel="successor-version"`, rec.Header().Get("Link"))
+	})
+
+	t.Run("unknown path is untouched", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/nope", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Empty(t, rec.Header().Get("Deprecation"))
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), `pr_service_api_version_requests_total{alias="false",version="v1"} 1`)
+	assert.Contains(t, rec.Body.String(), `pr_service_api_version_requests_total{alias="true",version="v1"} 1`)
+}
@@ -0,0 +1,40 @@
+package webhooks
+
+// WebhookResponse - итог обработки доставки, виден в журнале доставок внешней системы.
+type WebhookResponse struct {
+	DeliveryID    string `json:"delivery_id"`
+	Outcome       string `json:"outcome"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// githubPullRequestEvent - поля события pull_request GitHub, которые нужны сервису.
+type githubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title  string `json:"title"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// gitlabMergeRequestEvent - поля события Merge Request Hook GitLab, которые нужны сервису.
+type gitlabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	Project    struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID      int    `json:"iid"`
+		Title    string `json:"title"`
+		Action   string `json:"action"`
+		State    string `json:"state"`
+		AuthorID int64  `json:"author_id"`
+	} `json:"object_attributes"`
+}
@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/webhooks"
+)
+
+const (
+	githubSignatureHeader = "X-Hub-Signature-256"
+	githubEventHeader     = "X-GitHub-Event"
+	githubDeliveryHeader  = "X-GitHub-Delivery"
+)
+
+// GitHub принимает события pull_request: открытие, переоткрытие и закрытие с мержем.
+// PR заводится с id вида owner/repo#number.
+func (h *Handlers) GitHub(c echo.Context) error {
+	ctx := context.Background()
+
+	body, err := readPayload(c)
+	if err != nil {
+		return err
+	}
+	if !validGitHubSignature(h.config.GitHubSecret, body, c.Request().Header.Get(githubSignatureHeader)) {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid signature")
+	}
+
+	deliveryID := c.Request().Header.Get(githubDeliveryHeader)
+	if deliveryID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, githubDeliveryHeader+" is required")
+	}
+
+	switch event := c.Request().Header.Get(githubEventHeader); event {
+	case "pull_request":
+	case "ping":
+		// GitHub шлет ping при подключении вебхука, отвечаем, чтобы он отметил вебхук рабочим.
+		return c.JSON(http.StatusOK, ignoredResponse(deliveryID, "ping"))
+	default:
+		return c.JSON(http.StatusOK, ignoredResponse(deliveryID, "event %s is not handled", event))
+	}
+
+	var payload githubPullRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	result, err := h.usecase.HandlePullRequest(ctx, ucDto.PullRequestEvent{
+		Source:        ucDto.SourceGitHub,
+		DeliveryID:    deliveryID,
+		Action:        githubAction(payload),
+		PullRequestID: fmt.Sprintf("%s#%d", payload.Repository.FullName, payload.Number),
+		Title:         payload.PullRequest.Title,
+		Author:        payload.PullRequest.User.Login,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toResponse(deliveryID, result))
+}
+
+func githubAction(payload githubPullRequestEvent) string {
+	switch {
+	case payload.Action == "closed" && payload.PullRequest.Merged:
+		return ucDto.ActionMerged
+	case payload.Action == "closed":
+		return ucDto.ActionClosed
+	}
+	// opened и reopened называются так же, остальные действия usecase пропустит.
+	return payload.Action
+}
+
+// validGitHubSignature сверяет HMAC-SHA256 тела с секретом вебхука. Без секрета подпись не принимается:
+// HMAC с пустым ключом может посчитать кто угодно.
+func validGitHubSignature(secret string, body []byte, header string) bool {
+	if secret == "" {
+		return false
+	}
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
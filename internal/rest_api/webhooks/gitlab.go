@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/webhooks"
+)
+
+const (
+	gitlabTokenHeader = "X-Gitlab-Token"
+	gitlabEventHeader = "X-Gitlab-Event"
+	gitlabUUIDHeader  = "X-Gitlab-Event-UUID"
+
+	gitlabStateOpened = "opened"
+)
+
+// gitlabActions - действия Merge Request Hook в терминах usecase. Апрувы и прочее пропускаются.
+var gitlabActions = map[string]string{
+	"open":   ucDto.ActionOpened,
+	"reopen": ucDto.ActionReopened,
+	"merge":  ucDto.ActionMerged,
+	"close":  ucDto.ActionClosed,
+	"update": ucDto.ActionUpdated,
+}
+
+// GitLab принимает события Merge Request Hook. PR заводится с id вида group/project!iid,
+// автор ищется по gitlab_user_id.
+func (h *Handlers) GitLab(c echo.Context) error {
+	ctx := context.Background()
+
+	if !validGitLabToken(h.config.GitLabToken, c.Request().Header.Get(gitlabTokenHeader)) {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+	}
+
+	body, err := readPayload(c)
+	if err != nil {
+		return err
+	}
+
+	deliveryID := c.Request().Header.Get(gitlabUUIDHeader)
+	if deliveryID == "" {
+		// Старые версии GitLab заголовок не присылают. Повтор доставки шлет то же тело, по его хешу и отсекаем.
+		sum := sha256.Sum256(body)
+		deliveryID = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	if event := c.Request().Header.Get(gitlabEventHeader); event != "Merge Request Hook" {
+		return c.JSON(http.StatusOK, ignoredResponse(deliveryID, "event %s is not handled", event))
+	}
+
+	var payload gitlabMergeRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	// update приходит и на закрытые и смерженные MR, завести их как открытые нельзя.
+	if attrs := payload.ObjectAttributes; attrs.Action == "update" && attrs.State != gitlabStateOpened {
+		return c.JSON(http.StatusOK, ignoredResponse(deliveryID, "update of %s merge request is not mirrored", attrs.State))
+	}
+
+	action, ok := gitlabActions[payload.ObjectAttributes.Action]
+	if !ok {
+		action = payload.ObjectAttributes.Action
+	}
+
+	result, err := h.usecase.HandlePullRequest(ctx, ucDto.PullRequestEvent{
+		Source:        ucDto.SourceGitLab,
+		DeliveryID:    deliveryID,
+		Action:        action,
+		PullRequestID: fmt.Sprintf("%s!%d", payload.Project.PathWithNamespace, payload.ObjectAttributes.IID),
+		Title:         payload.ObjectAttributes.Title,
+		Author:        strconv.FormatInt(payload.ObjectAttributes.AuthorID, 10),
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toResponse(deliveryID, result))
+}
+
+// validGitLabToken сравнивает X-Gitlab-Token с секретом вебхука. Без секрета токен не принимается.
+func validGitLabToken(secret, token string) bool {
+	if secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
@@ -0,0 +1,73 @@
+//go:generate mockgen --source=handlers.go --destination=mocks/handlers.go -package=mocks
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/webhooks"
+)
+
+// maxPayloadSize - предел тела вебхука, GitHub и GitLab по умолчанию присылают не больше 25 МБ.
+const maxPayloadSize = 25 << 20
+
+type Usecase interface {
+	HandlePullRequest(ctx context.Context, ev ucDto.PullRequestEvent) (*ucDto.Result, error)
+}
+
+// Config - секреты внешних систем. Пока секрет не задан, вебхуки этой системы отклоняются.
+type Config struct {
+	GitHubSecret string
+	GitLabToken  string
+}
+
+type Handlers struct {
+	usecase Usecase
+	config  Config
+}
+
+func NewHandlers(usecase Usecase, config Config) *Handlers {
+	return &Handlers{
+		usecase: usecase,
+		config:  config,
+	}
+}
+
+// RegisterHandlers монтирует вебхуки вне версий API: их адрес прописан во внешних системах.
+func (h *Handlers) RegisterHandlers(e *echo.Echo) {
+	e.POST("/integrations/github/webhook", h.GitHub)
+	e.POST("/integrations/gitlab/webhook", h.GitLab)
+}
+
+func readPayload(c echo.Context) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxPayloadSize+1))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "failed to read payload")
+	}
+	if len(body) > maxPayloadSize {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, "payload is too large")
+	}
+	return body, nil
+}
+
+func ignoredResponse(deliveryID, format string, args ...any) WebhookResponse {
+	return WebhookResponse{
+		DeliveryID: deliveryID,
+		Outcome:    ucDto.OutcomeIgnored,
+		Reason:     fmt.Sprintf(format, args...),
+	}
+}
+
+func toResponse(deliveryID string, result *ucDto.Result) WebhookResponse {
+	return WebhookResponse{
+		DeliveryID:    deliveryID,
+		Outcome:       result.Outcome,
+		PullRequestID: result.PullRequestID,
+		Reason:        result.Reason,
+	}
+}
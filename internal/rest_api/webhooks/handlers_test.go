@@ -0,0 +1,454 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/events"
+	"github.com/qwerty268/pull_request_service/internal/rest_api/webhooks/mocks"
+	ucPR "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	prMocks "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/mocks"
+	prRepository "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/storage"
+	ucTeams "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	ucDto "github.com/qwerty268/pull_request_service/internal/usecases/webhooks"
+	ucMocks "github.com/qwerty268/pull_request_service/internal/usecases/webhooks/mocks"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+const (
+	testSecret = "It's a Secret to Everybody"
+	testToken  = "gitlab-token"
+)
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return body
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newGitHubRequest(event, deliveryID string, body []byte, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/integrations/github/webhook", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(githubEventHeader, event)
+	req.Header.Set(githubDeliveryHeader, deliveryID)
+	req.Header.Set(githubSignatureHeader, signature)
+	return req
+}
+
+func Test_GitHub(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		event   ucDto.PullRequestEvent
+		result  ucDto.Result
+	}{
+		{
+			name:    "opened",
+			fixture: "github/pull_request_opened.json",
+			event: ucDto.PullRequestEvent{
+				Source: ucDto.SourceGitHub, DeliveryID: "d-1", Action: ucDto.ActionOpened,
+				PullRequestID: "acme/api#7", Title: "Fix reviewer reassignment", Author: "alice",
+			},
+			result: ucDto.Result{Outcome: ucDto.OutcomeCreated, PullRequestID: "acme/api#7"},
+		},
+		{
+			name:    "reopened",
+			fixture: "github/pull_request_reopened.json",
+			event: ucDto.PullRequestEvent{
+				Source: ucDto.SourceGitHub, DeliveryID: "d-1", Action: ucDto.ActionReopened,
+				PullRequestID: "acme/api#7", Title: "Fix reviewer reassignment", Author: "alice",
+			},
+			result: ucDto.Result{Outcome: ucDto.OutcomeIgnored, PullRequestID: "acme/api#7", Reason: "pull request already exists"},
+		},
+		{
+			name:    "closed_merged",
+			fixture: "github/pull_request_closed_merged.json",
+			event: ucDto.PullRequestEvent{
+				Source: ucDto.SourceGitHub, DeliveryID: "d-1", Action: ucDto.ActionMerged,
+				PullRequestID: "acme/api#7", Title: "Fix reviewer reassignment", Author: "alice",
+			},
+			result: ucDto.Result{Outcome: ucDto.OutcomeMerged, PullRequestID: "acme/api#7"},
+		},
+		{
+			name:    "closed",
+			fixture: "github/pull_request_closed.json",
+			event: ucDto.PullRequestEvent{
+				Source: ucDto.SourceGitHub, DeliveryID: "d-1", Action: ucDto.ActionClosed,
+				PullRequestID: "acme/api#7", Title: "Fix reviewer reassignment", Author: "alice",
+			},
+			result: ucDto.Result{Outcome: ucDto.OutcomeIgnored, PullRequestID: "acme/api#7", Reason: "action closed is not mirrored"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecaseMock := mocks.NewMockUsecase(ctrl)
+			h := NewHandlers(usecaseMock, Config{GitHubSecret: testSecret})
+
+			result := tt.result
+			usecaseMock.EXPECT().HandlePullRequest(gomock.Any(), tt.event).Return(&result, nil)
+
+			body := fixture(t, tt.fixture)
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(newGitHubRequest("pull_request", "d-1", body, sign(testSecret, body)), rec)
+
+			err := h.GitHub(c)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var resp WebhookResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, WebhookResponse{
+				DeliveryID:    "d-1",
+				Outcome:       tt.result.Outcome,
+				PullRequestID: tt.result.PullRequestID,
+				Reason:        tt.result.Reason,
+			}, resp)
+		})
+	}
+}
+
+func Test_GitHub_Rejected(t *testing.T) {
+	body := fixture(t, "github/pull_request_opened.json")
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		delivery  string
+		status    int
+		code      string
+	}{
+		{name: "wrong_secret", secret: testSecret, signature: sign("other", body), delivery: "d-1", status: http.StatusUnauthorized, code: utils.Unauthorized},
+		{name: "missing_signature", secret: testSecret, delivery: "d-1", status: http.StatusUnauthorized, code: utils.Unauthorized},
+		{name: "malformed_signature", secret: testSecret, signature: "sha1=abc", delivery: "d-1", status: http.StatusUnauthorized, code: utils.Unauthorized},
+		{name: "secret_not_configured", signature: sign("", body), delivery: "d-1", status: http.StatusUnauthorized, code: utils.Unauthorized},
+		{name: "missing_delivery", secret: testSecret, signature: sign(testSecret, body), status: http.StatusBadRequest, code: utils.BadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewHandlers(mocks.NewMockUsecase(ctrl), Config{GitHubSecret: tt.secret})
+
+			e := echo.New()
+			e.HTTPErrorHandler = utils.HTTPErrorHandler
+			rec := httptest.NewRecorder()
+			c := e.NewContext(newGitHubRequest("pull_request", tt.delivery, body, tt.signature), rec)
+
+			e.HTTPErrorHandler(h.GitHub(c), c)
+			assert.Equal(t, tt.status, rec.Code)
+
+			var resp utils.ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.code, resp.Error.Code)
+		})
+	}
+}
+
+func Test_GitHub_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewHandlers(mocks.NewMockUsecase(ctrl), Config{GitHubSecret: testSecret})
+
+	body := fixture(t, "github/ping.json")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newGitHubRequest("ping", "d-2", body, sign(testSecret, body)), rec)
+
+	err := h.GitHub(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp WebhookResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, WebhookResponse{DeliveryID: "d-2", Outcome: ucDto.OutcomeIgnored, Reason: "ping"}, resp)
+}
+
+func Test_GitHub_UsecaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecaseMock := mocks.NewMockUsecase(ctrl)
+	h := NewHandlers(usecaseMock, Config{GitHubSecret: testSecret})
+
+	usecaseMock.EXPECT().HandlePullRequest(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+	body := fixture(t, "github/pull_request_closed_merged.json")
+	e := echo.New()
+	e.HTTPErrorHandler = utils.HTTPErrorHandler
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newGitHubRequest("pull_request", "d-1", body, sign(testSecret, body)), rec)
+
+	// 5xx заставляет GitHub повторить доставку, usecase к этому моменту уже снял отметку о ней.
+	e.HTTPErrorHandler(h.GitHub(c), c)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func newGitLabRequest(event, uuid string, body []byte, token string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/integrations/gitlab/webhook", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(gitlabEventHeader, event)
+	req.Header.Set(gitlabUUIDHeader, uuid)
+	req.Header.Set(gitlabTokenHeader, token)
+	return req
+}
+
+func Test_GitLab(t *testing.T) {
+	event := func(action, title string) ucDto.PullRequestEvent {
+		return ucDto.PullRequestEvent{
+			Source: ucDto.SourceGitLab, DeliveryID: "g-1", Action: action,
+			PullRequestID: "acme/web!12", Title: title, Author: "4821",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		fixture string
+		event   ucDto.PullRequestEvent
+		result  ucDto.Result
+	}{
+		{
+			name:    "open",
+			fixture: "gitlab/merge_request_open.json",
+			event:   event(ucDto.ActionOpened, "Add dark theme"),
+			result:  ucDto.Result{Outcome: ucDto.OutcomeCreated, PullRequestID: "acme/web!12"},
+		},
+		{
+			name:    "reopen",
+			fixture: "gitlab/merge_request_reopen.json",
+			event:   event(ucDto.ActionReopened, "Add dark theme"),
+			result:  ucDto.Result{Outcome: ucDto.OutcomeIgnored, PullRequestID: "acme/web!12", Reason: "pull request already exists"},
+		},
+		{
+			name:    "update",
+			fixture: "gitlab/merge_request_update.json",
+			event:   event(ucDto.ActionUpdated, "Add dark theme toggle"),
+			result:  ucDto.Result{Outcome: ucDto.OutcomeCreated, PullRequestID: "acme/web!12"},
+		},
+		{
+			name:    "merge",
+			fixture: "gitlab/merge_request_merge.json",
+			event:   event(ucDto.ActionMerged, "Add dark theme"),
+			result:  ucDto.Result{Outcome: ucDto.OutcomeMerged, PullRequestID: "acme/web!12"},
+		},
+		{
+			name:    "close",
+			fixture: "gitlab/merge_request_close.json",
+			event:   event(ucDto.ActionClosed, "Add dark theme"),
+			result:  ucDto.Result{Outcome: ucDto.OutcomeIgnored, PullRequestID: "acme/web!12", Reason: "action closed is not mirrored"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecaseMock := mocks.NewMockUsecase(ctrl)
+			h := NewHandlers(usecaseMock, Config{GitLabToken: testToken})
+
+			result := tt.result
+			usecaseMock.EXPECT().HandlePullRequest(gomock.Any(), tt.event).Return(&result, nil)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(newGitLabRequest("Merge Request Hook", "g-1", fixture(t, tt.fixture), testToken), rec)
+
+			err := h.GitLab(c)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var resp WebhookResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, WebhookResponse{
+				DeliveryID:    "g-1",
+				Outcome:       tt.result.Outcome,
+				PullRequestID: tt.result.PullRequestID,
+				Reason:        tt.result.Reason,
+			}, resp)
+		})
+	}
+}
+
+func Test_GitLab_Rejected(t *testing.T) {
+	body := fixture(t, "gitlab/merge_request_open.json")
+
+	tests := []struct {
+		name   string
+		secret string
+		token  string
+		uuid   string
+		status int
+		code   string
+	}{
+		{name: "wrong_token", secret: testToken, token: "other", uuid: "g-1", status: http.StatusUnauthorized, code: utils.Unauthorized},
+		{name: "missing_token", secret: testToken, uuid: "g-1", status: http.StatusUnauthorized, code: utils.Unauthorized},
+		{name: "token_not_configured", uuid: "g-1", status: http.StatusUnauthorized, code: utils.Unauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewHandlers(mocks.NewMockUsecase(ctrl), Config{GitLabToken: tt.secret})
+
+			e := echo.New()
+			e.HTTPErrorHandler = utils.HTTPErrorHandler
+			rec := httptest.NewRecorder()
+			c := e.NewContext(newGitLabRequest("Merge Request Hook", tt.uuid, body, tt.token), rec)
+
+			e.HTTPErrorHandler(h.GitLab(c), c)
+			assert.Equal(t, tt.status, rec.Code)
+
+			var resp utils.ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.code, resp.Error.Code)
+		})
+	}
+}
+
+func Test_GitLab_MissingUUID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecaseMock := mocks.NewMockUsecase(ctrl)
+	h := NewHandlers(usecaseMock, Config{GitLabToken: testToken})
+
+	body := fixture(t, "gitlab/merge_request_open.json")
+	sum := sha256.Sum256(body)
+	deliveryID := "sha256:" + hex.EncodeToString(sum[:])
+
+	// Повтор с тем же телом получает тот же id доставки.
+	usecaseMock.EXPECT().
+		HandlePullRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ev ucDto.PullRequestEvent) (*ucDto.Result, error) {
+			assert.Equal(t, deliveryID, ev.DeliveryID)
+			return &ucDto.Result{Outcome: ucDto.OutcomeCreated, PullRequestID: ev.PullRequestID}, nil
+		}).
+		Times(2)
+
+	e := echo.New()
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newGitLabRequest("Merge Request Hook", "", body, testToken), rec)
+
+		assert.NoError(t, h.GitLab(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp WebhookResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, deliveryID, resp.DeliveryID)
+	}
+}
+
+// Test_GitLab_MergeStrictTeam прогоняет мерж через настоящие usecase: команда требует больше ревьюеров,
+// чем есть у MR, но мерж в GitLab уже случился и должен отразиться у нас.
+func Test_GitLab_MergeStrictTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookStorage := ucMocks.NewMockstorage(ctrl)
+	prStorage := prMocks.NewMockprStorage(ctrl)
+	settings := prMocks.NewMocksettingsProvider(ctrl)
+	prUsecase := ucPR.NewUsecase(prStorage, nil, nil, settings, events.Discard)
+	h := NewHandlers(ucDto.NewUsecase(webhookStorage, prUsecase), Config{GitLabToken: testToken})
+
+	strict := ucTeams.DefaultSettings
+	strict.TeamName = "web"
+	strict.MinReviewers = 3
+
+	webhookStorage.EXPECT().ClaimDelivery(ucDto.SourceGitLab, "g-1").Return(true, nil)
+	prStorage.EXPECT().
+		SetPrMerged("acme/web!12", 0).
+		Return(&prRepository.PullRequest{
+			PullRequestID:     "acme/web!12",
+			AuthorID:          "u1",
+			AssignedReviewers: []string{"u2"},
+			IsMerged:          true,
+		}, true, nil)
+	settings.EXPECT().GetUserTeamSettings(gomock.Any(), "u1").Return(&strict, nil).AnyTimes()
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newGitLabRequest("Merge Request Hook", "g-1", fixture(t, "gitlab/merge_request_merge.json"), testToken), rec)
+
+	assert.NoError(t, h.GitLab(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp WebhookResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, WebhookResponse{DeliveryID: "g-1", Outcome: ucDto.OutcomeMerged, PullRequestID: "acme/web!12"}, resp)
+}
+
+func Test_GitLab_OtherEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewHandlers(mocks.NewMockUsecase(ctrl), Config{GitLabToken: testToken})
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newGitLabRequest("Note Hook", "g-2", fixture(t, "gitlab/note.json"), testToken), rec)
+
+	err := h.GitLab(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp WebhookResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, WebhookResponse{DeliveryID: "g-2", Outcome: ucDto.OutcomeIgnored, Reason: "event Note Hook is not handled"}, resp)
+}
+
+// Test_GitLab_UpdateClosed: правка закрытого MR не должна завести его у нас как открытый.
+func Test_GitLab_UpdateClosed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewHandlers(mocks.NewMockUsecase(ctrl), Config{GitLabToken: testToken})
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newGitLabRequest("Merge Request Hook", "g-3", fixture(t, "gitlab/merge_request_update_closed.json"), testToken), rec)
+
+	err := h.GitLab(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp WebhookResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, WebhookResponse{
+		DeliveryID: "g-3",
+		Outcome:    ucDto.OutcomeIgnored,
+		Reason:     "update of closed merge request is not mirrored",
+	}, resp)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handlers.go
+//
+// Generated by this command:
+//
+//	mockgen --source=handlers.go --destination=mocks/handlers.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	webhooks "github.com/qwerty268/pull_request_service/internal/usecases/webhooks"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// HandlePullRequest mocks base method.
+func (m *MockUsecase) HandlePullRequest(ctx context.Context, ev webhooks.PullRequestEvent) (*webhooks.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlePullRequest", ctx, ev)
+	ret0, _ := ret[0].(*webhooks.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandlePullRequest indicates an expected call of HandlePullRequest.
+func (mr *MockUsecaseMockRecorder) HandlePullRequest(ctx, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePullRequest", reflect.TypeOf((*MockUsecase)(nil).HandlePullRequest), ctx, ev)
+}
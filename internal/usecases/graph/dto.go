@@ -0,0 +1,30 @@
+package graph
+
+import "time"
+
+type User struct {
+	UserID   string
+	Username string
+	TeamName string
+	IsActive bool
+}
+
+type TeamMember struct {
+	UserID string
+	Role   string
+}
+
+type Team struct {
+	TeamName string
+	Members  []TeamMember
+}
+
+type PullRequest struct {
+	PullRequestID     string
+	PullRequestName   string
+	AuthorID          string
+	Status            string
+	AssignedReviewers []string
+	CreatedAt         time.Time
+	MergedAt          time.Time
+}
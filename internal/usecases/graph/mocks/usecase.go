@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+//
+// Generated by this command:
+//
+//	mockgen --source=usecase.go --destination=mocks/usecase.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	storage "github.com/qwerty268/pull_request_service/internal/usecases/graph/storage"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+	isgomock struct{}
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// GetAuthoredPRIDs mocks base method.
+func (m *Mockstorage) GetAuthoredPRIDs(userIDs []string) ([]storage.UserPR, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthoredPRIDs", userIDs)
+	ret0, _ := ret[0].([]storage.UserPR)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthoredPRIDs indicates an expected call of GetAuthoredPRIDs.
+func (mr *MockstorageMockRecorder) GetAuthoredPRIDs(userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthoredPRIDs", reflect.TypeOf((*Mockstorage)(nil).GetAuthoredPRIDs), userIDs)
+}
+
+// GetExistingTeams mocks base method.
+func (m *Mockstorage) GetExistingTeams(teamNames []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExistingTeams", teamNames)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExistingTeams indicates an expected call of GetExistingTeams.
+func (mr *MockstorageMockRecorder) GetExistingTeams(teamNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExistingTeams", reflect.TypeOf((*Mockstorage)(nil).GetExistingTeams), teamNames)
+}
+
+// GetPullRequestsByIDs mocks base method.
+func (m *Mockstorage) GetPullRequestsByIDs(prIDs []string) ([]storage.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestsByIDs", prIDs)
+	ret0, _ := ret[0].([]storage.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestsByIDs indicates an expected call of GetPullRequestsByIDs.
+func (mr *MockstorageMockRecorder) GetPullRequestsByIDs(prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestsByIDs", reflect.TypeOf((*Mockstorage)(nil).GetPullRequestsByIDs), prIDs)
+}
+
+// GetReviewPRIDs mocks base method.
+func (m *Mockstorage) GetReviewPRIDs(userIDs []string) ([]storage.UserPR, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewPRIDs", userIDs)
+	ret0, _ := ret[0].([]storage.UserPR)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewPRIDs indicates an expected call of GetReviewPRIDs.
+func (mr *MockstorageMockRecorder) GetReviewPRIDs(userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewPRIDs", reflect.TypeOf((*Mockstorage)(nil).GetReviewPRIDs), userIDs)
+}
+
+// GetTeamMembers mocks base method.
+func (m *Mockstorage) GetTeamMembers(teamNames []string) ([]storage.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMembers", teamNames)
+	ret0, _ := ret[0].([]storage.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMembers indicates an expected call of GetTeamMembers.
+func (mr *MockstorageMockRecorder) GetTeamMembers(teamNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMembers", reflect.TypeOf((*Mockstorage)(nil).GetTeamMembers), teamNames)
+}
+
+// GetTeamNames mocks base method.
+func (m *Mockstorage) GetTeamNames() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamNames")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamNames indicates an expected call of GetTeamNames.
+func (mr *MockstorageMockRecorder) GetTeamNames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamNames", reflect.TypeOf((*Mockstorage)(nil).GetTeamNames))
+}
+
+// GetUsersByIDs mocks base method.
+func (m *Mockstorage) GetUsersByIDs(userIDs []string) ([]storage.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIDs", userIDs)
+	ret0, _ := ret[0].([]storage.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockstorageMockRecorder) GetUsersByIDs(userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*Mockstorage)(nil).GetUsersByIDs), userIDs)
+}
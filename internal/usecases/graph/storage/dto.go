@@ -0,0 +1,33 @@
+package storage
+
+import "time"
+
+type User struct {
+	UserID   string `db:"user_id"`
+	Username string `db:"username"`
+	TeamName string `db:"team_name"`
+	IsActive bool   `db:"is_active"`
+}
+
+// TeamMember - строка team_user_map.
+type TeamMember struct {
+	TeamName string `db:"team_name"`
+	UserID   string `db:"user_id"`
+	Role     string `db:"role"`
+}
+
+type PullRequest struct {
+	PullRequestID     string
+	PullRequestName   string
+	AuthorID          string
+	IsMerged          bool
+	AssignedReviewers []string
+	CreatedAt         time.Time
+	MergedAt          time.Time
+}
+
+// UserPR - связь пользователя с PR: автор или ревьюер в зависимости от запроса.
+type UserPR struct {
+	UserID        string `db:"user_id"`
+	PullRequestID string `db:"pull_request_id"`
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/qwerty268/pull_request_service/internal/metrics"
+)
+
+// Storage - пакетные выборки для GraphQL. Каждый метод забирает данные сразу по списку ключей,
+// чтобы вложенные запросы не превращались в N+1.
+type Storage struct {
+	db    *sqlx.DB
+	close func() error
+}
+
+func NewStorage(db *sqlx.DB) *Storage {
+	return &Storage{
+		db: db,
+		close: func() error {
+			return fmt.Errorf("close: %v", db.Close())
+		},
+	}
+}
+
+func (s *Storage) GetUsersByIDs(userIDs []string) ([]User, error) {
+	defer metrics.ObserveQuery("graph", "GetUsersByIDs", time.Now())
+
+	query := `
+		SELECT user_id, username, COALESCE(team_name, '') AS team_name, COALESCE(is_active, false) AS is_active
+		FROM "user"
+		WHERE user_id = ANY($1)
+	`
+	var users []User
+	if err := s.db.Select(&users, query, pq.Array(userIDs)); err != nil {
+		return nil, fmt.Errorf("select users: %v", err)
+	}
+	return users, nil
+}
+
+// GetTeamNames выдает имена всех команд по алфавиту.
+func (s *Storage) GetTeamNames() ([]string, error) {
+	defer metrics.ObserveQuery("graph", "GetTeamNames", time.Now())
+
+	var names []string
+	if err := s.db.Select(&names, `SELECT team_name FROM team ORDER BY team_name`); err != nil {
+		return nil, fmt.Errorf("select teams: %v", err)
+	}
+	return names, nil
+}
+
+// GetExistingTeams выдает те из переданных команд, что есть в базе.
+func (s *Storage) GetExistingTeams(teamNames []string) ([]string, error) {
+	defer metrics.ObserveQuery("graph", "GetExistingTeams", time.Now())
+
+	var names []string
+	err := s.db.Select(&names, `SELECT team_name FROM team WHERE team_name = ANY($1)`, pq.Array(teamNames))
+	if err != nil {
+		return nil, fmt.Errorf("select teams: %v", err)
+	}
+	return names, nil
+}
+
+func (s *Storage) GetTeamMembers(teamNames []string) ([]TeamMember, error) {
+	defer metrics.ObserveQuery("graph", "GetTeamMembers", time.Now())
+
+	query := `
+		SELECT tum.team_name, tum.user_id, tum.role
+		FROM team_user_map AS tum
+		JOIN "user" AS u ON u.user_id = tum.user_id
+		WHERE tum.team_name = ANY($1)
+		ORDER BY tum.team_name, u.username
+	`
+	var members []TeamMember
+	if err := s.db.Select(&members, query, pq.Array(teamNames)); err != nil {
+		return nil, fmt.Errorf("select members: %v", err)
+	}
+	return members, nil
+}
+
+func (s *Storage) GetPullRequestsByIDs(prIDs []string) ([]PullRequest, error) {
+	defer metrics.ObserveQuery("graph", "GetPullRequestsByIDs", time.Now())
+
+	query := `
+		SELECT
+			pull_request_id,
+			pull_request_name,
+			author_id,
+			is_merged,
+			assigned_reviewers,
+			created_at,
+			merged_at
+		FROM pull_request
+		WHERE pull_request_id = ANY($1)
+	`
+	rows, err := s.db.Query(query, pq.Array(prIDs))
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+	defer rows.Close()
+
+	var prs []PullRequest
+	for rows.Next() {
+		var pr PullRequest
+		err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&pr.IsMerged,
+			pq.Array(&pr.AssignedReviewers),
+			&pr.CreatedAt,
+			&pr.MergedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		prs = append(prs, pr)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return prs, nil
+}
+
+// GetReviewPRIDs выдает PR, на которые назначены пользователи, от новых к старым.
+func (s *Storage) GetReviewPRIDs(userIDs []string) ([]UserPR, error) {
+	defer metrics.ObserveQuery("graph", "GetReviewPRIDs", time.Now())
+
+	query := `
+		SELECT prm.user_id, prm.pull_request_id
+		FROM pr_reviewers_map AS prm
+		JOIN pull_request AS pr ON pr.pull_request_id = prm.pull_request_id
+		WHERE prm.user_id = ANY($1)
+		ORDER BY pr.created_at DESC
+	`
+	var links []UserPR
+	if err := s.db.Select(&links, query, pq.Array(userIDs)); err != nil {
+		return nil, fmt.Errorf("select reviews: %v", err)
+	}
+	return links, nil
+}
+
+// GetAuthoredPRIDs выдает PR, созданные пользователями, от новых к старым.
+func (s *Storage) GetAuthoredPRIDs(userIDs []string) ([]UserPR, error) {
+	defer metrics.ObserveQuery("graph", "GetAuthoredPRIDs", time.Now())
+
+	query := `
+		SELECT author_id AS user_id, pull_request_id
+		FROM pull_request
+		WHERE author_id = ANY($1)
+		ORDER BY created_at DESC
+	`
+	var links []UserPR
+	if err := s.db.Select(&links, query, pq.Array(userIDs)); err != nil {
+		return nil, fmt.Errorf("select authored: %v", err)
+	}
+	return links, nil
+}
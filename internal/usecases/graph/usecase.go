@@ -0,0 +1,135 @@
+//go:generate mockgen --source=usecase.go --destination=mocks/usecase.go -package=mocks
+
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	repository "github.com/qwerty268/pull_request_service/internal/usecases/graph/storage"
+)
+
+const (
+	statusOpen   = "OPEN"
+	statusMerged = "MERGED"
+)
+
+type storage interface {
+	GetUsersByIDs(userIDs []string) ([]repository.User, error)
+	GetTeamNames() ([]string, error)
+	GetExistingTeams(teamNames []string) ([]string, error)
+	GetTeamMembers(teamNames []string) ([]repository.TeamMember, error)
+	GetPullRequestsByIDs(prIDs []string) ([]repository.PullRequest, error)
+	GetReviewPRIDs(userIDs []string) ([]repository.UserPR, error)
+	GetAuthoredPRIDs(userIDs []string) ([]repository.UserPR, error)
+}
+
+// Usecase - чтение для GraphQL. Методы принимают пачку ключей и отдают мапу по ключу,
+// отсутствующих в базе ключей в мапе нет.
+type Usecase struct {
+	storage storage
+}
+
+func NewUsecase(storage storage) Usecase {
+	return Usecase{
+		storage: storage,
+	}
+}
+
+func (u Usecase) GetUsers(_ context.Context, userIDs []string) (map[string]User, error) {
+	storageUsers, err := u.storage.GetUsersByIDs(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %v", err)
+	}
+
+	users := make(map[string]User, len(storageUsers))
+	for _, v := range storageUsers {
+		users[v.UserID] = User(v)
+	}
+	return users, nil
+}
+
+func (u Usecase) ListTeamNames(_ context.Context) ([]string, error) {
+	names, err := u.storage.GetTeamNames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %v", err)
+	}
+	return names, nil
+}
+
+func (u Usecase) GetTeams(_ context.Context, teamNames []string) (map[string]Team, error) {
+	existing, err := u.storage.GetExistingTeams(teamNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %v", err)
+	}
+	if len(existing) == 0 {
+		return map[string]Team{}, nil
+	}
+
+	members, err := u.storage.GetTeamMembers(existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %v", err)
+	}
+
+	teams := make(map[string]Team, len(existing))
+	for _, name := range existing {
+		teams[name] = Team{TeamName: name, Members: []TeamMember{}}
+	}
+	for _, m := range members {
+		team := teams[m.TeamName]
+		team.Members = append(team.Members, TeamMember{UserID: m.UserID, Role: m.Role})
+		teams[m.TeamName] = team
+	}
+	return teams, nil
+}
+
+func (u Usecase) GetPullRequests(_ context.Context, prIDs []string) (map[string]PullRequest, error) {
+	storagePrs, err := u.storage.GetPullRequestsByIDs(prIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull requests: %v", err)
+	}
+
+	prs := make(map[string]PullRequest, len(storagePrs))
+	for _, v := range storagePrs {
+		pr := PullRequest{
+			PullRequestID:     v.PullRequestID,
+			PullRequestName:   v.PullRequestName,
+			AuthorID:          v.AuthorID,
+			Status:            statusOpen,
+			AssignedReviewers: v.AssignedReviewers,
+			CreatedAt:         v.CreatedAt,
+			MergedAt:          v.MergedAt,
+		}
+		if v.IsMerged {
+			pr.Status = statusMerged
+		}
+		prs[v.PullRequestID] = pr
+	}
+	return prs, nil
+}
+
+// GetReviewPRIDs выдает id PR, на которые назначен каждый из пользователей.
+func (u Usecase) GetReviewPRIDs(_ context.Context, userIDs []string) (map[string][]string, error) {
+	links, err := u.storage.GetReviewPRIDs(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %v", err)
+	}
+	return groupByUser(links), nil
+}
+
+// GetAuthoredPRIDs выдает id PR, созданных каждым из пользователей.
+func (u Usecase) GetAuthoredPRIDs(_ context.Context, userIDs []string) (map[string][]string, error) {
+	links, err := u.storage.GetAuthoredPRIDs(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authored pull requests: %v", err)
+	}
+	return groupByUser(links), nil
+}
+
+func groupByUser(links []repository.UserPR) map[string][]string {
+	grouped := make(map[string][]string)
+	for _, l := range links {
+		grouped[l.UserID] = append(grouped[l.UserID], l.PullRequestID)
+	}
+	return grouped
+}
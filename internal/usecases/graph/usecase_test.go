@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/usecases/graph/mocks"
+	repository "github.com/qwerty268/pull_request_service/internal/usecases/graph/storage"
+)
+
+func TestUsecase_GetTeams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(storage)
+	ctx := context.Background()
+
+	t.Run("groups members", func(t *testing.T) {
+		storage.EXPECT().
+			GetExistingTeams([]string{"backend", "empty", "ghost"}).
+			Return([]string{"backend", "empty"}, nil)
+		storage.EXPECT().
+			GetTeamMembers([]string{"backend", "empty"}).
+			Return([]repository.TeamMember{
+				{TeamName: "backend", UserID: "u1", Role: "lead"},
+				{TeamName: "backend", UserID: "u2", Role: "member"},
+			}, nil)
+
+		teams, err := usecase.GetTeams(ctx, []string{"backend", "empty", "ghost"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]Team{
+			"backend": {TeamName: "backend", Members: []TeamMember{{UserID: "u1", Role: "lead"}, {UserID: "u2", Role: "member"}}},
+			"empty":   {TeamName: "empty", Members: []TeamMember{}},
+		}, teams)
+	})
+
+	t.Run("nothing found", func(t *testing.T) {
+		storage.EXPECT().
+			GetExistingTeams([]string{"ghost"}).
+			Return(nil, nil)
+
+		teams, err := usecase.GetTeams(ctx, []string{"ghost"})
+		require.NoError(t, err)
+		require.Empty(t, teams)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		storage.EXPECT().
+			GetExistingTeams(gomock.Any()).
+			Return(nil, errors.New("db down"))
+
+		_, err := usecase.GetTeams(ctx, []string{"backend"})
+		require.ErrorContains(t, err, "db down")
+	})
+}
+
+func TestUsecase_GetPullRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(storage)
+
+	created := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	storage.EXPECT().
+		GetPullRequestsByIDs([]string{"pr1", "pr2"}).
+		Return([]repository.PullRequest{
+			{PullRequestID: "pr1", AuthorID: "u1", CreatedAt: created},
+			{PullRequestID: "pr2", AuthorID: "u1", IsMerged: true, CreatedAt: created, MergedAt: created.Add(time.Hour)},
+		}, nil)
+
+	prs, err := usecase.GetPullRequests(context.Background(), []string{"pr1", "pr2"})
+	require.NoError(t, err)
+	require.Equal(t, statusOpen, prs["pr1"].Status)
+	require.Equal(t, statusMerged, prs["pr2"].Status)
+}
+
+func TestUsecase_GetReviewPRIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(storage)
+
+	storage.EXPECT().
+		GetReviewPRIDs([]string{"u1", "u2", "u3"}).
+		Return([]repository.UserPR{
+			{UserID: "u1", PullRequestID: "pr2"},
+			{UserID: "u2", PullRequestID: "pr2"},
+			{UserID: "u1", PullRequestID: "pr1"},
+		}, nil)
+
+	reviews, err := usecase.GetReviewPRIDs(context.Background(), []string{"u1", "u2", "u3"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"u1": {"pr2", "pr1"},
+		"u2": {"pr2"},
+	}, reviews)
+}
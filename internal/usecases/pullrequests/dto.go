@@ -22,4 +22,6 @@ type PullRequest struct {
 type ReassignedRewiew struct {
 	Pr          PullRequest
 	NewReviewer string
+	// Escalated - кандидатов не нашлось, и ревью передано лиду команды.
+	Escalated bool
 }
@@ -10,9 +10,12 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
+	events "github.com/qwerty268/pull_request_service/internal/events"
 	storage "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/storage"
+	teams "github.com/qwerty268/pull_request_service/internal/usecases/teams"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -69,6 +72,21 @@ func (mr *MockprStorageMockRecorder) CheckUserInPr(prID, userID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserInPr", reflect.TypeOf((*MockprStorage)(nil).CheckUserInPr), prID, userID)
 }
 
+// CountOpenReviews mocks base method.
+func (m *MockprStorage) CountOpenReviews(userIDs []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenReviews", userIDs)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenReviews indicates an expected call of CountOpenReviews.
+func (mr *MockprStorageMockRecorder) CountOpenReviews(userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenReviews", reflect.TypeOf((*MockprStorage)(nil).CountOpenReviews), userIDs)
+}
+
 // GetPrByID mocks base method.
 func (m *MockprStorage) GetPrByID(prID string) (*storage.PullRequest, error) {
 	m.ctrl.T.Helper()
@@ -99,18 +117,19 @@ func (mr *MockprStorageMockRecorder) ResetPrMember(filter any) *gomock.Call {
 }
 
 // SetPrMerged mocks base method.
-func (m *MockprStorage) SetPrMerged(prID string) (*storage.PullRequest, error) {
+func (m *MockprStorage) SetPrMerged(prID string, minReviewers int) (*storage.PullRequest, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetPrMerged", prID)
+	ret := m.ctrl.Call(m, "SetPrMerged", prID, minReviewers)
 	ret0, _ := ret[0].(*storage.PullRequest)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // SetPrMerged indicates an expected call of SetPrMerged.
-func (mr *MockprStorageMockRecorder) SetPrMerged(prID any) *gomock.Call {
+func (mr *MockprStorageMockRecorder) SetPrMerged(prID, minReviewers any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrMerged", reflect.TypeOf((*MockprStorage)(nil).SetPrMerged), prID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrMerged", reflect.TypeOf((*MockprStorage)(nil).SetPrMerged), prID, minReviewers)
 }
 
 // MockteamStorage is a mock of teamStorage interface.
@@ -167,6 +186,21 @@ func (mr *MockteamStorageMockRecorder) GetUserActiveTeammates(userID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserActiveTeammates", reflect.TypeOf((*MockteamStorage)(nil).GetUserActiveTeammates), userID)
 }
 
+// GetUserTeamLeads mocks base method.
+func (m *MockteamStorage) GetUserTeamLeads(userID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTeamLeads", userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTeamLeads indicates an expected call of GetUserTeamLeads.
+func (mr *MockteamStorageMockRecorder) GetUserTeamLeads(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTeamLeads", reflect.TypeOf((*MockteamStorage)(nil).GetUserTeamLeads), userID)
+}
+
 // MockuserStorage is a mock of userStorage interface.
 type MockuserStorage struct {
 	ctrl     *gomock.Controller
@@ -205,3 +239,78 @@ func (mr *MockuserStorageMockRecorder) CheckUserExists(userID any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExists", reflect.TypeOf((*MockuserStorage)(nil).CheckUserExists), userID)
 }
+
+// MocksettingsProvider is a mock of settingsProvider interface.
+type MocksettingsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MocksettingsProviderMockRecorder
+	isgomock struct{}
+}
+
+// MocksettingsProviderMockRecorder is the mock recorder for MocksettingsProvider.
+type MocksettingsProviderMockRecorder struct {
+	mock *MocksettingsProvider
+}
+
+// NewMocksettingsProvider creates a new mock instance.
+func NewMocksettingsProvider(ctrl *gomock.Controller) *MocksettingsProvider {
+	mock := &MocksettingsProvider{ctrl: ctrl}
+	mock.recorder = &MocksettingsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksettingsProvider) EXPECT() *MocksettingsProviderMockRecorder {
+	return m.recorder
+}
+
+// GetUserTeamSettings mocks base method.
+func (m *MocksettingsProvider) GetUserTeamSettings(ctx context.Context, userID string) (*teams.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTeamSettings", ctx, userID)
+	ret0, _ := ret[0].(*teams.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTeamSettings indicates an expected call of GetUserTeamSettings.
+func (mr *MocksettingsProviderMockRecorder) GetUserTeamSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTeamSettings", reflect.TypeOf((*MocksettingsProvider)(nil).GetUserTeamSettings), ctx, userID)
+}
+
+// MockeventPublisher is a mock of eventPublisher interface.
+type MockeventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockeventPublisherMockRecorder is the mock recorder for MockeventPublisher.
+type MockeventPublisherMockRecorder struct {
+	mock *MockeventPublisher
+}
+
+// NewMockeventPublisher creates a new mock instance.
+func NewMockeventPublisher(ctrl *gomock.Controller) *MockeventPublisher {
+	mock := &MockeventPublisher{ctrl: ctrl}
+	mock.recorder = &MockeventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventPublisher) EXPECT() *MockeventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockeventPublisher) Publish(ev events.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ev)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockeventPublisherMockRecorder) Publish(ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockeventPublisher)(nil).Publish), ev)
+}
@@ -24,3 +24,46 @@ type PullRequestShort struct {
 	AuthorID        string
 	IsMerged        bool
 }
+
+type ReviewHistoryFilter struct {
+	UserID string
+	// Status - один из HistoryStatus*, пустой - без фильтра.
+	Status string
+	// From и To ограничивают время назначения, nil - без ограничения.
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+// ReviewHistoryEntry - одно назначение пользователя на ревью.
+type ReviewHistoryEntry struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	Status          string
+	AssignedAt      time.Time
+	UnassignedAt    *time.Time
+	Reason          string
+}
+
+type ReviewHistoryPage struct {
+	Entries []ReviewHistoryEntry
+	Total   int
+}
+
+// DashboardPR - PR на персональной панели с текущими ревьюерами.
+type DashboardPR struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	Reviewers       []string
+	CreatedAt       time.Time
+	MergedAt        time.Time
+}
+
+type Dashboard struct {
+	Authored       []DashboardPR
+	AwaitingReview []DashboardPR
+	RecentlyMerged []DashboardPR
+}
@@ -8,11 +8,23 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+
+	"github.com/qwerty268/pull_request_service/internal/assignment"
+	"github.com/qwerty268/pull_request_service/internal/metrics"
 )
 
 var (
 	ErrAlreadyExists = errors.New("already exists")
 	ErrNotFound      = errors.New("not found")
+	// ErrTooFewReviewers - на PR назначено меньше ревьюеров, чем нужно для мержа.
+	ErrTooFewReviewers = errors.New("too few reviewers")
+)
+
+// Статусы записи истории ревью.
+const (
+	HistoryStatusActive     = "ACTIVE"
+	HistoryStatusMerged     = "MERGED"
+	HistoryStatusUnassigned = "UNASSIGNED"
 )
 
 type Storage struct {
@@ -30,6 +42,8 @@ func NewStorage(db *sqlx.DB) *Storage {
 }
 
 func (s *Storage) GetUserReviewRequests(userID string) ([]PullRequestShort, error) {
+	defer metrics.ObserveQuery("pullrequests", "GetUserReviewRequests", time.Now())
+
 	query := `
 	SELECT
 		pr.pull_request_id,
@@ -67,6 +81,8 @@ func (s *Storage) GetUserReviewRequests(userID string) ([]PullRequestShort, erro
 }
 
 func (s *Storage) AddPr(pr PullRequest) error {
+	defer metrics.ObserveQuery("pullrequests", "AddPr", time.Now())
+
 	tx, err := s.db.Beginx()
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
@@ -96,6 +112,14 @@ func (s *Storage) AddPr(pr PullRequest) error {
 		if err != nil {
 			return fmt.Errorf("insert pr_reviewers_map: %w", err)
 		}
+
+		_, err = tx.Exec(`
+			INSERT INTO review_assignment (pull_request_id, user_id, assigned_at)
+			VALUES ($1, $2, $3)
+		`, pr.PullRequestID, reviewerID, pr.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("insert review_assignment: %w", err)
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -104,17 +128,21 @@ func (s *Storage) AddPr(pr PullRequest) error {
 	return nil
 }
 
-func (s *Storage) SetPrMerged(prID string) (*PullRequest, error) {
-	now := time.Now()
-	// Сначала пробуем смержить, но только если не смержен
+// SetPrMerged мержит PR, если на него назначено не меньше minReviewers ревьюеров. Проверка и обновление
+// идут одним UPDATE, так что ревьюера не успеют снять между ними. merged - PR смержен именно этим вызовом;
+// уже смерженный PR возвращается как есть без проверки.
+func (s *Storage) SetPrMerged(prID string, minReviewers int) (*PullRequest, bool, error) {
+	defer metrics.ObserveQuery("pullrequests", "SetPrMerged", time.Now())
+
 	queryUpdate := `
 		UPDATE pull_request
 		SET is_merged = TRUE, merged_at = $2
 		WHERE pull_request_id = $1 AND is_merged = FALSE
+			AND COALESCE(cardinality(assigned_reviewers), 0) >= $3
 		RETURNING pull_request_id, pull_request_name, author_id, is_merged, assigned_reviewers, created_at, merged_at
 	`
 	var pr PullRequest
-	err := s.db.QueryRow(queryUpdate, prID, now).Scan(
+	err := s.db.QueryRow(queryUpdate, prID, time.Now(), minReviewers).Scan(
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
@@ -124,37 +152,26 @@ func (s *Storage) SetPrMerged(prID string) (*PullRequest, error) {
 		&pr.MergedAt,
 	)
 	if err == nil {
-		return &pr, nil
+		return &pr, true, nil
 	}
-
 	if !errors.Is(err, sql.ErrNoRows) {
-		return nil, fmt.Errorf("SetPrMerged (update): %w", err)
+		return nil, false, fmt.Errorf("SetPrMerged (update): %w", err)
 	}
 
-	querySelect := `
-		SELECT pull_request_id, pull_request_name, author_id, is_merged, assigned_reviewers, created_at, merged_at
-		FROM pull_request
-		WHERE pull_request_id = $1
-	`
-	err = s.db.QueryRow(querySelect, prID).Scan(
-		&pr.PullRequestID,
-		&pr.PullRequestName,
-		&pr.AuthorID,
-		&pr.IsMerged,
-		pq.Array(&pr.AssignedReviewers),
-		&pr.CreatedAt,
-		&pr.MergedAt,
-	)
+	// Ничего не обновилось: PR нет, он уже смержен или ревьюеров не хватает.
+	current, err := s.GetPrByID(prID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrNotFound
-		}
-		return nil, fmt.Errorf("SetPrMerged (select): %w", err)
+		return nil, false, err
 	}
-	return &pr, nil
+	if !current.IsMerged {
+		return nil, false, fmt.Errorf("pr has %d reviewers: %w", len(current.AssignedReviewers), ErrTooFewReviewers)
+	}
+	return current, false, nil
 }
 
 func (s *Storage) CheckUserInPr(prID, userID string) (bool, error) {
+	defer metrics.ObserveQuery("pullrequests", "CheckUserInPr", time.Now())
+
 	query := `
 		SELECT 1
 		FROM pr_reviewers_map
@@ -173,6 +190,8 @@ func (s *Storage) CheckUserInPr(prID, userID string) (bool, error) {
 }
 
 func (s *Storage) GetPrByID(prID string) (*PullRequest, error) {
+	defer metrics.ObserveQuery("pullrequests", "GetPrByID", time.Now())
+
 	query := `
         SELECT 
             pull_request_id, 
@@ -204,15 +223,284 @@ func (s *Storage) GetPrByID(prID string) (*PullRequest, error) {
 	return &pr, nil
 }
 
+// ResetPrMember заменяет ревьюера PR и записывает замену в историю назначений.
 func (s *Storage) ResetPrMember(filter ResetReviewerFilter) error {
-	query := `
+	defer metrics.ObserveQuery("pullrequests", "ResetPrMember", time.Now())
+
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`
 		UPDATE pr_reviewers_map
 		SET user_id = $3
 		WHERE pull_request_id = $1 AND user_id = $2
-	`
-	_, err := s.db.Exec(query, filter.PrID, filter.OldUserID, filter.NewUserID)
+	`, filter.PrID, filter.OldUserID, filter.NewUserID)
 	if err != nil {
 		return fmt.Errorf("ResetPrMember: %w", err)
 	}
+
+	_, err = tx.Exec(`
+		UPDATE pull_request
+		SET assigned_reviewers = array_replace(assigned_reviewers, $2, $3)
+		WHERE pull_request_id = $1
+	`, filter.PrID, filter.OldUserID, filter.NewUserID)
+	if err != nil {
+		return fmt.Errorf("update pull_request: %w", err)
+	}
+
+	if err := replaceAssignment(tx, filter.PrID, filter.OldUserID, filter.NewUserID, assignment.ReasonReassigned); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
 	return nil
 }
+
+// replaceAssignment закрывает запись истории старого ревьюера и открывает запись нового.
+// Вызывается внутри транзакции, которая меняет pr_reviewers_map.
+func replaceAssignment(tx *sqlx.Tx, prID, oldUserID, newUserID, reason string) error {
+	_, err := tx.Exec(`
+		UPDATE review_assignment
+		SET unassigned_at = now(), reason = $3
+		WHERE pull_request_id = $1 AND user_id = $2 AND unassigned_at IS NULL
+	`, prID, oldUserID, reason)
+	if err != nil {
+		return fmt.Errorf("close review_assignment: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO review_assignment (pull_request_id, user_id, assigned_at)
+		VALUES ($1, $2, now())
+	`, prID, newUserID)
+	if err != nil {
+		return fmt.Errorf("insert review_assignment: %w", err)
+	}
+	return nil
+}
+
+// reviewHistoryCTE - назначения пользователя $1 за период [$2, $3) со статусом записи истории.
+const reviewHistoryCTE = `
+		WITH history AS (
+			SELECT
+				ra.pull_request_id,
+				pr.pull_request_name,
+				pr.author_id,
+				CASE
+					WHEN ra.unassigned_at IS NOT NULL THEN 'UNASSIGNED'
+					WHEN pr.is_merged THEN 'MERGED'
+					ELSE 'ACTIVE'
+				END AS status,
+				ra.assigned_at,
+				ra.unassigned_at,
+				COALESCE(ra.reason, '') AS reason,
+				ra.id
+			FROM review_assignment AS ra
+			JOIN pull_request AS pr ON pr.pull_request_id = ra.pull_request_id
+			WHERE ra.user_id = $1
+			AND ($2::TIMESTAMPTZ IS NULL OR ra.assigned_at >= $2)
+			AND ($3::TIMESTAMPTZ IS NULL OR ra.assigned_at < $3)
+		)
+`
+
+// GetUserReviewHistory выдает страницу истории назначений пользователя, новые сверху.
+func (s *Storage) GetUserReviewHistory(filter ReviewHistoryFilter) (*ReviewHistoryPage, error) {
+	defer metrics.ObserveQuery("pullrequests", "GetUserReviewHistory", time.Now())
+
+	query := reviewHistoryCTE + `
+		SELECT
+			pull_request_id,
+			pull_request_name,
+			author_id,
+			status,
+			assigned_at,
+			unassigned_at,
+			reason,
+			COUNT(*) OVER ()
+		FROM history
+		WHERE $4 = '' OR status = $4
+		ORDER BY assigned_at DESC, id DESC
+		LIMIT $5 OFFSET $6
+	`
+
+	rows, err := s.db.Query(query, filter.UserID, filter.From, filter.To, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("GetUserReviewHistory: %w", err)
+	}
+	defer rows.Close()
+
+	page := &ReviewHistoryPage{Entries: make([]ReviewHistoryEntry, 0)}
+	for rows.Next() {
+		var (
+			entry        ReviewHistoryEntry
+			unassignedAt sql.NullTime
+		)
+		err := rows.Scan(
+			&entry.PullRequestID,
+			&entry.PullRequestName,
+			&entry.AuthorID,
+			&entry.Status,
+			&entry.AssignedAt,
+			&unassignedAt,
+			&entry.Reason,
+			&page.Total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		if unassignedAt.Valid {
+			entry.UnassignedAt = &unassignedAt.Time
+		}
+		page.Entries = append(page.Entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+
+	// Страница за пределами выборки - общее количество считаем отдельно.
+	if len(page.Entries) == 0 && filter.Offset > 0 {
+		err = s.db.QueryRow(reviewHistoryCTE+`
+			SELECT COUNT(*)
+			FROM history
+			WHERE $4 = '' OR status = $4
+		`, filter.UserID, filter.From, filter.To, filter.Status).Scan(&page.Total)
+		if err != nil {
+			return nil, fmt.Errorf("GetUserReviewHistory count: %w", err)
+		}
+	}
+	return page, nil
+}
+
+func (s *Storage) CountOpenReviews(userIDs []string) (map[string]int, error) {
+	defer metrics.ObserveQuery("pullrequests", "CountOpenReviews", time.Now())
+
+	query := `
+		SELECT prm.user_id, COUNT(*)
+		FROM pr_reviewers_map AS prm
+		JOIN pull_request AS pr ON pr.pull_request_id = prm.pull_request_id
+		WHERE prm.user_id = ANY($1) AND NOT pr.is_merged
+		GROUP BY prm.user_id
+	`
+
+	rows, err := s.db.Query(query, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("CountOpenReviews: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(userIDs))
+	for rows.Next() {
+		var (
+			userID string
+			count  int
+		)
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		counts[userID] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return counts, nil
+}
+
+// GetUserDashboard собирает PR пользователя тремя запросами: открытые авторские,
+// ожидающие его ревью (старые сверху) и смерженные авторские после mergedSince.
+// У открытых PR merged_at хранится нулевым, поэтому он читается только для смерженных.
+func (s *Storage) GetUserDashboard(userID string, mergedSince time.Time, mergedLimit int) (*Dashboard, error) {
+	defer metrics.ObserveQuery("pullrequests", "GetUserDashboard", time.Now())
+
+	const columns = `
+		pr.pull_request_id,
+		pr.pull_request_name,
+		pr.author_id,
+		ARRAY(
+			SELECT prm.user_id
+			FROM pr_reviewers_map AS prm
+			WHERE prm.pull_request_id = pr.pull_request_id
+			ORDER BY prm.user_id
+		),
+		pr.created_at,
+		CASE WHEN pr.is_merged THEN pr.merged_at END
+	`
+
+	authored, err := s.queryDashboardPRs(`
+		SELECT `+columns+`
+		FROM pull_request AS pr
+		WHERE pr.author_id = $1 AND NOT pr.is_merged
+		ORDER BY pr.created_at
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("authored: %w", err)
+	}
+
+	awaiting, err := s.queryDashboardPRs(`
+		SELECT `+columns+`
+		FROM pull_request AS pr
+		JOIN pr_reviewers_map AS my ON my.pull_request_id = pr.pull_request_id
+		WHERE my.user_id = $1 AND NOT pr.is_merged
+		ORDER BY pr.created_at
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("awaiting review: %w", err)
+	}
+
+	merged, err := s.queryDashboardPRs(`
+		SELECT `+columns+`
+		FROM pull_request AS pr
+		WHERE pr.author_id = $1 AND pr.is_merged AND pr.merged_at >= $2
+		ORDER BY pr.merged_at DESC
+		LIMIT $3
+	`, userID, mergedSince, mergedLimit)
+	if err != nil {
+		return nil, fmt.Errorf("recently merged: %w", err)
+	}
+
+	return &Dashboard{
+		Authored:       authored,
+		AwaitingReview: awaiting,
+		RecentlyMerged: merged,
+	}, nil
+}
+
+func (s *Storage) queryDashboardPRs(query string, args ...any) ([]DashboardPR, error) {
+	defer metrics.ObserveQuery("pullrequests", "queryDashboardPRs", time.Now())
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]DashboardPR, 0)
+	for rows.Next() {
+		var (
+			pr       DashboardPR
+			mergedAt sql.NullTime
+		)
+		err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			pq.Array(&pr.Reviewers),
+			&pr.CreatedAt,
+			&mergedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		if mergedAt.Valid {
+			pr.MergedAt = mergedAt.Time
+		}
+		prs = append(prs, pr)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return prs, nil
+}
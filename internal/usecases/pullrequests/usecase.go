@@ -7,9 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"time"
 
+	"github.com/qwerty268/pull_request_service/internal/events"
+	"github.com/qwerty268/pull_request_service/internal/metrics"
 	repository "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/storage"
+	"github.com/qwerty268/pull_request_service/internal/usecases/teams"
 )
 
 var (
@@ -18,6 +22,8 @@ var (
 	ErrPRMerged      = errors.New("already merged")
 	ErrNotAssigned   = errors.New("not assigned")
 	ErrNoCandidate   = errors.New("no condidate")
+	// ErrNotEnoughReviewers - на PR назначено меньше ревьюеров, чем требуют настройки команды.
+	ErrNotEnoughReviewers = errors.New("not enough reviewers")
 )
 
 var GetRandomReviewer = getRandomReviewer // Чтобы тестить.
@@ -29,11 +35,14 @@ const (
 
 type prStorage interface {
 	AddPr(pr repository.PullRequest) error
-	SetPrMerged(prID string) (*repository.PullRequest, error)
+	// SetPrMerged мержит PR, если на нем не меньше minReviewers ревьюеров. bool - PR смержен этим вызовом.
+	SetPrMerged(prID string, minReviewers int) (*repository.PullRequest, bool, error)
 	// CheckUserInPr проаеряет, что есть запись в таблице pr_user_map
 	CheckUserInPr(prID, userID string) (bool, error)
 	GetPrByID(prID string) (*repository.PullRequest, error)
 	ResetPrMember(filter repository.ResetReviewerFilter) error
+	// CountOpenReviews выдает число открытых PR, на которые назначен каждый из пользователей.
+	CountOpenReviews(userIDs []string) (map[string]int, error)
 }
 
 type teamStorage interface {
@@ -42,27 +51,48 @@ type teamStorage interface {
 	// CheckUserInCommand смотрит существует ли запись про юзера в таблице team_user_map.
 	// Подразумевается, что у пользователя одна команда.
 	CheckUserInCommand(userID string) (bool, error)
+	// GetUserTeamLeads выдает лидов команды пользователя (включая его самого, если он лид) вне зависимости от активности.
+	GetUserTeamLeads(userID string) ([]string, error)
 }
 
 type userStorage interface {
 	CheckUserExists(userID string) (bool, error)
 }
 
+type settingsProvider interface {
+	// GetUserTeamSettings выдает действующие настройки команды пользователя.
+	GetUserTeamSettings(ctx context.Context, userID string) (*teams.Settings, error)
+}
+
+type eventPublisher interface {
+	Publish(ev events.Event)
+}
+
 type Usecase struct {
 	prStorage   prStorage
 	teamStorage teamStorage
 	userStorage userStorage
+	settings    settingsProvider
+	events      eventPublisher
 }
 
-func NewUsecase(prStorage prStorage, teamStorage teamStorage, userStorage userStorage) Usecase {
+func NewUsecase(
+	prStorage prStorage,
+	teamStorage teamStorage,
+	userStorage userStorage,
+	settings settingsProvider,
+	publisher eventPublisher,
+) Usecase {
 	return Usecase{
 		prStorage:   prStorage,
 		teamStorage: teamStorage,
 		userStorage: userStorage,
+		settings:    settings,
+		events:      publisher,
 	}
 }
 
-func (u Usecase) CreatePR(_ context.Context, pr CreatePROpst) (*PullRequest, error) {
+func (u Usecase) CreatePR(ctx context.Context, pr CreatePROpst) (*PullRequest, error) {
 	newPr := &PullRequest{
 		PullRequestID:   pr.PullRequestID,
 		PullRequestName: pr.PullRequestName,
@@ -79,11 +109,19 @@ func (u Usecase) CreatePR(_ context.Context, pr CreatePROpst) (*PullRequest, err
 		return nil, fmt.Errorf("user or team not exists: %w", ErrNotFound)
 	}
 
+	settings, err := u.settings.GetUserTeamSettings(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team settings: %v", err)
+	}
+
 	activeTeammates, err := u.teamStorage.GetUserActiveTeammates(pr.AuthorID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user teammates: %v", err)
 	}
-	reviewers := getRandomReviewers(activeTeammates)
+	reviewers, err := u.selectReviewers(activeTeammates, settings.ReviewersCount, settings.SelectionStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewers: %v", err)
+	}
 
 	newPr.AssignedReviewers = reviewers
 
@@ -94,6 +132,8 @@ func (u Usecase) CreatePR(_ context.Context, pr CreatePROpst) (*PullRequest, err
 		}
 		return nil, fmt.Errorf("failed to save pr: %v", err)
 	}
+	metrics.PRCreated()
+	u.publish(ctx, events.PRCreated, settings.TeamName, newPr, toEventPR(newPr))
 
 	return newPr, nil
 }
@@ -113,28 +153,101 @@ func toStoragePR(pr *PullRequest) repository.PullRequest {
 	return repositoryPr
 }
 
-func getRandomReviewers(activeTeammates []string) []string {
-	if len(activeTeammates) <= 2 {
+// selectReviewers выбирает count ревьюеров из кандидатов согласно стратегии команды.
+func (u Usecase) selectReviewers(candidates []string, count int, strategy string) ([]string, error) {
+	if strategy != teams.StrategyLeastLoaded {
+		return getRandomReviewers(candidates, count), nil
+	}
+	if len(candidates) <= count {
+		return candidates, nil
+	}
+
+	load, err := u.prStorage.CountOpenReviews(candidates)
+	if err != nil {
+		return nil, fmt.Errorf("count open reviews: %v", err)
+	}
+	return getLeastLoadedReviewers(candidates, load, count), nil
+}
+
+func getRandomReviewers(activeTeammates []string, count int) []string {
+	if len(activeTeammates) <= count {
 		return activeTeammates
 	}
-	n := len(activeTeammates)
-	i := rand.Intn(n)
-	j := rand.Intn(n - 1)
-	if j >= i {
-		j++
+	reviewers := make([]string, count)
+	for i, j := range rand.Perm(len(activeTeammates))[:count] {
+		reviewers[i] = activeTeammates[j]
 	}
-	return []string{activeTeammates[i], activeTeammates[j]}
+	return reviewers
+}
+
+// getLeastLoadedReviewers берет наименее загруженных кандидатов. При равной загрузке порядок случайный.
+func getLeastLoadedReviewers(candidates []string, load map[string]int, count int) []string {
+	shuffled := getRandomReviewers(candidates, len(candidates))
+	sorted := make([]string, len(shuffled))
+	copy(sorted, shuffled)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return load[sorted[i]] < load[sorted[j]]
+	})
+	return sorted[:count]
 }
 
 func (u Usecase) MergePR(ctx context.Context, prID string) (*PullRequest, error) {
-	storagePr, err := u.prStorage.SetPrMerged(prID)
+	current, err := u.prStorage.GetPrByID(prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get pr from storage: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get pr from storage: %v", err)
+	}
+
+	// Уже смерженный PR возвращаем как есть, проверять настройки не нужно.
+	minReviewers := 0
+	if !current.IsMerged {
+		settings, err := u.settings.GetUserTeamSettings(ctx, current.AuthorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team settings: %v", err)
+		}
+		minReviewers = settings.MinReviewers
+	}
+
+	return u.setMerged(ctx, prID, minReviewers)
+}
+
+// MarkMerged отмечает PR смерженным без проверки настроек команды. Это для мержей, которые уже
+// случились во внешней системе: отказ в них не отменит мерж, а только разведет данные.
+func (u Usecase) MarkMerged(ctx context.Context, prID string) (*PullRequest, error) {
+	return u.setMerged(ctx, prID, 0)
+}
+
+func (u Usecase) GetPR(_ context.Context, prID string) (*PullRequest, error) {
+	storagePr, err := u.prStorage.GetPrByID(prID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get pr from storage: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get pr from storage: %v", err)
+	}
+	return fromStoragePr(storagePr), nil
+}
+
+func (u Usecase) setMerged(ctx context.Context, prID string, minReviewers int) (*PullRequest, error) {
+	storagePr, merged, err := u.prStorage.SetPrMerged(prID, minReviewers)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
 			return nil, fmt.Errorf("failed to set merged flag: %w", ErrNotFound)
+		case errors.Is(err, repository.ErrTooFewReviewers):
+			return nil, fmt.Errorf("team requires %d reviewers, %v: %w", minReviewers, err, ErrNotEnoughReviewers)
 		}
 		return nil, fmt.Errorf("failed to set merged flag: %v", err)
 	}
-	return fromStoragePr(storagePr), nil
+	pr := fromStoragePr(storagePr)
+	// Повторный мерж идемпотентен и в метрику и события не попадает.
+	if merged {
+		metrics.PRMerged()
+		u.publish(ctx, events.PRMerged, "", pr, toEventPR(pr))
+	}
+	return pr, nil
 }
 
 func fromStoragePr(storagePr *repository.PullRequest) *PullRequest {
@@ -184,17 +297,12 @@ func (u Usecase) ReassignReviewer(ctx context.Context, prID, oldUserID string) (
 		return nil, fmt.Errorf("failed to check user in pr: %w", ErrNotAssigned)
 	}
 
-	// 3. Выделяем чела, который должен остаться. Рассчет на то что ревьюеров может быть максимум 2.
-	newReviewers := make([]string, 0)
-	var reviewerShouldStay *string
-	if len(storagePr.AssignedReviewers) > 1 {
-		// Случай, что есть чел, которого надо оставить.
-		if storagePr.AssignedReviewers[0] == oldUserID {
-			reviewerShouldStay = &storagePr.AssignedReviewers[1]
-		} else {
-			reviewerShouldStay = &storagePr.AssignedReviewers[0]
+	// 3. Выделяем ревьюеров, которые должны остаться.
+	newReviewers := make([]string, 0, len(storagePr.AssignedReviewers))
+	for _, reviewer := range storagePr.AssignedReviewers {
+		if reviewer != oldUserID {
+			newReviewers = append(newReviewers, reviewer)
 		}
-		newReviewers = append(newReviewers, *reviewerShouldStay)
 	}
 
 	// 4. Выделяем активных тиммейтов, которых можно назначить на ревью.
@@ -203,34 +311,51 @@ func (u Usecase) ReassignReviewer(ctx context.Context, prID, oldUserID string) (
 		return nil, fmt.Errorf("failed to get active teammates: %v", err)
 	}
 
-	// 5. Из полученных пользователей вычитаем ревьюера, который должен остаться и автора.
-	var mustRemove map[string]struct{}
-	if reviewerShouldStay != nil {
-		mustRemove = map[string]struct{}{
-			*reviewerShouldStay: {},
-			storagePr.AuthorID:  {},
-		}
-	} else {
-		mustRemove = map[string]struct{}{
-			storagePr.AuthorID: {},
-		}
+	// 5. Из полученных пользователей вычитаем ревьюеров, которые должны остаться, и автора.
+	mustRemove := map[string]struct{}{
+		storagePr.AuthorID: {},
+	}
+	for _, reviewer := range newReviewers {
+		mustRemove[reviewer] = struct{}{}
 	}
 
 	// Фильтруем activeMembers
-	filtered := make([]string, 0, len(activeMembers))
-	for _, v := range activeMembers {
-		if _, toRemove := mustRemove[v]; !toRemove {
-			filtered = append(filtered, v)
-		}
-	}
-	activeMembers = filtered
-	// Нет кондидатов.
+	activeMembers = excludeUsers(activeMembers, mustRemove)
+
+	var (
+		newReviewer string
+		escalated   bool
+	)
 	if len(activeMembers) == 0 {
-		return nil, fmt.Errorf("failed to assign new condidate: %w", ErrNoCandidate)
+		// 6. Нет кондидатов - эскалируем на лида команды, даже если он неактивен.
+		leads, err := u.teamStorage.GetUserTeamLeads(oldUserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team leads: %v", err)
+		}
+		mustRemove[oldUserID] = struct{}{}
+		leads = excludeUsers(leads, mustRemove)
+		if len(leads) == 0 {
+			metrics.NoCandidate()
+			return nil, fmt.Errorf("failed to assign new condidate: %w", ErrNoCandidate)
+		}
+		newReviewer = GetRandomReviewer(leads)
+		escalated = true
+	} else {
+		// 6. Берем кандидата по стратегии команды автора.
+		settings, err := u.settings.GetUserTeamSettings(ctx, storagePr.AuthorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team settings: %v", err)
+		}
+		if settings.SelectionStrategy == teams.StrategyLeastLoaded {
+			selected, err := u.selectReviewers(activeMembers, 1, settings.SelectionStrategy)
+			if err != nil {
+				return nil, fmt.Errorf("failed to select reviewer: %v", err)
+			}
+			newReviewer = selected[0]
+		} else {
+			newReviewer = GetRandomReviewer(activeMembers)
+		}
 	}
-
-	// 6. Берем рандома.
-	newReviewer := GetRandomReviewer(activeMembers)
 	newReviewers = append(newReviewers, newReviewer)
 	// 7. Меняем запись в бд.
 	filter := repository.ResetReviewerFilter{
@@ -243,16 +368,62 @@ func (u Usecase) ReassignReviewer(ctx context.Context, prID, oldUserID string) (
 	if err != nil {
 		return nil, fmt.Errorf("failed tu reset pr member: %v", err)
 	}
+	metrics.ReviewerReassigned()
 
 	updatedPR := fromStoragePr(storagePr)
 	updatedPR.AssignedReviewers = newReviewers
+	u.publish(ctx, events.ReviewerReassigned, "", updatedPR, events.Reassignment{
+		PullRequestID: prID,
+		OldUserID:     oldUserID,
+		NewUserID:     newReviewer,
+		Escalated:     escalated,
+	}, oldUserID)
 
 	return &ReassignedRewiew{
 		Pr:          *updatedPR,
 		NewReviewer: newReviewer,
+		Escalated:   escalated,
 	}, nil
 }
 
+// publish рассылает событие по PR. Если команда неизвестна, она берется по автору PR;
+// без нее событие все равно уходит, просто не попадет в подписки с фильтром по команде.
+func (u Usecase) publish(ctx context.Context, eventType, teamName string, pr *PullRequest, data any, extraUserIDs ...string) {
+	if teamName == "" {
+		if settings, err := u.settings.GetUserTeamSettings(ctx, pr.AuthorID); err == nil {
+			teamName = settings.TeamName
+		}
+	}
+
+	userIDs := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+	u.events.Publish(events.Event{
+		Type:     eventType,
+		TeamName: teamName,
+		UserIDs:  append(userIDs, extraUserIDs...),
+		Data:     data,
+	})
+}
+
+func toEventPR(pr *PullRequest) events.PullRequest {
+	return events.PullRequest{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: pr.AssignedReviewers,
+	}
+}
+
+func excludeUsers(userIDs []string, exclude map[string]struct{}) []string {
+	filtered := make([]string, 0, len(userIDs))
+	for _, v := range userIDs {
+		if _, toRemove := exclude[v]; !toRemove {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
 func getRandomReviewer(activeMembers []string) string {
 	i := rand.Intn(len(activeMembers))
 	return activeMembers[i]
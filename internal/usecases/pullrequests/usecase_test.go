@@ -2,6 +2,7 @@ package pullrequests
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,8 +11,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/qwerty268/pull_request_service/internal/events"
 	"github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/mocks"
 	repo "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/storage"
+	"github.com/qwerty268/pull_request_service/internal/usecases/teams"
 )
 
 func TestUsecase_CreatePR(t *testing.T) {
@@ -20,9 +23,16 @@ func TestUsecase_CreatePR(t *testing.T) {
 
 	mockPRStorage := mocks.NewMockprStorage(ctrl)
 	mockTeamStorage := mocks.NewMockteamStorage(ctrl)
-	usecase := NewUsecase(mockPRStorage, mockTeamStorage, nil)
+	mockSettings := mocks.NewMocksettingsProvider(ctrl)
+	usecase := NewUsecase(mockPRStorage, mockTeamStorage, nil, mockSettings, events.Discard)
 	ctx := context.Background()
 
+	defaultSettings := teams.DefaultSettings
+	mockSettings.EXPECT().
+		GetUserTeamSettings(gomock.Any(), "authorA").
+		Return(&defaultSettings, nil).
+		AnyTimes()
+
 	base := CreatePROpst{
 		PullRequestID:   "pr42",
 		PullRequestName: "Fix bug",
@@ -196,12 +206,114 @@ func TestUsecase_CreatePR(t *testing.T) {
 	})
 }
 
+func TestUsecase_CreatePR_LeastLoaded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRStorage := mocks.NewMockprStorage(ctrl)
+	mockTeamStorage := mocks.NewMockteamStorage(ctrl)
+	mockSettings := mocks.NewMocksettingsProvider(ctrl)
+	usecase := NewUsecase(mockPRStorage, mockTeamStorage, nil, mockSettings, events.Discard)
+	ctx := context.Background()
+
+	settings := teams.DefaultSettings
+	settings.ReviewersCount = 3
+	settings.SelectionStrategy = teams.StrategyLeastLoaded
+	team := []string{"a1", "a2", "a3", "a4", "a5"}
+
+	mockTeamStorage.EXPECT().
+		CheckUserInCommand("authorA").
+		Return(true, nil)
+	// Второй раз настройки читаются ради команды события: в них она не заполнена.
+	mockSettings.EXPECT().
+		GetUserTeamSettings(ctx, "authorA").
+		Return(&settings, nil).
+		Times(2)
+	mockTeamStorage.EXPECT().
+		GetUserActiveTeammates("authorA").
+		Return(team, nil)
+	mockPRStorage.EXPECT().
+		CountOpenReviews(gomock.Any()).
+		Return(map[string]int{"a1": 5, "a2": 1, "a4": 3, "a5": 2}, nil)
+	mockPRStorage.EXPECT().
+		AddPr(gomock.AssignableToTypeOf(repo.PullRequest{})).
+		Return(nil)
+
+	pr, err := usecase.CreatePR(ctx, CreatePROpst{
+		PullRequestID:   "pr42",
+		PullRequestName: "Fix bug",
+		AuthorID:        "authorA",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a3", "a2", "a5"}, pr.AssignedReviewers)
+}
+
+func TestUsecase_PublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRStorage := mocks.NewMockprStorage(ctrl)
+	mockTeamStorage := mocks.NewMockteamStorage(ctrl)
+	mockSettings := mocks.NewMocksettingsProvider(ctrl)
+	mockEvents := mocks.NewMockeventPublisher(ctrl)
+	uc := NewUsecase(mockPRStorage, mockTeamStorage, nil, mockSettings, mockEvents)
+	ctx := context.Background()
+
+	settings := teams.DefaultSettings
+	settings.TeamName = "backend"
+	mockSettings.EXPECT().
+		GetUserTeamSettings(ctx, "authorA").
+		Return(&settings, nil).
+		AnyTimes()
+
+	t.Run("PR_CREATED", func(t *testing.T) {
+		mockTeamStorage.EXPECT().CheckUserInCommand("authorA").Return(true, nil)
+		mockTeamStorage.EXPECT().GetUserActiveTeammates("authorA").Return([]string{"a1"}, nil)
+		mockPRStorage.EXPECT().AddPr(gomock.Any()).Return(nil)
+		mockEvents.EXPECT().
+			Publish(gomock.Any()).
+			Do(func(ev events.Event) {
+				require.Equal(t, events.PRCreated, ev.Type)
+				require.Equal(t, "backend", ev.TeamName)
+				require.Equal(t, []string{"authorA", "a1"}, ev.UserIDs)
+				require.Equal(t, "pr42", ev.Data.(events.PullRequest).PullRequestID)
+			})
+
+		_, err := uc.CreatePR(ctx, CreatePROpst{PullRequestID: "pr42", PullRequestName: "Fix bug", AuthorID: "authorA"})
+		require.NoError(t, err)
+	})
+
+	t.Run("PR_MERGED only once", func(t *testing.T) {
+		merged := &repo.PullRequest{PullRequestID: "pr42", AuthorID: "authorA", IsMerged: true, AssignedReviewers: []string{"a1"}}
+		open := *merged
+		open.IsMerged = false
+
+		mockPRStorage.EXPECT().GetPrByID("pr42").Return(&open, nil)
+		mockPRStorage.EXPECT().SetPrMerged("pr42", 0).Return(merged, true, nil)
+		mockEvents.EXPECT().
+			Publish(gomock.Any()).
+			Do(func(ev events.Event) {
+				require.Equal(t, events.PRMerged, ev.Type)
+				require.Equal(t, "backend", ev.TeamName)
+				require.Equal(t, statusMerged, ev.Data.(events.PullRequest).Status)
+			})
+		_, err := uc.MergePR(ctx, "pr42")
+		require.NoError(t, err)
+
+		mockPRStorage.EXPECT().GetPrByID("pr42").Return(merged, nil)
+		mockPRStorage.EXPECT().SetPrMerged("pr42", 0).Return(merged, false, nil)
+		_, err = uc.MergePR(ctx, "pr42")
+		require.NoError(t, err)
+	})
+}
+
 func TestUsecase_MergePR(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockPRStorage := mocks.NewMockprStorage(ctrl)
-	uc := NewUsecase(mockPRStorage, nil, nil)
+	mockSettings := mocks.NewMocksettingsProvider(ctrl)
+	uc := NewUsecase(mockPRStorage, nil, nil, mockSettings, events.Discard)
 	ctx := context.Background()
 
 	basePR := &repo.PullRequest{
@@ -213,11 +325,24 @@ func TestUsecase_MergePR(t *testing.T) {
 		CreatedAt:         time.Now().Add(-time.Hour),
 		MergedAt:          time.Now(),
 	}
+	openPR := *basePR
+	openPR.IsMerged = false
+	openPR.MergedAt = time.Time{}
+
+	defaultSettings := teams.DefaultSettings
 
 	t.Run("ok", func(t *testing.T) {
 		mockPRStorage.EXPECT().
-			SetPrMerged("pr73").
-			Return(basePR, nil)
+			GetPrByID("pr73").
+			Return(&openPR, nil)
+		// Второй раз - команда для события PR_MERGED.
+		mockSettings.EXPECT().
+			GetUserTeamSettings(ctx, "johnny").
+			Return(&defaultSettings, nil).
+			Times(2)
+		mockPRStorage.EXPECT().
+			SetPrMerged("pr73", 0).
+			Return(basePR, true, nil)
 
 		pr, err := uc.MergePR(ctx, "pr73")
 		require.NoError(t, err)
@@ -231,21 +356,70 @@ func TestUsecase_MergePR(t *testing.T) {
 		require.WithinDuration(t, basePR.CreatedAt, pr.CreatedAt, time.Second)
 	})
 
+	t.Run("already merged skips settings", func(t *testing.T) {
+		mockPRStorage.EXPECT().
+			GetPrByID("pr73").
+			Return(basePR, nil)
+		mockPRStorage.EXPECT().
+			SetPrMerged("pr73", 0).
+			Return(basePR, false, nil)
+
+		pr, err := uc.MergePR(ctx, "pr73")
+		require.NoError(t, err)
+		require.Equal(t, statusMerged, pr.Status)
+	})
+
+	t.Run("not enough reviewers", func(t *testing.T) {
+		strict := teams.DefaultSettings
+		strict.MinReviewers = 3
+		mockPRStorage.EXPECT().
+			GetPrByID("pr73").
+			Return(&openPR, nil)
+		mockSettings.EXPECT().
+			GetUserTeamSettings(ctx, "johnny").
+			Return(&strict, nil)
+		mockPRStorage.EXPECT().
+			SetPrMerged("pr73", 3).
+			Return(nil, false, fmt.Errorf("pr has 2 reviewers: %w", repo.ErrTooFewReviewers))
+
+		pr, err := uc.MergePR(ctx, "pr73")
+		require.ErrorIs(t, err, ErrNotEnoughReviewers)
+		require.ErrorContains(t, err, "team requires 3 reviewers, pr has 2 reviewers")
+		require.Nil(t, pr)
+	})
+
+	t.Run("settings error", func(t *testing.T) {
+		mockPRStorage.EXPECT().
+			GetPrByID("pr73").
+			Return(&openPR, nil)
+		mockSettings.EXPECT().
+			GetUserTeamSettings(ctx, "johnny").
+			Return(nil, errors.New("settings fail"))
+
+		pr, err := uc.MergePR(ctx, "pr73")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "settings fail")
+		require.Nil(t, pr)
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		mockPRStorage.EXPECT().
-			SetPrMerged("pr-notfound").
+			GetPrByID("pr-notfound").
 			Return(nil, repo.ErrNotFound)
 
 		pr, err := uc.MergePR(ctx, "pr-notfound")
 		require.ErrorIs(t, err, ErrNotFound)
-		require.Contains(t, err.Error(), "failed to set merged flag")
+		require.Contains(t, err.Error(), "failed to get pr from storage")
 		require.Nil(t, pr)
 	})
 
 	t.Run("storage error", func(t *testing.T) {
 		mockPRStorage.EXPECT().
-			SetPrMerged("pr73").
-			Return(nil, errors.New("unexpected error"))
+			GetPrByID("pr73").
+			Return(basePR, nil)
+		mockPRStorage.EXPECT().
+			SetPrMerged("pr73", 0).
+			Return(nil, false, errors.New("unexpected error"))
 
 		pr, err := uc.MergePR(ctx, "pr73")
 		require.Error(t, err)
@@ -255,12 +429,15 @@ func TestUsecase_MergePR(t *testing.T) {
 	})
 
 	t.Run("status open", func(t *testing.T) {
-		basePROpen := *basePR
-		basePROpen.IsMerged = false
-
 		mockPRStorage.EXPECT().
-			SetPrMerged("pr-open").
-			Return(&basePROpen, nil)
+			GetPrByID("pr-open").
+			Return(&openPR, nil)
+		mockSettings.EXPECT().
+			GetUserTeamSettings(ctx, "johnny").
+			Return(&defaultSettings, nil)
+		mockPRStorage.EXPECT().
+			SetPrMerged("pr-open", 0).
+			Return(&openPR, false, nil)
 
 		pr, err := uc.MergePR(ctx, "pr-open")
 		require.NoError(t, err)
@@ -269,6 +446,75 @@ func TestUsecase_MergePR(t *testing.T) {
 	})
 }
 
+func TestUsecase_MarkMerged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRStorage := mocks.NewMockprStorage(ctrl)
+	mockSettings := mocks.NewMocksettingsProvider(ctrl)
+	uc := NewUsecase(mockPRStorage, nil, nil, mockSettings, events.Discard)
+	ctx := context.Background()
+
+	t.Run("ignores min reviewers", func(t *testing.T) {
+		// Минимум ревьюеров не запрашивается: SetPrMerged вызывается с нулем.
+		// Настройки читаются только ради команды события PR_MERGED.
+		mockPRStorage.EXPECT().
+			SetPrMerged("pr73", 0).
+			Return(&repo.PullRequest{PullRequestID: "pr73", AuthorID: "johnny", IsMerged: true}, true, nil)
+		mockSettings.EXPECT().
+			GetUserTeamSettings(ctx, "johnny").
+			Return(&teams.Settings{TeamName: "backend", MinReviewers: 3}, nil)
+
+		pr, err := uc.MarkMerged(ctx, "pr73")
+		require.NoError(t, err)
+		require.Equal(t, statusMerged, pr.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPRStorage.EXPECT().
+			SetPrMerged("pr-notfound", 0).
+			Return(nil, false, repo.ErrNotFound)
+
+		pr, err := uc.MarkMerged(ctx, "pr-notfound")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Nil(t, pr)
+	})
+}
+
+func TestUsecase_GetPR(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRStorage := mocks.NewMockprStorage(ctrl)
+	uc := NewUsecase(mockPRStorage, nil, nil, nil, events.Discard)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockPRStorage.EXPECT().
+			GetPrByID("pr1").
+			Return(&repo.PullRequest{
+				PullRequestID:     "pr1",
+				AuthorID:          "u1",
+				AssignedReviewers: []string{"u2"},
+			}, nil)
+
+		pr, err := uc.GetPR(ctx, "pr1")
+		require.NoError(t, err)
+		require.Equal(t, statusOpen, pr.Status)
+		require.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPRStorage.EXPECT().
+			GetPrByID("pr1").
+			Return(nil, repo.ErrNotFound)
+
+		pr, err := uc.GetPR(ctx, "pr1")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Nil(t, pr)
+	})
+}
+
 func TestUsecase_ReassignReviewer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -276,10 +522,16 @@ func TestUsecase_ReassignReviewer(t *testing.T) {
 	mockPRStorage := mocks.NewMockprStorage(ctrl)
 	mockUserStorage := mocks.NewMockuserStorage(ctrl)
 	mockTeamStorage := mocks.NewMockteamStorage(ctrl)
+	mockSettings := mocks.NewMocksettingsProvider(ctrl)
 
-	usecase := NewUsecase(mockPRStorage, mockTeamStorage, mockUserStorage)
+	usecase := NewUsecase(mockPRStorage, mockTeamStorage, mockUserStorage, mockSettings, events.Discard)
 
 	ctx := context.Background()
+	defaultSettings := teams.DefaultSettings
+	mockSettings.EXPECT().
+		GetUserTeamSettings(ctx, "author").
+		Return(&defaultSettings, nil).
+		AnyTimes()
 	prID := "pr1"
 	oldUserID := "bob"
 	now := time.Now()
@@ -366,11 +618,54 @@ func TestUsecase_ReassignReviewer(t *testing.T) {
 			Return(true, nil)
 		mockTeamStorage.EXPECT().GetUserActiveTeammates(oldUserID).
 			Return([]string{}, nil)
+		mockTeamStorage.EXPECT().GetUserTeamLeads(oldUserID).
+			Return([]string{"alice", "bob"}, nil)
 		res, err := usecase.ReassignReviewer(ctx, prID, oldUserID)
 		require.ErrorIs(t, err, ErrNoCandidate)
 		require.Nil(t, res)
 	})
 
+	t.Run("no candidates, escalated to lead", func(t *testing.T) {
+		mockPRStorage.EXPECT().GetPrByID(prID).
+			Return(storagePr, nil)
+		mockUserStorage.EXPECT().CheckUserExists(oldUserID).
+			Return(true, nil)
+		mockPRStorage.EXPECT().CheckUserInPr(prID, oldUserID).
+			Return(true, nil)
+		mockTeamStorage.EXPECT().GetUserActiveTeammates(oldUserID).
+			Return([]string{"alice", "author"}, nil)
+		mockTeamStorage.EXPECT().GetUserTeamLeads(oldUserID).
+			Return([]string{"bob", "dora"}, nil)
+		mockPRStorage.EXPECT().ResetPrMember(repo.ResetReviewerFilter{
+			PrID:      prID,
+			OldUserID: oldUserID,
+			NewUserID: "dora",
+		}).Return(nil)
+
+		res, err := usecase.ReassignReviewer(ctx, prID, oldUserID)
+		require.NoError(t, err)
+		require.Equal(t, "dora", res.NewReviewer)
+		require.True(t, res.Escalated)
+		require.ElementsMatch(t, []string{"alice", "dora"}, res.Pr.AssignedReviewers)
+	})
+
+	t.Run("team leads error", func(t *testing.T) {
+		mockPRStorage.EXPECT().GetPrByID(prID).
+			Return(storagePr, nil)
+		mockUserStorage.EXPECT().CheckUserExists(oldUserID).
+			Return(true, nil)
+		mockPRStorage.EXPECT().CheckUserInPr(prID, oldUserID).
+			Return(true, nil)
+		mockTeamStorage.EXPECT().GetUserActiveTeammates(oldUserID).
+			Return([]string{}, nil)
+		mockTeamStorage.EXPECT().GetUserTeamLeads(oldUserID).
+			Return(nil, errors.New("leads fail"))
+		res, err := usecase.ReassignReviewer(ctx, prID, oldUserID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get team leads")
+		require.Nil(t, res)
+	})
+
 	t.Run("team storage error", func(t *testing.T) {
 		mockPRStorage.EXPECT().GetPrByID(prID).
 			Return(storagePr, nil)
@@ -0,0 +1,53 @@
+package scim
+
+// User - пользователь в терминах провижининга.
+type User struct {
+	UserID   string
+	Username string
+	// TeamName - команда пользователя, пусто если он ни в одной не состоит.
+	TeamName string
+	IsActive bool
+}
+
+type UserFilter struct {
+	UserID   string
+	Username string
+	IsActive *bool
+	Limit    int
+	Offset   int
+}
+
+type UsersPage struct {
+	Users []User
+	Total int
+}
+
+// UserPatch - изменение пользователя. nil-поля не меняются.
+type UserPatch struct {
+	Username *string
+	IsActive *bool
+}
+
+// Group - команда в терминах провижининга.
+type Group struct {
+	Name    string
+	Members []string
+}
+
+type GroupFilter struct {
+	Name   string
+	Limit  int
+	Offset int
+}
+
+type GroupsPage struct {
+	Groups []Group
+	Total  int
+}
+
+type GroupPatch struct {
+	// SetMembers - новый состав команды целиком, nil если состав не заменяется.
+	SetMembers    *[]string
+	AddMembers    []string
+	RemoveMembers []string
+}
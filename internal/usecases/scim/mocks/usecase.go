@@ -0,0 +1,264 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+//
+// Generated by this command:
+//
+//	mockgen --source=usecase.go --destination=mocks/usecase.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	events "github.com/qwerty268/pull_request_service/internal/events"
+	storage "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
+	users "github.com/qwerty268/pull_request_service/internal/usecases/users"
+	storage0 "github.com/qwerty268/pull_request_service/internal/usecases/users/storage"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserStorage is a mock of userStorage interface.
+type MockuserStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockuserStorageMockRecorder is the mock recorder for MockuserStorage.
+type MockuserStorageMockRecorder struct {
+	mock *MockuserStorage
+}
+
+// NewMockuserStorage creates a new mock instance.
+func NewMockuserStorage(ctrl *gomock.Controller) *MockuserStorage {
+	mock := &MockuserStorage{ctrl: ctrl}
+	mock.recorder = &MockuserStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserStorage) EXPECT() *MockuserStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockuserStorage) CreateUser(user storage0.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockuserStorageMockRecorder) CreateUser(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockuserStorage)(nil).CreateUser), user)
+}
+
+// GetUser mocks base method.
+func (m *MockuserStorage) GetUser(userID string) (*storage0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", userID)
+	ret0, _ := ret[0].(*storage0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserStorageMockRecorder) GetUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserStorage)(nil).GetUser), userID)
+}
+
+// ListUsers mocks base method.
+func (m *MockuserStorage) ListUsers(filter storage0.ListUsersFilter) (*storage0.UsersPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", filter)
+	ret0, _ := ret[0].(*storage0.UsersPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockuserStorageMockRecorder) ListUsers(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockuserStorage)(nil).ListUsers), filter)
+}
+
+// SetUserActive mocks base method.
+func (m *MockuserStorage) SetUserActive(userID string, isActive bool) (*storage0.User, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActive", userID, isActive)
+	ret0, _ := ret[0].(*storage0.User)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockuserStorageMockRecorder) SetUserActive(userID, isActive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockuserStorage)(nil).SetUserActive), userID, isActive)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockuserStorage) UpdateUsername(userID, username string) (*storage0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", userID, username)
+	ret0, _ := ret[0].(*storage0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockuserStorageMockRecorder) UpdateUsername(userID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockuserStorage)(nil).UpdateUsername), userID, username)
+}
+
+// MockuserMover is a mock of userMover interface.
+type MockuserMover struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMoverMockRecorder
+	isgomock struct{}
+}
+
+// MockuserMoverMockRecorder is the mock recorder for MockuserMover.
+type MockuserMoverMockRecorder struct {
+	mock *MockuserMover
+}
+
+// NewMockuserMover creates a new mock instance.
+func NewMockuserMover(ctrl *gomock.Controller) *MockuserMover {
+	mock := &MockuserMover{ctrl: ctrl}
+	mock.recorder = &MockuserMoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserMover) EXPECT() *MockuserMoverMockRecorder {
+	return m.recorder
+}
+
+// ChangeTeam mocks base method.
+func (m *MockuserMover) ChangeTeam(ctx context.Context, opts users.TeamChangeOpts) (*users.TeamChangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeTeam", ctx, opts)
+	ret0, _ := ret[0].(*users.TeamChangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeTeam indicates an expected call of ChangeTeam.
+func (mr *MockuserMoverMockRecorder) ChangeTeam(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTeam", reflect.TypeOf((*MockuserMover)(nil).ChangeTeam), ctx, opts)
+}
+
+// DeleteUser mocks base method.
+func (m *MockuserMover) DeleteUser(ctx context.Context, opts users.DeleteUserOpts) (*users.DeleteUserResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, opts)
+	ret0, _ := ret[0].(*users.DeleteUserResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockuserMoverMockRecorder) DeleteUser(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockuserMover)(nil).DeleteUser), ctx, opts)
+}
+
+// MockteamStorage is a mock of teamStorage interface.
+type MockteamStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockteamStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockteamStorageMockRecorder is the mock recorder for MockteamStorage.
+type MockteamStorageMockRecorder struct {
+	mock *MockteamStorage
+}
+
+// NewMockteamStorage creates a new mock instance.
+func NewMockteamStorage(ctrl *gomock.Controller) *MockteamStorage {
+	mock := &MockteamStorage{ctrl: ctrl}
+	mock.recorder = &MockteamStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockteamStorage) EXPECT() *MockteamStorageMockRecorder {
+	return m.recorder
+}
+
+// GetTeam mocks base method.
+func (m *MockteamStorage) GetTeam(teamName string) (*storage.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", teamName)
+	ret0, _ := ret[0].(*storage.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockteamStorageMockRecorder) GetTeam(teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockteamStorage)(nil).GetTeam), teamName)
+}
+
+// ListTeams mocks base method.
+func (m *MockteamStorage) ListTeams(filter storage.ListTeamsFilter) (*storage.TeamsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", filter)
+	ret0, _ := ret[0].(*storage.TeamsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockteamStorageMockRecorder) ListTeams(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockteamStorage)(nil).ListTeams), filter)
+}
+
+// MockeventPublisher is a mock of eventPublisher interface.
+type MockeventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockeventPublisherMockRecorder is the mock recorder for MockeventPublisher.
+type MockeventPublisherMockRecorder struct {
+	mock *MockeventPublisher
+}
+
+// NewMockeventPublisher creates a new mock instance.
+func NewMockeventPublisher(ctrl *gomock.Controller) *MockeventPublisher {
+	mock := &MockeventPublisher{ctrl: ctrl}
+	mock.recorder = &MockeventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventPublisher) EXPECT() *MockeventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockeventPublisher) Publish(ev events.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ev)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockeventPublisherMockRecorder) Publish(ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockeventPublisher)(nil).Publish), ev)
+}
@@ -0,0 +1,332 @@
+//go:generate mockgen --source=usecase.go --destination=mocks/usecase.go -package=mocks
+
+package scim
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/qwerty268/pull_request_service/internal/events"
+	teamRepository "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
+	"github.com/qwerty268/pull_request_service/internal/usecases/users"
+	userRepository "github.com/qwerty268/pull_request_service/internal/usecases/users/storage"
+)
+
+var (
+	ErrAlreadyExists = errors.New("already exists")
+	ErrNotFound      = errors.New("not found")
+	// ErrConflict - операцию нельзя выполнить из-за связанных данных, например PR пользователя.
+	ErrConflict = errors.New("conflict")
+)
+
+type userStorage interface {
+	CreateUser(user userRepository.User) error
+	GetUser(userID string) (*userRepository.User, error)
+	ListUsers(filter userRepository.ListUsersFilter) (*userRepository.UsersPage, error)
+	SetUserActive(userID string, isActive bool) (*userRepository.User, bool, error)
+	UpdateUsername(userID, username string) (*userRepository.User, error)
+}
+
+// userMover - удаление пользователей и смена состава команд с передачей ревью, общие с /users/delete
+// и /users/moveTeam.
+type userMover interface {
+	DeleteUser(ctx context.Context, opts users.DeleteUserOpts) (*users.DeleteUserResult, error)
+	ChangeTeam(ctx context.Context, opts users.TeamChangeOpts) (*users.TeamChangeResult, error)
+}
+
+type teamStorage interface {
+	GetTeam(teamName string) (*teamRepository.Team, error)
+	ListTeams(filter teamRepository.ListTeamsFilter) (*teamRepository.TeamsPage, error)
+}
+
+// DefaultListLimit - размер страницы, если клиент не передал count.
+const DefaultListLimit = 100
+
+type eventPublisher interface {
+	Publish(ev events.Event)
+}
+
+// Usecase отображает ресурсы провижининга (пользователи и группы) на user, team и team_user_map.
+type Usecase struct {
+	userStorage userStorage
+	teamStorage teamStorage
+	users       userMover
+	events      eventPublisher
+}
+
+func NewUsecase(userStorage userStorage, teamStorage teamStorage, userUsecase userMover, publisher eventPublisher) Usecase {
+	return Usecase{
+		userStorage: userStorage,
+		teamStorage: teamStorage,
+		users:       userUsecase,
+		events:      publisher,
+	}
+}
+
+// CreateUser заводит пользователя без команды. Если IdP не передал идентификатор, он генерируется.
+func (u Usecase) CreateUser(_ context.Context, user User) (*User, error) {
+	if user.UserID == "" {
+		id, err := newUserID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate user id: %v", err)
+		}
+		user.UserID = id
+	}
+
+	err := u.userStorage.CreateUser(userRepository.User{
+		UserID:   user.UserID,
+		Username: user.Username,
+		IsActive: user.IsActive,
+	})
+	if err != nil {
+		if errors.Is(err, userRepository.ErrAlreadyExists) {
+			return nil, fmt.Errorf("failed to create user: %w", ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to create user: %v", err)
+	}
+
+	user.TeamName = ""
+	return &user, nil
+}
+
+func (u Usecase) GetUser(_ context.Context, userID string) (*User, error) {
+	storageUser, err := u.userStorage.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, userRepository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get user: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %v", err)
+	}
+
+	return fromStorageUser(storageUser), nil
+}
+
+func (u Usecase) ListUsers(_ context.Context, filter UserFilter) (*UsersPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+
+	storagePage, err := u.userStorage.ListUsers(userRepository.ListUsersFilter{
+		UserID:   filter.UserID,
+		Username: filter.Username,
+		IsActive: filter.IsActive,
+		Limit:    filter.Limit,
+		Offset:   filter.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %v", err)
+	}
+
+	page := &UsersPage{
+		Users: make([]User, len(storagePage.Users)),
+		Total: storagePage.Total,
+	}
+	for i, v := range storagePage.Users {
+		page.Users[i] = *fromStorageUser(&v)
+	}
+	return page, nil
+}
+
+// PatchUser применяет изменения пользователя. active=false означает деактивацию через SetUserActive.
+func (u Usecase) PatchUser(ctx context.Context, userID string, patch UserPatch) (*User, error) {
+	if patch.Username != nil {
+		_, err := u.userStorage.UpdateUsername(userID, *patch.Username)
+		if err != nil {
+			if errors.Is(err, userRepository.ErrNotFound) {
+				return nil, fmt.Errorf("failed to update username: %w", ErrNotFound)
+			}
+			if errors.Is(err, userRepository.ErrAlreadyExists) {
+				return nil, fmt.Errorf("failed to update username: %w", ErrAlreadyExists)
+			}
+			return nil, fmt.Errorf("failed to update username: %v", err)
+		}
+	}
+
+	if patch.IsActive != nil {
+		user, changed, err := u.userStorage.SetUserActive(userID, *patch.IsActive)
+		if err != nil {
+			if errors.Is(err, userRepository.ErrNotFound) {
+				return nil, fmt.Errorf("failed to set user active: %w", ErrNotFound)
+			}
+			return nil, fmt.Errorf("failed to set user active: %v", err)
+		}
+		if !*patch.IsActive && changed {
+			u.events.Publish(events.Event{
+				Type:     events.UserDeactivated,
+				TeamName: user.TeamName,
+				UserIDs:  []string{user.UserID},
+				Data:     events.User{UserID: user.UserID, Username: user.Username, TeamName: user.TeamName},
+			})
+		}
+	}
+
+	return u.GetUser(ctx, userID)
+}
+
+// DeleteUser удаляет пользователя так же, как /users/delete: открытые ревью передаются сокомандникам,
+// а автора открытых PR или ревьюера, которому нет замены, удалить нельзя.
+func (u Usecase) DeleteUser(ctx context.Context, userID string) error {
+	_, err := u.users.DeleteUser(ctx, users.DeleteUserOpts{UserID: userID, ReassignReviews: true})
+	if err != nil {
+		switch {
+		case errors.Is(err, users.ErrNotFound):
+			return fmt.Errorf("failed to delete user: %w", ErrNotFound)
+		case errors.Is(err, users.ErrHasOpenPRs),
+			errors.Is(err, users.ErrHasOpenReviews),
+			errors.Is(err, users.ErrHasHistory):
+			return fmt.Errorf("failed to delete user: %v: %w", err, ErrConflict)
+		}
+		return fmt.Errorf("failed to delete user: %v", err)
+	}
+	return nil
+}
+
+// CreateGroup создает команду. Участники должны уже существовать и переводятся из прежних команд
+// с передачей ревью, как в /users/moveTeam.
+func (u Usecase) CreateGroup(ctx context.Context, group Group) (*Group, error) {
+	err := u.changeTeam(ctx, "failed to create group", users.TeamChangeOpts{
+		TeamName: group.Name,
+		Create:   true,
+		Join:     group.Members,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.events.Publish(events.Event{
+		Type:     events.TeamCreated,
+		TeamName: group.Name,
+		UserIDs:  group.Members,
+		Data:     events.Team{TeamName: group.Name, Members: group.Members},
+	})
+
+	return u.GetGroup(ctx, group.Name)
+}
+
+func (u Usecase) GetGroup(_ context.Context, name string) (*Group, error) {
+	team, err := u.teamStorage.GetTeam(name)
+	if err != nil {
+		if errors.Is(err, teamRepository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get group: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get group: %v", err)
+	}
+
+	return fromStorageTeam(team), nil
+}
+
+// ListGroups выдает страницу команд с участниками. Фильтр по имени - точное совпадение.
+func (u Usecase) ListGroups(ctx context.Context, filter GroupFilter) (*GroupsPage, error) {
+	if filter.Name != "" {
+		group, err := u.GetGroup(ctx, filter.Name)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return &GroupsPage{Groups: make([]Group, 0)}, nil
+			}
+			return nil, err
+		}
+		if filter.Offset > 0 {
+			return &GroupsPage{Groups: make([]Group, 0), Total: 1}, nil
+		}
+		return &GroupsPage{Groups: []Group{*group}, Total: 1}, nil
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+
+	storagePage, err := u.teamStorage.ListTeams(teamRepository.ListTeamsFilter{
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %v", err)
+	}
+
+	page := &GroupsPage{
+		Groups: make([]Group, 0, len(storagePage.Teams)),
+		Total:  storagePage.Total,
+	}
+	for _, summary := range storagePage.Teams {
+		team, err := u.teamStorage.GetTeam(summary.TeamName)
+		if err != nil {
+			// Команду могли удалить между запросами.
+			if errors.Is(err, teamRepository.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get group: %v", err)
+		}
+		page.Groups = append(page.Groups, *fromStorageTeam(team))
+	}
+	return page, nil
+}
+
+// PatchGroup меняет состав команды одной транзакцией. SetMembers заменяет состав целиком и применяется до Add/Remove.
+// Ушедшие участники передают открытые ревью оставшимся.
+func (u Usecase) PatchGroup(ctx context.Context, name string, patch GroupPatch) (*Group, error) {
+	err := u.changeTeam(ctx, "failed to patch group", users.TeamChangeOpts{
+		TeamName: name,
+		Set:      patch.SetMembers,
+		Join:     patch.AddMembers,
+		Leave:    patch.RemoveMembers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetGroup(ctx, name)
+}
+
+// DeleteGroup удаляет команду. Ее участники остаются без команды, открытые ревью между ними не передаются.
+func (u Usecase) DeleteGroup(ctx context.Context, name string) error {
+	return u.changeTeam(ctx, "failed to delete group", users.TeamChangeOpts{
+		TeamName: name,
+		Delete:   true,
+	})
+}
+
+// changeTeam меняет состав команды через users, чтобы ревью передавались так же, как при /users/moveTeam.
+func (u Usecase) changeTeam(ctx context.Context, msg string, opts users.TeamChangeOpts) error {
+	opts.HandoverReviews = true
+	_, err := u.users.ChangeTeam(ctx, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, users.ErrTeamExists):
+			return fmt.Errorf("%s: %w", msg, ErrAlreadyExists)
+		case errors.Is(err, users.ErrNotFound), errors.Is(err, users.ErrTeamNotFound):
+			return fmt.Errorf("%s: %v: %w", msg, err, ErrNotFound)
+		}
+		return fmt.Errorf("%s: %v", msg, err)
+	}
+	return nil
+}
+
+func fromStorageUser(user *userRepository.User) *User {
+	return &User{
+		UserID:   user.UserID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+}
+
+func fromStorageTeam(team *teamRepository.Team) *Group {
+	group := &Group{
+		Name:    team.TeamName,
+		Members: make([]string, len(team.Members)),
+	}
+	for i, m := range team.Members {
+		group.Members[i] = m.UserID
+	}
+	return group
+}
+
+func newUserID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "u-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,277 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/events"
+	"github.com/qwerty268/pull_request_service/internal/usecases/scim/mocks"
+	teamRepository "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
+	"github.com/qwerty268/pull_request_service/internal/usecases/users"
+	userRepository "github.com/qwerty268/pull_request_service/internal/usecases/users/storage"
+)
+
+func TestUsecase_CreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	usecase := NewUsecase(userStorage, nil, nil, events.Discard)
+	ctx := context.Background()
+
+	t.Run("external id", func(t *testing.T) {
+		userStorage.EXPECT().
+			CreateUser(userRepository.User{UserID: "u1", Username: "alice", IsActive: true}).
+			Return(nil)
+		user, err := usecase.CreateUser(ctx, User{UserID: "u1", Username: "alice", IsActive: true})
+		require.NoError(t, err)
+		require.Equal(t, "u1", user.UserID)
+	})
+
+	t.Run("generated id", func(t *testing.T) {
+		userStorage.EXPECT().
+			CreateUser(gomock.Any()).
+			Return(nil)
+		user, err := usecase.CreateUser(ctx, User{Username: "bob"})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(user.UserID, "u-"))
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		userStorage.EXPECT().
+			CreateUser(gomock.Any()).
+			Return(userRepository.ErrAlreadyExists)
+		_, err := usecase.CreateUser(ctx, User{UserID: "u1", Username: "alice"})
+		require.ErrorIs(t, err, ErrAlreadyExists)
+	})
+}
+
+func TestUsecase_PatchUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	usecase := NewUsecase(userStorage, nil, nil, events.Discard)
+	ctx := context.Background()
+
+	t.Run("deactivate", func(t *testing.T) {
+		isActive := false
+		userStorage.EXPECT().
+			SetUserActive("u1", false).
+			Return(&userRepository.User{UserID: "u1"}, false, nil)
+		userStorage.EXPECT().
+			GetUser("u1").
+			Return(&userRepository.User{UserID: "u1", Username: "alice", TeamName: "backend"}, nil)
+
+		user, err := usecase.PatchUser(ctx, "u1", UserPatch{IsActive: &isActive})
+		require.NoError(t, err)
+		require.Equal(t, User{UserID: "u1", Username: "alice", TeamName: "backend"}, *user)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		username := "alice2"
+		userStorage.EXPECT().
+			UpdateUsername("u9", "alice2").
+			Return(nil, userRepository.ErrNotFound)
+
+		_, err := usecase.PatchUser(ctx, "u9", UserPatch{Username: &username})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUsecase_DeleteUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	remover := mocks.NewMockuserMover(ctrl)
+	usecase := NewUsecase(nil, nil, remover, events.Discard)
+	ctx := context.Background()
+
+	t.Run("reviews handed over", func(t *testing.T) {
+		remover.EXPECT().
+			DeleteUser(ctx, users.DeleteUserOpts{UserID: "u1", ReassignReviews: true}).
+			Return(&users.DeleteUserResult{UserID: "u1"}, nil)
+
+		require.NoError(t, usecase.DeleteUser(ctx, "u1"))
+	})
+
+	t.Run("open reviews without candidate", func(t *testing.T) {
+		remover.EXPECT().
+			DeleteUser(ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("no candidate for [pr1]: %w", users.ErrHasOpenReviews))
+
+		err := usecase.DeleteUser(ctx, "u1")
+		require.ErrorIs(t, err, ErrConflict)
+		require.Contains(t, err.Error(), "pr1")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		remover.EXPECT().
+			DeleteUser(ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("failed to find user: %w", users.ErrNotFound))
+
+		err := usecase.DeleteUser(ctx, "u9")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUsecase_CreateGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamStorage := mocks.NewMockteamStorage(ctrl)
+	mover := mocks.NewMockuserMover(ctrl)
+	usecase := NewUsecase(nil, teamStorage, mover, events.Discard)
+	ctx := context.Background()
+
+	t.Run("with members", func(t *testing.T) {
+		mover.EXPECT().
+			ChangeTeam(ctx, users.TeamChangeOpts{TeamName: "backend", Create: true, Join: []string{"u1"}, HandoverReviews: true}).
+			Return(&users.TeamChangeResult{}, nil)
+		teamStorage.EXPECT().
+			GetTeam("backend").
+			Return(&teamRepository.Team{TeamName: "backend", Members: []teamRepository.TeamMember{{UserID: "u1"}}}, nil)
+
+		group, err := usecase.CreateGroup(ctx, Group{Name: "backend", Members: []string{"u1"}})
+		require.NoError(t, err)
+		require.Equal(t, &Group{Name: "backend", Members: []string{"u1"}}, group)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		mover.EXPECT().
+			ChangeTeam(ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("failed to change team: %w", users.ErrTeamExists))
+
+		_, err := usecase.CreateGroup(ctx, Group{Name: "backend"})
+		require.ErrorIs(t, err, ErrAlreadyExists)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		mover.EXPECT().
+			ChangeTeam(ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("failed to change team: user u9: %w", users.ErrNotFound))
+
+		_, err := usecase.CreateGroup(ctx, Group{Name: "backend", Members: []string{"u9"}})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUsecase_PatchGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamStorage := mocks.NewMockteamStorage(ctrl)
+	mover := mocks.NewMockuserMover(ctrl)
+	usecase := NewUsecase(nil, teamStorage, mover, events.Discard)
+	ctx := context.Background()
+
+	team := &teamRepository.Team{
+		TeamName: "backend",
+		Members: []teamRepository.TeamMember{
+			{UserID: "u2"},
+			{UserID: "u3"},
+		},
+	}
+
+	t.Run("replace and remove in one change", func(t *testing.T) {
+		// Состав читается и меняется в одной транзакции users, здесь только передается патч.
+		members := []string{"u2", "u3"}
+		gomock.InOrder(
+			mover.EXPECT().
+				ChangeTeam(ctx, users.TeamChangeOpts{
+					TeamName:        "backend",
+					Set:             &members,
+					Join:            []string{"u4"},
+					Leave:           []string{"u4"},
+					HandoverReviews: true,
+				}).
+				Return(&users.TeamChangeResult{}, nil),
+			teamStorage.EXPECT().GetTeam("backend").Return(team, nil),
+		)
+
+		group, err := usecase.PatchGroup(ctx, "backend", GroupPatch{
+			SetMembers:    &members,
+			AddMembers:    []string{"u4"},
+			RemoveMembers: []string{"u4"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"u2", "u3"}, group.Members)
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		mover.EXPECT().
+			ChangeTeam(ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("failed to change team: %w", users.ErrTeamNotFound))
+
+		_, err := usecase.PatchGroup(ctx, "nope", GroupPatch{AddMembers: []string{"u1"}})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mover.EXPECT().
+			ChangeTeam(ctx, gomock.Any()).
+			Return(nil, errors.New("db down"))
+
+		_, err := usecase.PatchGroup(ctx, "backend", GroupPatch{RemoveMembers: []string{"u1"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "db down")
+	})
+}
+
+func TestUsecase_DeleteGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mover := mocks.NewMockuserMover(ctrl)
+	usecase := NewUsecase(nil, nil, mover, events.Discard)
+	ctx := context.Background()
+
+	t.Run("members leave through users", func(t *testing.T) {
+		mover.EXPECT().
+			ChangeTeam(ctx, users.TeamChangeOpts{TeamName: "backend", Delete: true, HandoverReviews: true}).
+			Return(&users.TeamChangeResult{}, nil)
+
+		require.NoError(t, usecase.DeleteGroup(ctx, "backend"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mover.EXPECT().
+			ChangeTeam(ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("failed to change team: %w", users.ErrTeamNotFound))
+
+		require.ErrorIs(t, usecase.DeleteGroup(ctx, "nope"), ErrNotFound)
+	})
+}
+
+func TestUsecase_ListGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamStorage := mocks.NewMockteamStorage(ctrl)
+	usecase := NewUsecase(nil, teamStorage, nil, events.Discard)
+	ctx := context.Background()
+
+	teamStorage.EXPECT().
+		ListTeams(teamRepository.ListTeamsFilter{Limit: DefaultListLimit}).
+		Return(&teamRepository.TeamsPage{
+			Teams: []teamRepository.TeamSummary{{TeamName: "backend"}, {TeamName: "gone"}},
+			Total: 2,
+		}, nil)
+	teamStorage.EXPECT().
+		GetTeam("backend").
+		Return(&teamRepository.Team{TeamName: "backend", Members: []teamRepository.TeamMember{{UserID: "u1"}}}, nil)
+	teamStorage.EXPECT().
+		GetTeam("gone").
+		Return(nil, teamRepository.ErrNotFound)
+
+	page, err := usecase.ListGroups(ctx, GroupFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []Group{{Name: "backend", Members: []string{"u1"}}}, page.Groups)
+	require.Equal(t, 2, page.Total)
+}
@@ -0,0 +1,100 @@
+package stats
+
+import "time"
+
+// Window - период статистики [From, To) и необязательный фильтр по команде.
+type Window struct {
+	From     time.Time
+	To       time.Time
+	TeamName string
+}
+
+type ReviewerStats struct {
+	UserID         string
+	Username       string
+	TeamName       string
+	Assigned       int
+	ReassignedAway int
+	Authored       int
+}
+
+type TeamStats struct {
+	TeamName       string
+	MembersCount   int
+	Assigned       int
+	ReassignedAway int
+	Authored       int
+}
+
+// ReviewerReport - статистика по пользователям с итоговыми границами окна.
+type ReviewerReport struct {
+	Window    Window
+	Reviewers []ReviewerStats
+}
+
+type TeamReport struct {
+	Window Window
+	Teams  []TeamStats
+}
+
+// Percentiles - p50, p90 и p99 длительности.
+type Percentiles struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+type TeamLatency struct {
+	TeamName    string
+	Merged      int
+	TimeToMerge Percentiles
+}
+
+type AuthorLatency struct {
+	UserID      string
+	Username    string
+	TeamName    string
+	Merged      int
+	TimeToMerge Percentiles
+}
+
+// LatencyReport - время до мержа PR, смерженных в окне.
+// Время до первого ревью появится, когда будут храниться вердикты ревьюеров.
+type LatencyReport struct {
+	Window  Window
+	Teams   []TeamLatency
+	Authors []AuthorLatency
+}
+
+type TeamActivity struct {
+	TeamName    string
+	OpenPRs     int
+	ActiveUsers int
+}
+
+// MemberFairness - нагрузка участника относительно справедливой доли.
+type MemberFairness struct {
+	UserID   string
+	Username string
+	Assigned int
+	// ActiveShare - доля окна, в течение которой участник был активен, от 0 до 1.
+	ActiveShare float64
+	// IdealShare - справедливая доля назначений с поправкой на время активности.
+	IdealShare  float64
+	ActualShare float64
+	// Expected - число назначений, которое пришлось бы на участника при идеальном распределении.
+	Expected float64
+}
+
+// FairnessReport - распределение назначений в команде за окно.
+type FairnessReport struct {
+	Window Window
+	// TotalAssigned - назначения участников, активных в окне.
+	TotalAssigned int
+	// Gini - коэффициент Джини по назначениям на единицу времени активности: 0 - поровну, 1 - все одному.
+	Gini    float64
+	Members []MemberFairness
+	// MostOverloaded и MostUnderused - участники с наибольшим избытком и недобором назначений, nil если таких нет.
+	MostOverloaded *MemberFairness
+	MostUnderused  *MemberFairness
+}
@@ -0,0 +1,120 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	repository "github.com/qwerty268/pull_request_service/internal/usecases/stats/storage"
+)
+
+// GetFairness считает, насколько равномерно распределены назначения на ревью в команде за окно.
+// Справедливая доля участника пропорциональна времени его активности в окне,
+// поэтому недавно включенные пользователи не попадают в недогруженные.
+func (u Usecase) GetFairness(_ context.Context, window Window) (*FairnessReport, error) {
+	window, err := normalizeWindow(window)
+	if err != nil {
+		return nil, err
+	}
+
+	load, err := u.storage.GetTeamMemberLoad(repository.Window(window))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("team %s: %w", window.TeamName, ErrTeamNotFound)
+		}
+		return nil, fmt.Errorf("failed to get member load: %v", err)
+	}
+
+	report := computeFairness(load, window.To.Sub(window.From).Seconds())
+	report.Window = window
+	return report, nil
+}
+
+func computeFairness(load []repository.MemberLoad, windowSeconds float64) *FairnessReport {
+	report := &FairnessReport{
+		Members: make([]MemberFairness, len(load)),
+	}
+
+	var totalWeight float64
+	for i, v := range load {
+		report.Members[i] = MemberFairness{
+			UserID:      v.UserID,
+			Username:    v.Username,
+			Assigned:    v.Assigned,
+			ActiveShare: math.Min(1, v.ActiveSeconds/windowSeconds),
+		}
+		// Назначения неактивных в окне участников не входят в общий счет, как и в weightedGini,
+		// иначе доли активных перестают складываться в единицу.
+		if report.Members[i].ActiveShare == 0 {
+			continue
+		}
+		report.TotalAssigned += v.Assigned
+		totalWeight += report.Members[i].ActiveShare
+	}
+
+	var overloaded, underused *MemberFairness
+	for i := range report.Members {
+		m := &report.Members[i]
+		if totalWeight == 0 || m.ActiveShare == 0 {
+			continue
+		}
+		if report.TotalAssigned > 0 {
+			m.ActualShare = float64(m.Assigned) / float64(report.TotalAssigned)
+		}
+		m.IdealShare = m.ActiveShare / totalWeight
+		m.Expected = m.IdealShare * float64(report.TotalAssigned)
+
+		surplus := float64(m.Assigned) - m.Expected
+		if surplus > 0 && (overloaded == nil || surplus > float64(overloaded.Assigned)-overloaded.Expected) {
+			overloaded = m
+		}
+		if surplus < 0 && (underused == nil || -surplus > underused.Expected-float64(underused.Assigned)) {
+			underused = m
+		}
+	}
+
+	if overloaded != nil {
+		c := *overloaded
+		report.MostOverloaded = &c
+	}
+	if underused != nil {
+		c := *underused
+		report.MostUnderused = &c
+	}
+	report.Gini = weightedGini(report.Members)
+	return report
+}
+
+// weightedGini считает коэффициент Джини по назначениям на единицу времени активности,
+// взвешивая участников долей активности. Неактивные в окне участники не учитываются.
+func weightedGini(members []MemberFairness) float64 {
+	var totalWeight, totalAssigned float64
+	for _, m := range members {
+		if m.ActiveShare == 0 {
+			continue
+		}
+		totalWeight += m.ActiveShare
+		totalAssigned += float64(m.Assigned)
+	}
+	if totalWeight == 0 || totalAssigned == 0 {
+		return 0
+	}
+	mean := totalAssigned / totalWeight
+
+	var diff float64
+	for _, a := range members {
+		if a.ActiveShare == 0 {
+			continue
+		}
+		for _, b := range members {
+			if b.ActiveShare == 0 {
+				continue
+			}
+			rateA := float64(a.Assigned) / a.ActiveShare
+			rateB := float64(b.Assigned) / b.ActiveShare
+			diff += a.ActiveShare * b.ActiveShare * math.Abs(rateA-rateB)
+		}
+	}
+	return diff / (2 * totalWeight * totalWeight * mean)
+}
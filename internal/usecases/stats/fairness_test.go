@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/usecases/stats/mocks"
+	repository "github.com/qwerty268/pull_request_service/internal/usecases/stats/storage"
+)
+
+const day = float64(24 * 60 * 60)
+
+func TestComputeFairness(t *testing.T) {
+	t.Run("skewed", func(t *testing.T) {
+		report := computeFairness([]repository.MemberLoad{
+			{UserID: "u1", Assigned: 9, ActiveSeconds: 10 * day},
+			{UserID: "u2", Assigned: 1, ActiveSeconds: 10 * day},
+			{UserID: "u3", Assigned: 2, ActiveSeconds: 10 * day},
+		}, 10*day)
+
+		require.Equal(t, 12, report.TotalAssigned)
+		require.InDelta(t, 4.0/9, report.Gini, 1e-9)
+		require.InDelta(t, 1.0/3, report.Members[0].IdealShare, 1e-9)
+		require.InDelta(t, 0.75, report.Members[0].ActualShare, 1e-9)
+		require.Equal(t, "u1", report.MostOverloaded.UserID)
+		require.Equal(t, "u2", report.MostUnderused.UserID)
+	})
+
+	t.Run("newly activated user is not flagged", func(t *testing.T) {
+		report := computeFairness([]repository.MemberLoad{
+			{UserID: "u1", Assigned: 10, ActiveSeconds: 10 * day},
+			{UserID: "u2", Assigned: 10, ActiveSeconds: 10 * day},
+			{UserID: "u3", Assigned: 1, ActiveSeconds: day},
+		}, 10*day)
+
+		require.InDelta(t, 0.1, report.Members[2].ActiveShare, 1e-9)
+		require.InDelta(t, 1.0, report.Members[2].Expected, 1e-9)
+		require.InDelta(t, 0, report.Gini, 1e-9)
+		require.Nil(t, report.MostOverloaded)
+		require.Nil(t, report.MostUnderused)
+	})
+
+	t.Run("inactive member is ignored", func(t *testing.T) {
+		report := computeFairness([]repository.MemberLoad{
+			{UserID: "u1", Assigned: 3, ActiveSeconds: 10 * day},
+			{UserID: "u2", Assigned: 3, ActiveSeconds: 10 * day},
+			{UserID: "u3", Assigned: 0, ActiveSeconds: 0},
+		}, 10*day)
+
+		require.Equal(t, MemberFairness{UserID: "u3"}, report.Members[2])
+		require.InDelta(t, 0, report.Gini, 1e-9)
+		require.Nil(t, report.MostUnderused)
+	})
+
+	t.Run("assignments of inactive member are not counted", func(t *testing.T) {
+		report := computeFairness([]repository.MemberLoad{
+			{UserID: "u1", Assigned: 3, ActiveSeconds: 10 * day},
+			{UserID: "u2", Assigned: 1, ActiveSeconds: 10 * day},
+			{UserID: "u3", Assigned: 4, ActiveSeconds: 0},
+		}, 10*day)
+
+		require.Equal(t, 4, report.TotalAssigned)
+		require.InDelta(t, 0.75, report.Members[0].ActualShare, 1e-9)
+		require.InDelta(t, 2.0, report.Members[0].Expected, 1e-9)
+		require.Equal(t, MemberFairness{UserID: "u3", Assigned: 4}, report.Members[2])
+		require.Equal(t, "u1", report.MostOverloaded.UserID)
+		require.Equal(t, "u2", report.MostUnderused.UserID)
+	})
+
+	t.Run("no assignments", func(t *testing.T) {
+		report := computeFairness([]repository.MemberLoad{
+			{UserID: "u1", ActiveSeconds: 10 * day},
+		}, 10*day)
+
+		require.Equal(t, 0, report.TotalAssigned)
+		require.Zero(t, report.Gini)
+		require.Nil(t, report.MostOverloaded)
+		require.Nil(t, report.MostUnderused)
+	})
+}
+
+func TestUsecase_GetFairness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(storage)
+	ctx := context.Background()
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)
+	window := Window{From: from, To: to, TeamName: "backend"}
+
+	t.Run("success", func(t *testing.T) {
+		storage.EXPECT().
+			GetTeamMemberLoad(repository.Window(window)).
+			Return([]repository.MemberLoad{
+				{UserID: "u1", Username: "Alice", Assigned: 2, ActiveSeconds: 10 * day},
+				{UserID: "u2", Username: "Bob", Assigned: 2, ActiveSeconds: 10 * day},
+			}, nil)
+
+		report, err := usecase.GetFairness(ctx, window)
+		require.NoError(t, err)
+		require.Equal(t, window, report.Window)
+		require.Len(t, report.Members, 2)
+		require.InDelta(t, 0.5, report.Members[1].IdealShare, 1e-9)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		storage.EXPECT().
+			GetTeamMemberLoad(repository.Window(window)).
+			Return(nil, repository.ErrNotFound)
+
+		_, err := usecase.GetFairness(ctx, window)
+		require.ErrorIs(t, err, ErrTeamNotFound)
+	})
+}
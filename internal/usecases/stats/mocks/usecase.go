@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+//
+// Generated by this command:
+//
+//	mockgen --source=usecase.go --destination=mocks/usecase.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	storage "github.com/qwerty268/pull_request_service/internal/usecases/stats/storage"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+	isgomock struct{}
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// GetAuthorMergeLatency mocks base method.
+func (m *Mockstorage) GetAuthorMergeLatency(window storage.Window) ([]storage.AuthorLatency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorMergeLatency", window)
+	ret0, _ := ret[0].([]storage.AuthorLatency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorMergeLatency indicates an expected call of GetAuthorMergeLatency.
+func (mr *MockstorageMockRecorder) GetAuthorMergeLatency(window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorMergeLatency", reflect.TypeOf((*Mockstorage)(nil).GetAuthorMergeLatency), window)
+}
+
+// GetReviewerStats mocks base method.
+func (m *Mockstorage) GetReviewerStats(window storage.Window) ([]storage.ReviewerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewerStats", window)
+	ret0, _ := ret[0].([]storage.ReviewerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewerStats indicates an expected call of GetReviewerStats.
+func (mr *MockstorageMockRecorder) GetReviewerStats(window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewerStats", reflect.TypeOf((*Mockstorage)(nil).GetReviewerStats), window)
+}
+
+// GetTeamActivity mocks base method.
+func (m *Mockstorage) GetTeamActivity() ([]storage.TeamActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamActivity")
+	ret0, _ := ret[0].([]storage.TeamActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamActivity indicates an expected call of GetTeamActivity.
+func (mr *MockstorageMockRecorder) GetTeamActivity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamActivity", reflect.TypeOf((*Mockstorage)(nil).GetTeamActivity))
+}
+
+// GetTeamMemberLoad mocks base method.
+func (m *Mockstorage) GetTeamMemberLoad(window storage.Window) ([]storage.MemberLoad, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMemberLoad", window)
+	ret0, _ := ret[0].([]storage.MemberLoad)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMemberLoad indicates an expected call of GetTeamMemberLoad.
+func (mr *MockstorageMockRecorder) GetTeamMemberLoad(window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMemberLoad", reflect.TypeOf((*Mockstorage)(nil).GetTeamMemberLoad), window)
+}
+
+// GetTeamMergeLatency mocks base method.
+func (m *Mockstorage) GetTeamMergeLatency(window storage.Window) ([]storage.TeamLatency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMergeLatency", window)
+	ret0, _ := ret[0].([]storage.TeamLatency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMergeLatency indicates an expected call of GetTeamMergeLatency.
+func (mr *MockstorageMockRecorder) GetTeamMergeLatency(window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMergeLatency", reflect.TypeOf((*Mockstorage)(nil).GetTeamMergeLatency), window)
+}
+
+// GetTeamStats mocks base method.
+func (m *Mockstorage) GetTeamStats(window storage.Window) ([]storage.TeamStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamStats", window)
+	ret0, _ := ret[0].([]storage.TeamStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamStats indicates an expected call of GetTeamStats.
+func (mr *MockstorageMockRecorder) GetTeamStats(window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamStats", reflect.TypeOf((*Mockstorage)(nil).GetTeamStats), window)
+}
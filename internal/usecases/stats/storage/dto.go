@@ -0,0 +1,65 @@
+package storage
+
+import "time"
+
+// Window - полуинтервал [From, To). TeamName пустой - все команды.
+type Window struct {
+	From     time.Time
+	To       time.Time
+	TeamName string
+}
+
+type ReviewerStats struct {
+	UserID   string
+	Username string
+	TeamName string
+	// Assigned - сколько раз пользователя назначили ревьюером в окне.
+	Assigned int
+	// ReassignedAway - сколько раз с него сняли ревью: переназначение или передача при смене команды.
+	ReassignedAway int
+	// Authored - сколько PR пользователь создал в окне.
+	Authored int
+}
+
+type TeamStats struct {
+	TeamName       string
+	MembersCount   int
+	Assigned       int
+	ReassignedAway int
+	Authored       int
+}
+
+// Latency - перцентили длительности в секундах по Count PR.
+type Latency struct {
+	Count int
+	P50   float64
+	P90   float64
+	P99   float64
+}
+
+type TeamLatency struct {
+	TeamName string
+	Latency
+}
+
+type AuthorLatency struct {
+	UserID   string
+	Username string
+	TeamName string
+	Latency
+}
+
+// TeamActivity - текущее число открытых PR (по команде автора) и активных участников.
+type TeamActivity struct {
+	TeamName    string
+	OpenPRs     int
+	ActiveUsers int
+}
+
+// MemberLoad - назначения участника за окно и сколько секунд окна он был активен.
+type MemberLoad struct {
+	UserID        string
+	Username      string
+	Assigned      int
+	ActiveSeconds float64
+}
@@ -0,0 +1,346 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/qwerty268/pull_request_service/internal/metrics"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type Storage struct {
+	db    *sqlx.DB
+	close func() error
+}
+
+func NewStorage(db *sqlx.DB) *Storage {
+	return &Storage{
+		db: db,
+		close: func() error {
+			return fmt.Errorf("close: %v", db.Close())
+		},
+	}
+}
+
+// userCounters - счетчики по пользователям за окно [$1, $2).
+// Здесь и ниже команда пользователя берется из team_user_map, как в teams и graph.
+const userCounters = `
+	assigned AS (
+		SELECT user_id, COUNT(*) AS cnt
+		FROM review_assignment
+		WHERE assigned_at >= $1 AND assigned_at < $2
+		GROUP BY user_id
+	),
+	reassigned AS (
+		SELECT user_id, COUNT(*) AS cnt
+		FROM review_assignment
+		WHERE unassigned_at >= $1 AND unassigned_at < $2
+		AND reason IN ('reassigned', 'handover')
+		GROUP BY user_id
+	),
+	authored AS (
+		SELECT author_id AS user_id, COUNT(*) AS cnt
+		FROM pull_request
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY author_id
+	)
+`
+
+// GetReviewerStats считает назначения, снятия и авторские PR каждого пользователя за окно.
+func (s *Storage) GetReviewerStats(window Window) ([]ReviewerStats, error) {
+	defer metrics.ObserveQuery("stats", "GetReviewerStats", time.Now())
+
+	query := `
+		WITH ` + userCounters + `
+		SELECT
+			u.user_id,
+			u.username,
+			COALESCE(tum.team_name, ''),
+			COALESCE(a.cnt, 0),
+			COALESCE(r.cnt, 0),
+			COALESCE(p.cnt, 0)
+		FROM "user" AS u
+		LEFT JOIN team_user_map AS tum ON tum.user_id = u.user_id
+		LEFT JOIN assigned AS a ON a.user_id = u.user_id
+		LEFT JOIN reassigned AS r ON r.user_id = u.user_id
+		LEFT JOIN authored AS p ON p.user_id = u.user_id
+		WHERE $3 = '' OR tum.team_name = $3
+		ORDER BY u.user_id
+	`
+
+	rows, err := s.db.Query(query, window.From, window.To, window.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("GetReviewerStats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]ReviewerStats, 0)
+	for rows.Next() {
+		var st ReviewerStats
+		err := rows.Scan(&st.UserID, &st.Username, &st.TeamName, &st.Assigned, &st.ReassignedAway, &st.Authored)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		stats = append(stats, st)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return stats, nil
+}
+
+// GetTeamStats считает те же счетчики, сложенные по текущим участникам команды.
+func (s *Storage) GetTeamStats(window Window) ([]TeamStats, error) {
+	defer metrics.ObserveQuery("stats", "GetTeamStats", time.Now())
+
+	query := `
+		WITH ` + userCounters + `
+		SELECT
+			t.team_name,
+			COUNT(tum.user_id),
+			COALESCE(SUM(a.cnt), 0),
+			COALESCE(SUM(r.cnt), 0),
+			COALESCE(SUM(p.cnt), 0)
+		FROM team AS t
+		LEFT JOIN team_user_map AS tum ON tum.team_name = t.team_name
+		LEFT JOIN assigned AS a ON a.user_id = tum.user_id
+		LEFT JOIN reassigned AS r ON r.user_id = tum.user_id
+		LEFT JOIN authored AS p ON p.user_id = tum.user_id
+		WHERE $3 = '' OR t.team_name = $3
+		GROUP BY t.team_name
+		ORDER BY t.team_name
+	`
+
+	rows, err := s.db.Query(query, window.From, window.To, window.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("GetTeamStats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]TeamStats, 0)
+	for rows.Next() {
+		var st TeamStats
+		err := rows.Scan(&st.TeamName, &st.MembersCount, &st.Assigned, &st.ReassignedAway, &st.Authored)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		stats = append(stats, st)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return stats, nil
+}
+
+// mergedPRs - PR, смерженные в окне [$1, $2), с временем до мержа в секундах.
+// Команда PR - текущая команда автора.
+const mergedPRs = `
+	merged AS (
+		SELECT
+			pr.author_id,
+			u.username,
+			COALESCE(tum.team_name, '') AS team_name,
+			EXTRACT(EPOCH FROM pr.merged_at - pr.created_at) AS seconds
+		FROM pull_request AS pr
+		JOIN "user" AS u ON u.user_id = pr.author_id
+		LEFT JOIN team_user_map AS tum ON tum.user_id = pr.author_id
+		WHERE pr.is_merged
+		AND pr.merged_at >= $1 AND pr.merged_at < $2
+		AND ($3 = '' OR tum.team_name = $3)
+	)
+`
+
+const latencyPercentiles = `
+	COUNT(*),
+	percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds),
+	percentile_cont(0.9) WITHIN GROUP (ORDER BY seconds),
+	percentile_cont(0.99) WITHIN GROUP (ORDER BY seconds)
+`
+
+// GetTeamMergeLatency считает перцентили времени до мержа по командам.
+func (s *Storage) GetTeamMergeLatency(window Window) ([]TeamLatency, error) {
+	defer metrics.ObserveQuery("stats", "GetTeamMergeLatency", time.Now())
+
+	query := `
+		WITH ` + mergedPRs + `
+		SELECT team_name, ` + latencyPercentiles + `
+		FROM merged
+		GROUP BY team_name
+		ORDER BY team_name
+	`
+
+	rows, err := s.db.Query(query, window.From, window.To, window.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("GetTeamMergeLatency: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]TeamLatency, 0)
+	for rows.Next() {
+		var st TeamLatency
+		err := rows.Scan(&st.TeamName, &st.Count, &st.P50, &st.P90, &st.P99)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		stats = append(stats, st)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return stats, nil
+}
+
+// GetAuthorMergeLatency считает перцентили времени до мержа по авторам.
+func (s *Storage) GetAuthorMergeLatency(window Window) ([]AuthorLatency, error) {
+	defer metrics.ObserveQuery("stats", "GetAuthorMergeLatency", time.Now())
+
+	query := `
+		WITH ` + mergedPRs + `
+		SELECT author_id, username, team_name, ` + latencyPercentiles + `
+		FROM merged
+		GROUP BY author_id, username, team_name
+		ORDER BY author_id
+	`
+
+	rows, err := s.db.Query(query, window.From, window.To, window.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("GetAuthorMergeLatency: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]AuthorLatency, 0)
+	for rows.Next() {
+		var st AuthorLatency
+		err := rows.Scan(&st.UserID, &st.Username, &st.TeamName, &st.Count, &st.P50, &st.P90, &st.P99)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		stats = append(stats, st)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return stats, nil
+}
+
+// GetTeamActivity считает открытые PR и активных пользователей каждой команды на текущий момент.
+func (s *Storage) GetTeamActivity() ([]TeamActivity, error) {
+	defer metrics.ObserveQuery("stats", "GetTeamActivity", time.Now())
+
+	query := `
+		SELECT
+			t.team_name,
+			(
+				SELECT COUNT(*)
+				FROM pull_request AS pr
+				JOIN team_user_map AS tum ON tum.user_id = pr.author_id
+				WHERE tum.team_name = t.team_name AND NOT pr.is_merged
+			),
+			(
+				SELECT COUNT(*)
+				FROM team_user_map AS tum
+				JOIN "user" AS u ON u.user_id = tum.user_id
+				WHERE tum.team_name = t.team_name AND u.is_active
+			)
+		FROM team AS t
+		ORDER BY t.team_name
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("GetTeamActivity: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]TeamActivity, 0)
+	for rows.Next() {
+		var st TeamActivity
+		if err := rows.Scan(&st.TeamName, &st.OpenPRs, &st.ActiveUsers); err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		stats = append(stats, st)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return stats, nil
+}
+
+// GetTeamMemberLoad выдает назначения на ревью и время активности текущих участников команды за окно.
+// Время активности берется из журнала user_activity: интервалы, начатые включением, обрезаются границами окна.
+func (s *Storage) GetTeamMemberLoad(window Window) ([]MemberLoad, error) {
+	defer metrics.ObserveQuery("stats", "GetTeamMemberLoad", time.Now())
+
+	var exists bool
+	err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM team WHERE team_name = $1)`, window.TeamName).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("check team: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	query := `
+		WITH members AS (
+			SELECT u.user_id, u.username
+			FROM team_user_map AS tum
+			JOIN "user" AS u ON u.user_id = tum.user_id
+			WHERE tum.team_name = $3
+		),
+		changes AS (
+			SELECT
+				ua.user_id,
+				ua.is_active,
+				ua.changed_at,
+				LEAD(ua.changed_at) OVER (PARTITION BY ua.user_id ORDER BY ua.changed_at, ua.id) AS next_at
+			FROM user_activity AS ua
+			JOIN members AS m ON m.user_id = ua.user_id
+		),
+		active AS (
+			SELECT
+				user_id,
+				SUM(EXTRACT(EPOCH FROM LEAST(COALESCE(next_at, $2), $2) - GREATEST(changed_at, $1))) AS seconds
+			FROM changes
+			WHERE is_active AND changed_at < $2 AND COALESCE(next_at, $2) > $1
+			GROUP BY user_id
+		),
+		assigned AS (
+			SELECT user_id, COUNT(*) AS cnt
+			FROM review_assignment
+			WHERE assigned_at >= $1 AND assigned_at < $2
+			GROUP BY user_id
+		)
+		SELECT
+			m.user_id,
+			m.username,
+			COALESCE(a.cnt, 0),
+			COALESCE(act.seconds, 0)
+		FROM members AS m
+		LEFT JOIN assigned AS a ON a.user_id = m.user_id
+		LEFT JOIN active AS act ON act.user_id = m.user_id
+		ORDER BY m.user_id
+	`
+
+	rows, err := s.db.Query(query, window.From, window.To, window.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("GetTeamMemberLoad: %w", err)
+	}
+	defer rows.Close()
+
+	load := make([]MemberLoad, 0)
+	for rows.Next() {
+		var m MemberLoad
+		if err := rows.Scan(&m.UserID, &m.Username, &m.Assigned, &m.ActiveSeconds); err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		load = append(load, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return load, nil
+}
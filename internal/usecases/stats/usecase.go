@@ -0,0 +1,167 @@
+//go:generate mockgen --source=usecase.go --destination=mocks/usecase.go -package=mocks
+
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	repository "github.com/qwerty268/pull_request_service/internal/usecases/stats/storage"
+)
+
+var (
+	ErrInvalidWindow = errors.New("invalid window")
+	ErrTeamNotFound  = errors.New("team not found")
+)
+
+// DefaultWindow - период статистики, если начало не задано.
+const DefaultWindow = 30 * 24 * time.Hour
+
+type storage interface {
+	GetReviewerStats(window repository.Window) ([]repository.ReviewerStats, error)
+	GetTeamStats(window repository.Window) ([]repository.TeamStats, error)
+	GetTeamMergeLatency(window repository.Window) ([]repository.TeamLatency, error)
+	GetAuthorMergeLatency(window repository.Window) ([]repository.AuthorLatency, error)
+	GetTeamActivity() ([]repository.TeamActivity, error)
+	GetTeamMemberLoad(window repository.Window) ([]repository.MemberLoad, error)
+}
+
+type Usecase struct {
+	storage storage
+}
+
+func NewUsecase(storage storage) Usecase {
+	return Usecase{
+		storage: storage,
+	}
+}
+
+// GetReviewerStats считает статистику по каждому пользователю за окно.
+func (u Usecase) GetReviewerStats(_ context.Context, window Window) (*ReviewerReport, error) {
+	window, err := normalizeWindow(window)
+	if err != nil {
+		return nil, err
+	}
+
+	storageStats, err := u.storage.GetReviewerStats(repository.Window(window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer stats: %v", err)
+	}
+
+	report := &ReviewerReport{
+		Window:    window,
+		Reviewers: make([]ReviewerStats, len(storageStats)),
+	}
+	for i, v := range storageStats {
+		report.Reviewers[i] = ReviewerStats(v)
+	}
+	return report, nil
+}
+
+// GetTeamStats считает статистику по каждой команде за окно.
+func (u Usecase) GetTeamStats(_ context.Context, window Window) (*TeamReport, error) {
+	window, err := normalizeWindow(window)
+	if err != nil {
+		return nil, err
+	}
+
+	storageStats, err := u.storage.GetTeamStats(repository.Window(window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team stats: %v", err)
+	}
+
+	report := &TeamReport{
+		Window: window,
+		Teams:  make([]TeamStats, len(storageStats)),
+	}
+	for i, v := range storageStats {
+		report.Teams[i] = TeamStats(v)
+	}
+	return report, nil
+}
+
+// GetMergeLatency считает перцентили времени до мержа по командам и авторам за окно.
+func (u Usecase) GetMergeLatency(_ context.Context, window Window) (*LatencyReport, error) {
+	window, err := normalizeWindow(window)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := u.storage.GetTeamMergeLatency(repository.Window(window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team latency: %v", err)
+	}
+
+	authors, err := u.storage.GetAuthorMergeLatency(repository.Window(window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author latency: %v", err)
+	}
+
+	report := &LatencyReport{
+		Window:  window,
+		Teams:   make([]TeamLatency, len(teams)),
+		Authors: make([]AuthorLatency, len(authors)),
+	}
+	for i, v := range teams {
+		report.Teams[i] = TeamLatency{
+			TeamName:    v.TeamName,
+			Merged:      v.Count,
+			TimeToMerge: fromStorageLatency(v.Latency),
+		}
+	}
+	for i, v := range authors {
+		report.Authors[i] = AuthorLatency{
+			UserID:      v.UserID,
+			Username:    v.Username,
+			TeamName:    v.TeamName,
+			Merged:      v.Count,
+			TimeToMerge: fromStorageLatency(v.Latency),
+		}
+	}
+	return report, nil
+}
+
+// GetTeamActivity выдает текущее число открытых PR и активных пользователей по командам.
+func (u Usecase) GetTeamActivity(_ context.Context) ([]TeamActivity, error) {
+	storageActivity, err := u.storage.GetTeamActivity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team activity: %v", err)
+	}
+
+	activity := make([]TeamActivity, len(storageActivity))
+	for i, v := range storageActivity {
+		activity[i] = TeamActivity(v)
+	}
+	return activity, nil
+}
+
+func fromStorageLatency(l repository.Latency) Percentiles {
+	return Percentiles{
+		P50: secondsToDuration(l.P50),
+		P90: secondsToDuration(l.P90),
+		P99: secondsToDuration(l.P99),
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
+
+var Now = time.Now // Чтобы тестить.
+
+// normalizeWindow подставляет границы по умолчанию: конец - сейчас, начало - DefaultWindow до конца.
+func normalizeWindow(window Window) (Window, error) {
+	if window.To.IsZero() {
+		window.To = Now()
+	}
+	if window.From.IsZero() {
+		window.From = window.To.Add(-DefaultWindow)
+	}
+	if !window.From.Before(window.To) {
+		return window, fmt.Errorf("from %s is not before to %s: %w",
+			window.From.Format(time.RFC3339), window.To.Format(time.RFC3339), ErrInvalidWindow)
+	}
+	return window, nil
+}
@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/usecases/stats/mocks"
+	repository "github.com/qwerty268/pull_request_service/internal/usecases/stats/storage"
+)
+
+func TestUsecase_GetReviewerStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(storage)
+	ctx := context.Background()
+
+	now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	orig := Now
+	Now = func() time.Time { return now }
+	defer func() { Now = orig }()
+
+	t.Run("default window", func(t *testing.T) {
+		storage.EXPECT().
+			GetReviewerStats(repository.Window{From: now.Add(-DefaultWindow), To: now, TeamName: "backend"}).
+			Return([]repository.ReviewerStats{
+				{UserID: "u1", Username: "Alice", TeamName: "backend", Assigned: 5, ReassignedAway: 1, Authored: 2},
+			}, nil)
+
+		report, err := usecase.GetReviewerStats(ctx, Window{TeamName: "backend"})
+		require.NoError(t, err)
+		require.Equal(t, now, report.Window.To)
+		require.Equal(t, []ReviewerStats{
+			{UserID: "u1", Username: "Alice", TeamName: "backend", Assigned: 5, ReassignedAway: 1, Authored: 2},
+		}, report.Reviewers)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		_, err := usecase.GetReviewerStats(ctx, Window{From: now, To: now.Add(-time.Hour)})
+		require.ErrorIs(t, err, ErrInvalidWindow)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		storage.EXPECT().
+			GetReviewerStats(gomock.Any()).
+			Return(nil, errors.New("db down"))
+
+		_, err := usecase.GetReviewerStats(ctx, Window{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "db down")
+	})
+}
+
+func TestUsecase_GetTeamStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(storage)
+	ctx := context.Background()
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	storage.EXPECT().
+		GetTeamStats(repository.Window{From: from, To: to}).
+		Return([]repository.TeamStats{
+			{TeamName: "backend", MembersCount: 3, Assigned: 10, ReassignedAway: 2, Authored: 5},
+		}, nil)
+
+	report, err := usecase.GetTeamStats(ctx, Window{From: from, To: to})
+	require.NoError(t, err)
+	require.Equal(t, &TeamReport{
+		Window: Window{From: from, To: to},
+		Teams:  []TeamStats{{TeamName: "backend", MembersCount: 3, Assigned: 10, ReassignedAway: 2, Authored: 5}},
+	}, report)
+}
+
+func TestUsecase_GetMergeLatency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(storage)
+	ctx := context.Background()
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	window := repository.Window{From: from, To: to, TeamName: "backend"}
+
+	t.Run("success", func(t *testing.T) {
+		storage.EXPECT().
+			GetTeamMergeLatency(window).
+			Return([]repository.TeamLatency{
+				{TeamName: "backend", Latency: repository.Latency{Count: 4, P50: 3600, P90: 7200.4, P99: 86400}},
+			}, nil)
+		storage.EXPECT().
+			GetAuthorMergeLatency(window).
+			Return([]repository.AuthorLatency{
+				{UserID: "u1", Username: "Alice", TeamName: "backend", Latency: repository.Latency{Count: 4, P50: 3600, P90: 7200.4, P99: 86400}},
+			}, nil)
+
+		report, err := usecase.GetMergeLatency(ctx, Window(window))
+		require.NoError(t, err)
+
+		percentiles := Percentiles{P50: time.Hour, P90: 2 * time.Hour, P99: 24 * time.Hour}
+		require.Equal(t, &LatencyReport{
+			Window:  Window(window),
+			Teams:   []TeamLatency{{TeamName: "backend", Merged: 4, TimeToMerge: percentiles}},
+			Authors: []AuthorLatency{{UserID: "u1", Username: "Alice", TeamName: "backend", Merged: 4, TimeToMerge: percentiles}},
+		}, report)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		storage.EXPECT().
+			GetTeamMergeLatency(window).
+			Return([]repository.TeamLatency{}, nil)
+		storage.EXPECT().
+			GetAuthorMergeLatency(window).
+			Return(nil, errors.New("db down"))
+
+		_, err := usecase.GetMergeLatency(ctx, Window(window))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "db down")
+	})
+}
+
+func TestUsecase_GetTeamActivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(storage)
+
+	storage.EXPECT().
+		GetTeamActivity().
+		Return([]repository.TeamActivity{{TeamName: "backend", OpenPRs: 2, ActiveUsers: 4}}, nil)
+
+	activity, err := usecase.GetTeamActivity(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []TeamActivity{{TeamName: "backend", OpenPRs: 2, ActiveUsers: 4}}, activity)
+}
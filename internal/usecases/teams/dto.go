@@ -4,9 +4,89 @@ type TeamMember struct {
 	UserID   string
 	Username string
 	IsActive bool
+	// Role - роль участника в команде: member, lead или maintainer.
+	Role string
 }
 
 type Team struct {
 	TeamName string
 	Members  []TeamMember
 }
+
+// Settings - действующие настройки команды с учетом значений по умолчанию.
+type Settings struct {
+	TeamName string
+	// ReviewersCount - сколько ревьюеров назначать на новый PR.
+	ReviewersCount int
+	// SelectionStrategy - как выбирать ревьюеров из активных сокомандников.
+	SelectionStrategy string
+	// MinReviewers - сколько ревьюеров должно быть назначено на PR, чтобы его можно было смержить.
+	// Это не число апрувов: апрувы сервис не отслеживает.
+	MinReviewers int
+}
+
+// SettingsUpdate - изменение настроек команды. nil-поля не меняются.
+type SettingsUpdate struct {
+	TeamName          string
+	ReviewersCount    *int
+	SelectionStrategy *string
+	MinReviewers      *int
+}
+
+type ListTeamsFilter struct {
+	NamePrefix string
+	Limit      int
+	Offset     int
+}
+
+// TeamSummary - команда со счетчиками участников и открытых PR.
+type TeamSummary struct {
+	TeamName           string
+	MembersCount       int
+	ActiveMembersCount int
+	OpenPRsCount       int
+}
+
+type TeamsPage struct {
+	Teams []TeamSummary
+	Total int
+}
+
+// RosterRow - строка импортируемого файла с командами в исходном виде.
+type RosterRow struct {
+	// Location - где строка находится в файле, например "line 3" или "teams[0].members[1]".
+	Location string
+	TeamName string
+	UserID   string
+	Username string
+	IsActive string
+	Role     string
+}
+
+type RowError struct {
+	Location string
+	Message  string
+}
+
+const (
+	ChangeCreateTeam = "create_team"
+	ChangeCreateUser = "create_user"
+	ChangeMoveUser   = "move_user"
+	ChangeUpdateUser = "update_user"
+	ChangeSetRole    = "set_role"
+)
+
+// RosterChange - одно изменение, которое импорт внесет в бд.
+type RosterChange struct {
+	Kind     string
+	TeamName string
+	UserID   string
+	Details  string
+}
+
+type ImportResult struct {
+	Changes []RosterChange
+	Errors  []RowError
+	// Applied - изменения записаны в бд (не dry-run и нет ошибок).
+	Applied bool
+}
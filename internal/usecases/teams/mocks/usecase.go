@@ -12,6 +12,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	events "github.com/qwerty268/pull_request_service/internal/events"
 	storage "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -54,6 +55,21 @@ func (mr *MockstorageMockRecorder) AddTeam(team any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeam", reflect.TypeOf((*Mockstorage)(nil).AddTeam), team)
 }
 
+// GetRosterState mocks base method.
+func (m *Mockstorage) GetRosterState(teamNames, userIDs, usernames []string) (*storage.RosterState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRosterState", teamNames, userIDs, usernames)
+	ret0, _ := ret[0].(*storage.RosterState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRosterState indicates an expected call of GetRosterState.
+func (mr *MockstorageMockRecorder) GetRosterState(teamNames, userIDs, usernames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRosterState", reflect.TypeOf((*Mockstorage)(nil).GetRosterState), teamNames, userIDs, usernames)
+}
+
 // GetTeam mocks base method.
 func (m *Mockstorage) GetTeam(teamName string) (*storage.Team, error) {
 	m.ctrl.T.Helper()
@@ -68,3 +84,128 @@ func (mr *MockstorageMockRecorder) GetTeam(teamName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*Mockstorage)(nil).GetTeam), teamName)
 }
+
+// GetTeamSettings mocks base method.
+func (m *Mockstorage) GetTeamSettings(teamName string) (*storage.TeamSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamSettings", teamName)
+	ret0, _ := ret[0].(*storage.TeamSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamSettings indicates an expected call of GetTeamSettings.
+func (mr *MockstorageMockRecorder) GetTeamSettings(teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamSettings", reflect.TypeOf((*Mockstorage)(nil).GetTeamSettings), teamName)
+}
+
+// GetUserTeamSettings mocks base method.
+func (m *Mockstorage) GetUserTeamSettings(userID string) (*storage.TeamSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTeamSettings", userID)
+	ret0, _ := ret[0].(*storage.TeamSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTeamSettings indicates an expected call of GetUserTeamSettings.
+func (mr *MockstorageMockRecorder) GetUserTeamSettings(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTeamSettings", reflect.TypeOf((*Mockstorage)(nil).GetUserTeamSettings), userID)
+}
+
+// ImportTeams mocks base method.
+func (m *Mockstorage) ImportTeams(teams []storage.Team) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTeams", teams)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportTeams indicates an expected call of ImportTeams.
+func (mr *MockstorageMockRecorder) ImportTeams(teams any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTeams", reflect.TypeOf((*Mockstorage)(nil).ImportTeams), teams)
+}
+
+// ListTeams mocks base method.
+func (m *Mockstorage) ListTeams(filter storage.ListTeamsFilter) (*storage.TeamsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", filter)
+	ret0, _ := ret[0].(*storage.TeamsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockstorageMockRecorder) ListTeams(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*Mockstorage)(nil).ListTeams), filter)
+}
+
+// SetMemberRole mocks base method.
+func (m *Mockstorage) SetMemberRole(teamName, userID, role string) (*storage.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMemberRole", teamName, userID, role)
+	ret0, _ := ret[0].(*storage.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMemberRole indicates an expected call of SetMemberRole.
+func (mr *MockstorageMockRecorder) SetMemberRole(teamName, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRole", reflect.TypeOf((*Mockstorage)(nil).SetMemberRole), teamName, userID, role)
+}
+
+// UpdateTeamSettings mocks base method.
+func (m *Mockstorage) UpdateTeamSettings(settings storage.TeamSettings) (*storage.TeamSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamSettings", settings)
+	ret0, _ := ret[0].(*storage.TeamSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamSettings indicates an expected call of UpdateTeamSettings.
+func (mr *MockstorageMockRecorder) UpdateTeamSettings(settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamSettings", reflect.TypeOf((*Mockstorage)(nil).UpdateTeamSettings), settings)
+}
+
+// MockeventPublisher is a mock of eventPublisher interface.
+type MockeventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockeventPublisherMockRecorder is the mock recorder for MockeventPublisher.
+type MockeventPublisherMockRecorder struct {
+	mock *MockeventPublisher
+}
+
+// NewMockeventPublisher creates a new mock instance.
+func NewMockeventPublisher(ctrl *gomock.Controller) *MockeventPublisher {
+	mock := &MockeventPublisher{ctrl: ctrl}
+	mock.recorder = &MockeventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventPublisher) EXPECT() *MockeventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockeventPublisher) Publish(ev events.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ev)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockeventPublisherMockRecorder) Publish(ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockeventPublisher)(nil).Publish), ev)
+}
@@ -0,0 +1,124 @@
+package teams
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	RosterFormatCSV  = "csv"
+	RosterFormatYAML = "yaml"
+)
+
+var ErrUnknownRosterFormat = errors.New("unknown roster format")
+
+// csvRosterHeader - обязательные колонки CSV. Колонка role необязательна.
+var csvRosterHeader = []string{"team_name", "user_id", "username", "is_active"}
+
+type yamlRoster struct {
+	Teams []struct {
+		TeamName string `yaml:"team_name"`
+		Members  []struct {
+			UserID   string `yaml:"user_id"`
+			Username string `yaml:"username"`
+			IsActive string `yaml:"is_active"`
+			Role     string `yaml:"role"`
+		} `yaml:"members"`
+	} `yaml:"teams"`
+}
+
+// ParseRoster разбирает файл с командами. Проверяется только синтаксис файла,
+// значения полей проверяет ImportRoster, чтобы собрать ошибки по всем строкам сразу.
+func ParseRoster(format string, r io.Reader) ([]RosterRow, error) {
+	switch strings.ToLower(format) {
+	case RosterFormatCSV:
+		return parseRosterCSV(r)
+	case RosterFormatYAML, "yml":
+		return parseRosterYAML(r)
+	default:
+		return nil, fmt.Errorf("%q: %w", format, ErrUnknownRosterFormat)
+	}
+}
+
+func parseRosterCSV(r io.Reader) ([]RosterRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, name := range csvRosterHeader {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]RosterRow, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read record: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, RosterRow{
+			Location: fmt.Sprintf("line %d", line),
+			TeamName: field(record, "team_name"),
+			UserID:   field(record, "user_id"),
+			Username: field(record, "username"),
+			IsActive: field(record, "is_active"),
+			Role:     field(record, "role"),
+		})
+	}
+	return rows, nil
+}
+
+func parseRosterYAML(r io.Reader) ([]RosterRow, error) {
+	var roster yamlRoster
+	if err := yaml.NewDecoder(r).Decode(&roster); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decode yaml: %v", err)
+	}
+
+	rows := make([]RosterRow, 0)
+	for i, team := range roster.Teams {
+		if len(team.Members) == 0 {
+			rows = append(rows, RosterRow{
+				Location: fmt.Sprintf("teams[%d]", i),
+				TeamName: team.TeamName,
+			})
+			continue
+		}
+		for j, m := range team.Members {
+			rows = append(rows, RosterRow{
+				Location: fmt.Sprintf("teams[%d].members[%d]", i, j),
+				TeamName: team.TeamName,
+				UserID:   m.UserID,
+				Username: m.Username,
+				IsActive: m.IsActive,
+				Role:     m.Role,
+			})
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,57 @@
+package teams
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoster(t *testing.T) {
+	t.Run("csv", func(t *testing.T) {
+		data := "team_name,user_id,username,is_active,role\n" +
+			"backend,u1,Alice,true,lead\n" +
+			"backend,u2,Bob,false,\n"
+
+		rows, err := ParseRoster(RosterFormatCSV, strings.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, []RosterRow{
+			{Location: "line 2", TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: "true", Role: "lead"},
+			{Location: "line 3", TeamName: "backend", UserID: "u2", Username: "Bob", IsActive: "false"},
+		}, rows)
+	})
+
+	t.Run("csv missing column", func(t *testing.T) {
+		_, err := ParseRoster(RosterFormatCSV, strings.NewReader("team_name,user_id\nbackend,u1\n"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "username")
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data := `
+teams:
+  - team_name: backend
+    members:
+      - user_id: u1
+        username: Alice
+        is_active: true
+        role: lead
+  - team_name: frontend
+    members:
+      - user_id: u3
+        username: Carl
+        is_active: yes
+`
+		rows, err := ParseRoster(RosterFormatYAML, strings.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, []RosterRow{
+			{Location: "teams[0].members[0]", TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: "true", Role: "lead"},
+			{Location: "teams[1].members[0]", TeamName: "frontend", UserID: "u3", Username: "Carl", IsActive: "yes"},
+		}, rows)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := ParseRoster("xml", strings.NewReader(""))
+		require.ErrorIs(t, err, ErrUnknownRosterFormat)
+	})
+}
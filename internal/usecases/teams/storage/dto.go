@@ -4,9 +4,53 @@ type TeamMember struct {
 	UserID   string
 	Username string
 	IsActive bool
+	Role     string
 }
 
 type Team struct {
 	TeamName string
 	Members  []TeamMember
 }
+
+// TeamSettings - настройки команды. nil-поле означает, что значение не задано
+// и используется глобальное значение по умолчанию.
+type TeamSettings struct {
+	TeamName          string
+	ReviewersCount    *int
+	SelectionStrategy *string
+	MinReviewers      *int
+}
+
+type ListTeamsFilter struct {
+	NamePrefix string
+	Limit      int
+	Offset     int
+}
+
+// TeamSummary - команда со счетчиками участников и открытых PR.
+type TeamSummary struct {
+	TeamName           string
+	MembersCount       int
+	ActiveMembersCount int
+	OpenPRsCount       int
+}
+
+type TeamsPage struct {
+	Teams []TeamSummary
+	// Total - сколько всего команд подходит под фильтр без учета пагинации.
+	Total int
+}
+
+// RosterMember - текущее состояние пользователя в бд для сравнения с импортом.
+type RosterMember struct {
+	UserID   string
+	Username string
+	TeamName string
+	IsActive bool
+	Role     string
+}
+
+type RosterState struct {
+	ExistingTeams []string
+	Members       []RosterMember
+}
@@ -4,9 +4,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+
+	"github.com/qwerty268/pull_request_service/internal/metrics"
 )
 
 var (
@@ -29,6 +33,8 @@ func NewStorage(db *sqlx.DB) *Storage {
 }
 
 func (s *Storage) AddTeam(team Team) error {
+	defer metrics.ObserveQuery("teams", "AddTeam", time.Now())
+
 	tx, err := s.db.Beginx()
 	if err != nil {
 		return fmt.Errorf("start tx: %v", err)
@@ -62,10 +68,18 @@ func (s *Storage) AddTeam(team Team) error {
 			return fmt.Errorf("insert user: %v", err)
 		}
 
+		// Пользователь состоит только в одной команде - убираем прежнее членство.
 		_, err = tx.Exec(`
-			INSERT INTO team_user_map (team_name, user_id) VALUES ($1, $2)
+			DELETE FROM team_user_map WHERE user_id = $1 AND team_name <> $2
+		`, m.UserID, team.TeamName)
+		if err != nil {
+			return fmt.Errorf("delete team_user_map: %w", err)
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO team_user_map (team_name, user_id, role) VALUES ($1, $2, $3)
 			ON CONFLICT DO NOTHING
-		`, team.TeamName, m.UserID)
+		`, team.TeamName, m.UserID, m.Role)
 		if err != nil {
 			return fmt.Errorf("insert team_user_map: %w", err)
 		}
@@ -78,12 +92,14 @@ func (s *Storage) AddTeam(team Team) error {
 }
 
 func (s *Storage) GetTeam(teamName string) (*Team, error) {
+	defer metrics.ObserveQuery("teams", "GetTeam", time.Now())
+
 	query := `
 	SELECT
 		u.user_id,
 		u.username,
-		u.team_name,
-		u.is_active
+		u.is_active,
+		tum.role
 	FROM "user" AS u
 	JOIN team_user_map AS tum ON tum.user_id = u.user_id
 	WHERE tum.team_name = $1
@@ -98,8 +114,8 @@ func (s *Storage) GetTeam(teamName string) (*Team, error) {
 	var members []TeamMember
 	for rows.Next() {
 		var m TeamMember
-		// user_id, username, team_name, is_active
-		if err := rows.Scan(&m.UserID, &m.Username, &m.Username, &m.IsActive); err != nil {
+		// user_id, username, is_active, role
+		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive, &m.Role); err != nil {
 			return nil, fmt.Errorf("scan: %v", err)
 		}
 		members = append(members, m)
@@ -108,8 +124,17 @@ func (s *Storage) GetTeam(teamName string) (*Team, error) {
 		return nil, fmt.Errorf("rows: %v", err)
 	}
 
+	// Команда без участников тоже существует.
 	if len(members) == 0 {
-		return nil, ErrNotFound
+		var dummy string
+		err := s.db.QueryRow(`SELECT team_name FROM team WHERE team_name = $1`, teamName).Scan(&dummy)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrNotFound
+			}
+			return nil, fmt.Errorf("query team: %v", err)
+		}
+		members = make([]TeamMember, 0)
 	}
 
 	team := &Team{
@@ -120,6 +145,8 @@ func (s *Storage) GetTeam(teamName string) (*Team, error) {
 }
 
 func (s *Storage) GetUserActiveTeammates(userID string) ([]string, error) {
+	defer metrics.ObserveQuery("teams", "GetUserActiveTeammates", time.Now())
+
 	query := `
 	SELECT u.user_id
 	FROM "user" AS u
@@ -148,6 +175,8 @@ func (s *Storage) GetUserActiveTeammates(userID string) ([]string, error) {
 }
 
 func (s *Storage) CheckUserInCommand(userID string) (bool, error) {
+	defer metrics.ObserveQuery("teams", "CheckUserInCommand", time.Now())
+
 	query := `SELECT user_id FROM team_user_map WHERE user_id = $1 LIMIT 1`
 
 	row := s.db.QueryRow(query, userID)
@@ -162,3 +191,344 @@ func (s *Storage) CheckUserInCommand(userID string) (bool, error) {
 	}
 	return true, nil
 }
+
+func (s *Storage) GetTeamSettings(teamName string) (*TeamSettings, error) {
+	defer metrics.ObserveQuery("teams", "GetTeamSettings", time.Now())
+
+	query := `
+	SELECT
+		t.team_name,
+		ts.reviewers_count,
+		ts.selection_strategy,
+		ts.min_reviewers
+	FROM team AS t
+	LEFT JOIN team_settings AS ts ON ts.team_name = t.team_name
+	WHERE t.team_name = $1
+	`
+
+	var settings TeamSettings
+	err := s.db.QueryRow(query, teamName).Scan(
+		&settings.TeamName,
+		&settings.ReviewersCount,
+		&settings.SelectionStrategy,
+		&settings.MinReviewers,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("GetTeamSettings: %w", err)
+	}
+	return &settings, nil
+}
+
+// GetUserTeamSettings выдает настройки команды, в которой состоит пользователь.
+func (s *Storage) GetUserTeamSettings(userID string) (*TeamSettings, error) {
+	defer metrics.ObserveQuery("teams", "GetUserTeamSettings", time.Now())
+
+	query := `
+	SELECT
+		tum.team_name,
+		ts.reviewers_count,
+		ts.selection_strategy,
+		ts.min_reviewers
+	FROM team_user_map AS tum
+	LEFT JOIN team_settings AS ts ON ts.team_name = tum.team_name
+	WHERE tum.user_id = $1
+	ORDER BY tum.team_name
+	LIMIT 1
+	`
+
+	var settings TeamSettings
+	err := s.db.QueryRow(query, userID).Scan(
+		&settings.TeamName,
+		&settings.ReviewersCount,
+		&settings.SelectionStrategy,
+		&settings.MinReviewers,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("GetUserTeamSettings: %w", err)
+	}
+	return &settings, nil
+}
+
+// UpdateTeamSettings обновляет только заданные (не nil) поля настроек.
+func (s *Storage) UpdateTeamSettings(settings TeamSettings) (*TeamSettings, error) {
+	defer metrics.ObserveQuery("teams", "UpdateTeamSettings", time.Now())
+
+	query := `
+	INSERT INTO team_settings
+		(team_name, reviewers_count, selection_strategy, min_reviewers)
+	VALUES
+		($1, $2, $3, $4)
+	ON CONFLICT (team_name)
+	DO UPDATE SET
+		reviewers_count    = COALESCE(excluded.reviewers_count, team_settings.reviewers_count),
+		selection_strategy = COALESCE(excluded.selection_strategy, team_settings.selection_strategy),
+		min_reviewers      = COALESCE(excluded.min_reviewers, team_settings.min_reviewers)
+	RETURNING team_name, reviewers_count, selection_strategy, min_reviewers
+	`
+
+	var updated TeamSettings
+	err := s.db.QueryRow(
+		query,
+		settings.TeamName,
+		settings.ReviewersCount,
+		settings.SelectionStrategy,
+		settings.MinReviewers,
+	).Scan(
+		&updated.TeamName,
+		&updated.ReviewersCount,
+		&updated.SelectionStrategy,
+		&updated.MinReviewers,
+	)
+	if err != nil {
+		// Нарушение внешнего ключа - команды не существует.
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("UpdateTeamSettings: %w", err)
+	}
+	return &updated, nil
+}
+
+// ListTeams выдает страницу команд со счетчиками одним запросом.
+func (s *Storage) ListTeams(filter ListTeamsFilter) (*TeamsPage, error) {
+	defer metrics.ObserveQuery("teams", "ListTeams", time.Now())
+
+	// LEFT JOIN LATERAL нужен, чтобы получить общее количество даже для пустой страницы.
+	query := `
+	SELECT
+		total.cnt,
+		page.team_name,
+		page.members_count,
+		page.active_members_count,
+		page.open_prs_count
+	FROM (
+		SELECT COUNT(*) AS cnt FROM team WHERE team_name LIKE $1
+	) AS total
+	LEFT JOIN LATERAL (
+		SELECT
+			t.team_name,
+			(
+				SELECT COUNT(*)
+				FROM team_user_map AS tum
+				WHERE tum.team_name = t.team_name
+			) AS members_count,
+			(
+				SELECT COUNT(*)
+				FROM team_user_map AS tum
+				JOIN "user" AS u ON u.user_id = tum.user_id
+				WHERE tum.team_name = t.team_name AND u.is_active
+			) AS active_members_count,
+			(
+				SELECT COUNT(*)
+				FROM pull_request AS pr
+				JOIN team_user_map AS tum ON tum.user_id = pr.author_id
+				WHERE tum.team_name = t.team_name AND NOT pr.is_merged
+			) AS open_prs_count
+		FROM team AS t
+		WHERE t.team_name LIKE $1
+		ORDER BY t.team_name
+		LIMIT $2 OFFSET $3
+	) AS page ON TRUE
+	`
+
+	rows, err := s.db.Query(query, escapeLike(filter.NamePrefix)+"%", filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+	defer rows.Close()
+
+	page := &TeamsPage{Teams: make([]TeamSummary, 0)}
+	for rows.Next() {
+		var (
+			teamName                                sql.NullString
+			membersCount, activeCount, openPRsCount sql.NullInt64
+		)
+		if err := rows.Scan(&page.Total, &teamName, &membersCount, &activeCount, &openPRsCount); err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		// Пустая страница - единственная строка без команды.
+		if !teamName.Valid {
+			continue
+		}
+		page.Teams = append(page.Teams, TeamSummary{
+			TeamName:           teamName.String,
+			MembersCount:       int(membersCount.Int64),
+			ActiveMembersCount: int(activeCount.Int64),
+			OpenPRsCount:       int(openPRsCount.Int64),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return page, nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// SetMemberRole меняет роль участника команды.
+func (s *Storage) SetMemberRole(teamName, userID, role string) (*TeamMember, error) {
+	defer metrics.ObserveQuery("teams", "SetMemberRole", time.Now())
+
+	query := `
+	WITH updated AS (
+		UPDATE team_user_map
+		SET role = $3
+		WHERE team_name = $1 AND user_id = $2
+		RETURNING user_id, role
+	)
+	SELECT u.user_id, u.username, u.is_active, updated.role
+	FROM updated
+	JOIN "user" AS u ON u.user_id = updated.user_id
+	`
+
+	var m TeamMember
+	err := s.db.QueryRow(query, teamName, userID, role).Scan(&m.UserID, &m.Username, &m.IsActive, &m.Role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("SetMemberRole: %w", err)
+	}
+	return &m, nil
+}
+
+func (s *Storage) GetUserTeamLeads(userID string) ([]string, error) {
+	defer metrics.ObserveQuery("teams", "GetUserTeamLeads", time.Now())
+
+	query := `
+	SELECT tum.user_id
+	FROM team_user_map AS tum
+	WHERE tum.team_name = (SELECT team_name FROM team_user_map WHERE user_id = $1 LIMIT 1)
+	AND tum.role = 'lead'
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+	defer rows.Close()
+
+	leads := make([]string, 0)
+	for rows.Next() {
+		var leadID string
+		if err := rows.Scan(&leadID); err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		leads = append(leads, leadID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return leads, nil
+}
+
+// GetRosterState выдает существующие команды и пользователей, которых затрагивает импорт.
+// Пользователи ищутся как по user_id, так и по username.
+func (s *Storage) GetRosterState(teamNames, userIDs, usernames []string) (*RosterState, error) {
+	defer metrics.ObserveQuery("teams", "GetRosterState", time.Now())
+
+	state := &RosterState{
+		ExistingTeams: make([]string, 0),
+		Members:       make([]RosterMember, 0),
+	}
+
+	err := s.db.Select(&state.ExistingTeams, `SELECT team_name FROM team WHERE team_name = ANY($1)`, pq.Array(teamNames))
+	if err != nil {
+		return nil, fmt.Errorf("select teams: %v", err)
+	}
+
+	query := `
+	SELECT
+		u.user_id,
+		u.username,
+		COALESCE(tum.team_name, u.team_name, ''),
+		COALESCE(u.is_active, false),
+		COALESCE(tum.role, '')
+	FROM "user" AS u
+	LEFT JOIN team_user_map AS tum ON tum.user_id = u.user_id
+	WHERE u.user_id = ANY($1) OR u.username = ANY($2)
+	`
+
+	rows, err := s.db.Query(query, pq.Array(userIDs), pq.Array(usernames))
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m RosterMember
+		if err := rows.Scan(&m.UserID, &m.Username, &m.TeamName, &m.IsActive, &m.Role); err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		state.Members = append(state.Members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+	return state, nil
+}
+
+// ImportTeams в одной транзакции создает недостающие команды и добавляет или переводит в них пользователей.
+// В отличие от AddTeam существующие команды не считаются ошибкой.
+func (s *Storage) ImportTeams(teams []Team) error {
+	defer metrics.ObserveQuery("teams", "ImportTeams", time.Now())
+
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("start tx: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, team := range teams {
+		_, err = tx.Exec(`INSERT INTO team (team_name) VALUES ($1) ON CONFLICT DO NOTHING`, team.TeamName)
+		if err != nil {
+			return fmt.Errorf("insert team: %v", err)
+		}
+
+		for _, m := range team.Members {
+			_, err = tx.Exec(`
+				INSERT INTO "user" (user_id, username, team_name, is_active)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (user_id)
+				DO UPDATE SET
+					username=excluded.username,
+					team_name=excluded.team_name,
+					is_active=excluded.is_active
+			`, m.UserID, m.Username, team.TeamName, m.IsActive)
+			if err != nil {
+				return fmt.Errorf("insert user: %v", err)
+			}
+
+			_, err = tx.Exec(`
+				DELETE FROM team_user_map WHERE user_id = $1 AND team_name <> $2
+			`, m.UserID, team.TeamName)
+			if err != nil {
+				return fmt.Errorf("delete team_user_map: %w", err)
+			}
+
+			_, err = tx.Exec(`
+				INSERT INTO team_user_map (team_name, user_id, role) VALUES ($1, $2, $3)
+				ON CONFLICT (team_name, user_id) DO UPDATE SET role = excluded.role
+			`, team.TeamName, m.UserID, m.Role)
+			if err != nil {
+				return fmt.Errorf("insert team_user_map: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
@@ -6,27 +6,67 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
+	"github.com/qwerty268/pull_request_service/internal/events"
 	repository "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
 )
 
 var (
-	ErrAlreadyExists = errors.New("already exists")
-	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidSettings = errors.New("invalid settings")
+	ErrInvalidRoster   = errors.New("invalid roster")
 )
 
+const (
+	// StrategyRandom - случайные активные сокомандники.
+	StrategyRandom = "random"
+	// StrategyLeastLoaded - сокомандники с наименьшим числом открытых ревью.
+	StrategyLeastLoaded = "least_loaded"
+)
+
+const (
+	RoleMember     = "member"
+	RoleLead       = "lead"
+	RoleMaintainer = "maintainer"
+)
+
+// DefaultListLimit - размер страницы списка команд, если он не задан.
+const DefaultListLimit = 20
+
+// DefaultSettings - глобальные настройки, которые действуют, если команда их не переопределила.
+var DefaultSettings = Settings{
+	ReviewersCount:    2,
+	SelectionStrategy: StrategyRandom,
+	MinReviewers:      0,
+}
+
 type storage interface {
 	AddTeam(team repository.Team) error
 	GetTeam(teamName string) (*repository.Team, error)
+	GetTeamSettings(teamName string) (*repository.TeamSettings, error)
+	GetUserTeamSettings(userID string) (*repository.TeamSettings, error)
+	UpdateTeamSettings(settings repository.TeamSettings) (*repository.TeamSettings, error)
+	ListTeams(filter repository.ListTeamsFilter) (*repository.TeamsPage, error)
+	SetMemberRole(teamName, userID, role string) (*repository.TeamMember, error)
+	GetRosterState(teamNames, userIDs, usernames []string) (*repository.RosterState, error)
+	ImportTeams(teams []repository.Team) error
+}
+
+type eventPublisher interface {
+	Publish(ev events.Event)
 }
 
 type Usecase struct {
 	storage storage
+	events  eventPublisher
 }
 
-func NewUsecase(storage storage) Usecase {
+func NewUsecase(storage storage, publisher eventPublisher) Usecase {
 	return Usecase{
 		storage: storage,
+		events:  publisher,
 	}
 }
 
@@ -38,10 +78,24 @@ func (u Usecase) AddTeam(_ context.Context, team Team) error {
 		}
 		return fmt.Errorf("failed to add new teram: %v", err)
 	}
+	u.publishTeamCreated(team)
 
 	return nil
 }
 
+func (u Usecase) publishTeamCreated(team Team) {
+	members := make([]string, len(team.Members))
+	for i, v := range team.Members {
+		members[i] = v.UserID
+	}
+	u.events.Publish(events.Event{
+		Type:     events.TeamCreated,
+		TeamName: team.TeamName,
+		UserIDs:  members,
+		Data:     events.Team{TeamName: team.TeamName, Members: members},
+	})
+}
+
 func toStorageTeam(team Team) repository.Team {
 	storageTeam := repository.Team{
 		TeamName: team.TeamName,
@@ -49,6 +103,9 @@ func toStorageTeam(team Team) repository.Team {
 	}
 	for i, v := range team.Members {
 		storageTeam.Members[i] = repository.TeamMember(v)
+		if storageTeam.Members[i].Role == "" {
+			storageTeam.Members[i].Role = RoleMember
+		}
 	}
 	return storageTeam
 }
@@ -76,3 +133,350 @@ func fromStorageTeam(storageTeam *repository.Team) *Team {
 		Members:  memers,
 	}
 }
+
+func (u Usecase) GetTeamSettings(_ context.Context, teamName string) (*Settings, error) {
+	storageSettings, err := u.storage.GetTeamSettings(teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get team settings: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get team settings: %v", err)
+	}
+
+	settings := resolveSettings(storageSettings)
+	return &settings, nil
+}
+
+// GetUserTeamSettings выдает настройки команды пользователя.
+// Если пользователь не состоит в команде, выдаются настройки по умолчанию.
+func (u Usecase) GetUserTeamSettings(_ context.Context, userID string) (*Settings, error) {
+	storageSettings, err := u.storage.GetUserTeamSettings(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			settings := DefaultSettings
+			return &settings, nil
+		}
+		return nil, fmt.Errorf("failed to get user team settings: %v", err)
+	}
+
+	settings := resolveSettings(storageSettings)
+	return &settings, nil
+}
+
+func (u Usecase) UpdateTeamSettings(_ context.Context, update SettingsUpdate) (*Settings, error) {
+	current, err := u.storage.GetTeamSettings(update.TeamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get team settings: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get team settings: %v", err)
+	}
+
+	// Проверяем настройки в том виде, в котором они будут действовать после обновления.
+	merged := *current
+	if update.ReviewersCount != nil {
+		merged.ReviewersCount = update.ReviewersCount
+	}
+	if update.SelectionStrategy != nil {
+		merged.SelectionStrategy = update.SelectionStrategy
+	}
+	if update.MinReviewers != nil {
+		merged.MinReviewers = update.MinReviewers
+	}
+	if err := validateSettings(resolveSettings(&merged)); err != nil {
+		return nil, err
+	}
+
+	storageSettings, err := u.storage.UpdateTeamSettings(repository.TeamSettings(update))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to update team settings: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update team settings: %v", err)
+	}
+
+	settings := resolveSettings(storageSettings)
+	return &settings, nil
+}
+
+func validateSettings(settings Settings) error {
+	if settings.SelectionStrategy != StrategyRandom && settings.SelectionStrategy != StrategyLeastLoaded {
+		return fmt.Errorf("unknown selection strategy %q: %w", settings.SelectionStrategy, ErrInvalidSettings)
+	}
+	if settings.MinReviewers > settings.ReviewersCount {
+		return fmt.Errorf("min reviewers exceed reviewers count: %w", ErrInvalidSettings)
+	}
+	return nil
+}
+
+// resolveSettings подставляет значения по умолчанию вместо незаданных полей.
+func resolveSettings(storageSettings *repository.TeamSettings) Settings {
+	settings := DefaultSettings
+	settings.TeamName = storageSettings.TeamName
+	if storageSettings.ReviewersCount != nil {
+		settings.ReviewersCount = *storageSettings.ReviewersCount
+	}
+	if storageSettings.SelectionStrategy != nil {
+		settings.SelectionStrategy = *storageSettings.SelectionStrategy
+	}
+	if storageSettings.MinReviewers != nil {
+		settings.MinReviewers = *storageSettings.MinReviewers
+	}
+	return settings
+}
+
+func (u Usecase) ListTeams(_ context.Context, filter ListTeamsFilter) (*TeamsPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+
+	storagePage, err := u.storage.ListTeams(repository.ListTeamsFilter(filter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %v", err)
+	}
+
+	page := &TeamsPage{
+		Teams: make([]TeamSummary, len(storagePage.Teams)),
+		Total: storagePage.Total,
+	}
+	for i, v := range storagePage.Teams {
+		page.Teams[i] = TeamSummary(v)
+	}
+	return page, nil
+}
+
+func (u Usecase) SetMemberRole(_ context.Context, teamName, userID, role string) (*TeamMember, error) {
+	storageMember, err := u.storage.SetMemberRole(teamName, userID, role)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to set member role: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to set member role: %v", err)
+	}
+
+	member := TeamMember(*storageMember)
+	return &member, nil
+}
+
+// ImportRoster проверяет все строки импорта, считает изменения относительно бд и,
+// если ошибок нет и это не dry-run, применяет их одной транзакцией.
+// При ошибках в строках возвращается результат со списком ошибок и ErrInvalidRoster.
+func (u Usecase) ImportRoster(_ context.Context, rows []RosterRow, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{
+		Changes: make([]RosterChange, 0),
+		Errors:  make([]RowError, 0),
+	}
+
+	teams, rowErrors := groupRosterRows(rows)
+	result.Errors = append(result.Errors, rowErrors...)
+
+	var teamNames, userIDs, usernames []string
+	for _, team := range teams {
+		teamNames = append(teamNames, team.TeamName)
+		for _, m := range team.Members {
+			userIDs = append(userIDs, m.UserID)
+			usernames = append(usernames, m.Username)
+		}
+	}
+
+	state, err := u.storage.GetRosterState(teamNames, userIDs, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster state: %v", err)
+	}
+
+	changes, stateErrors := diffRoster(rows, teams, state)
+	result.Changes = changes
+	result.Errors = append(result.Errors, stateErrors...)
+
+	if len(result.Errors) > 0 {
+		return result, fmt.Errorf("%d invalid rows: %w", len(result.Errors), ErrInvalidRoster)
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	storageTeams := make([]repository.Team, len(teams))
+	for i, team := range teams {
+		storageTeams[i] = toStorageTeam(team)
+	}
+	if err := u.storage.ImportTeams(storageTeams); err != nil {
+		return nil, fmt.Errorf("failed to import teams: %v", err)
+	}
+
+	created := make(map[string]bool)
+	for _, change := range changes {
+		if change.Kind == ChangeCreateTeam {
+			created[change.TeamName] = true
+		}
+	}
+	for _, team := range teams {
+		if created[team.TeamName] {
+			u.publishTeamCreated(team)
+		}
+	}
+
+	result.Applied = true
+	return result, nil
+}
+
+// groupRosterRows проверяет поля строк и собирает их в команды в порядке появления.
+func groupRosterRows(rows []RosterRow) ([]Team, []RowError) {
+	var (
+		teams     []Team
+		errs      []RowError
+		teamIndex = make(map[string]int)
+		seenUsers = make(map[string]string)
+		seenNames = make(map[string]string)
+	)
+
+	for _, row := range rows {
+		rowErr := func(format string, args ...any) {
+			errs = append(errs, RowError{Location: row.Location, Message: fmt.Sprintf(format, args...)})
+		}
+
+		// Строка с одной командой - команда без участников, так ее выгружает prctl export.
+		if row.TeamName != "" && row.UserID == "" && row.Username == "" && row.IsActive == "" && row.Role == "" {
+			if _, ok := teamIndex[row.TeamName]; !ok {
+				teamIndex[row.TeamName] = len(teams)
+				teams = append(teams, Team{TeamName: row.TeamName})
+			}
+			continue
+		}
+
+		if row.TeamName == "" {
+			rowErr("team_name is required")
+		}
+		if row.UserID == "" {
+			rowErr("user_id is required")
+		}
+		if row.Username == "" {
+			rowErr("username is required")
+		}
+		isActive, err := strconv.ParseBool(row.IsActive)
+		if err != nil {
+			rowErr("is_active must be true or false, got %q", row.IsActive)
+		}
+		role := row.Role
+		if role == "" {
+			role = RoleMember
+		}
+		if role != RoleMember && role != RoleLead && role != RoleMaintainer {
+			rowErr("unknown role %q", row.Role)
+		}
+
+		if row.UserID != "" {
+			if prev, ok := seenUsers[row.UserID]; ok {
+				rowErr("user_id %s is already listed at %s", row.UserID, prev)
+				continue
+			}
+			seenUsers[row.UserID] = row.Location
+		}
+		if row.Username != "" {
+			if prev, ok := seenNames[row.Username]; ok {
+				rowErr("username %s is already listed at %s", row.Username, prev)
+				continue
+			}
+			seenNames[row.Username] = row.Location
+		}
+
+		if row.TeamName == "" || row.UserID == "" || row.Username == "" {
+			continue
+		}
+		i, ok := teamIndex[row.TeamName]
+		if !ok {
+			i = len(teams)
+			teamIndex[row.TeamName] = i
+			teams = append(teams, Team{TeamName: row.TeamName})
+		}
+		teams[i].Members = append(teams[i].Members, TeamMember{
+			UserID:   row.UserID,
+			Username: row.Username,
+			IsActive: isActive,
+			Role:     role,
+		})
+	}
+	return teams, errs
+}
+
+// diffRoster сравнивает импорт с текущим состоянием бд.
+func diffRoster(rows []RosterRow, teams []Team, state *repository.RosterState) ([]RosterChange, []RowError) {
+	existingTeams := make(map[string]struct{}, len(state.ExistingTeams))
+	for _, name := range state.ExistingTeams {
+		existingTeams[name] = struct{}{}
+	}
+	byID := make(map[string]repository.RosterMember, len(state.Members))
+	byName := make(map[string]repository.RosterMember, len(state.Members))
+	for _, m := range state.Members {
+		byID[m.UserID] = m
+		byName[m.Username] = m
+	}
+	locations := make(map[string]string, len(rows))
+	for _, row := range rows {
+		if _, ok := locations[row.UserID]; !ok {
+			locations[row.UserID] = row.Location
+		}
+	}
+
+	changes := make([]RosterChange, 0)
+	errs := make([]RowError, 0)
+	for _, team := range teams {
+		if _, ok := existingTeams[team.TeamName]; !ok {
+			changes = append(changes, RosterChange{Kind: ChangeCreateTeam, TeamName: team.TeamName})
+		}
+
+		for _, m := range team.Members {
+			if other, ok := byName[m.Username]; ok && other.UserID != m.UserID {
+				errs = append(errs, RowError{
+					Location: locations[m.UserID],
+					Message:  fmt.Sprintf("username %s already belongs to user %s", m.Username, other.UserID),
+				})
+				continue
+			}
+
+			current, ok := byID[m.UserID]
+			if !ok {
+				changes = append(changes, RosterChange{
+					Kind:     ChangeCreateUser,
+					TeamName: team.TeamName,
+					UserID:   m.UserID,
+					Details:  fmt.Sprintf("username=%s is_active=%t role=%s", m.Username, m.IsActive, m.Role),
+				})
+				continue
+			}
+
+			if current.TeamName != team.TeamName {
+				changes = append(changes, RosterChange{
+					Kind:     ChangeMoveUser,
+					TeamName: team.TeamName,
+					UserID:   m.UserID,
+					Details:  fmt.Sprintf("from team %s", current.TeamName),
+				})
+			}
+			if current.Username != m.Username {
+				changes = append(changes, RosterChange{
+					Kind:     ChangeUpdateUser,
+					TeamName: team.TeamName,
+					UserID:   m.UserID,
+					Details:  fmt.Sprintf("username %s -> %s", current.Username, m.Username),
+				})
+			}
+			if current.IsActive != m.IsActive {
+				changes = append(changes, RosterChange{
+					Kind:     ChangeUpdateUser,
+					TeamName: team.TeamName,
+					UserID:   m.UserID,
+					Details:  fmt.Sprintf("is_active %t -> %t", current.IsActive, m.IsActive),
+				})
+			}
+			if current.TeamName == team.TeamName && current.Role != m.Role {
+				changes = append(changes, RosterChange{
+					Kind:     ChangeSetRole,
+					TeamName: team.TeamName,
+					UserID:   m.UserID,
+					Details:  fmt.Sprintf("role %s -> %s", current.Role, m.Role),
+				})
+			}
+		}
+	}
+	return changes, errs
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/qwerty268/pull_request_service/internal/events"
 	"github.com/qwerty268/pull_request_service/internal/usecases/teams/mocks"
 	repository "github.com/qwerty268/pull_request_service/internal/usecases/teams/storage"
 )
@@ -18,7 +19,7 @@ func TestUsecase_AddTeam(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStorage := mocks.NewMockstorage(ctrl)
-	u := Usecase{storage: mockStorage}
+	u := Usecase{storage: mockStorage, events: events.Discard}
 
 	team := Team{TeamName: "myteam"}
 
@@ -50,6 +51,25 @@ func TestUsecase_AddTeam(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "db fail")
 	})
+
+	t.Run("publishes TEAM_CREATED", func(t *testing.T) {
+		mockEvents := mocks.NewMockeventPublisher(ctrl)
+		u := Usecase{storage: mockStorage, events: mockEvents}
+		team := Team{TeamName: "myteam", Members: []TeamMember{{UserID: "u1"}, {UserID: "u2"}}}
+
+		mockStorage.EXPECT().
+			AddTeam(toStorageTeam(team)).
+			Return(nil)
+		mockEvents.EXPECT().Publish(events.Event{
+			Type:     events.TeamCreated,
+			TeamName: "myteam",
+			UserIDs:  []string{"u1", "u2"},
+			Data:     events.Team{TeamName: "myteam", Members: []string{"u1", "u2"}},
+		})
+
+		err := u.AddTeam(context.Background(), team)
+		require.NoError(t, err)
+	})
 }
 
 func TestUsecase_GetTeam(t *testing.T) {
@@ -57,7 +77,7 @@ func TestUsecase_GetTeam(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStorage := mocks.NewMockstorage(ctrl)
-	usecase := NewUsecase(mockStorage)
+	usecase := NewUsecase(mockStorage, events.Discard)
 	ctx := context.Background()
 
 	wantRepoTeam := &repository.Team{
@@ -105,3 +125,292 @@ func TestUsecase_GetTeam(t *testing.T) {
 		require.Nil(t, got)
 	})
 }
+
+func TestUsecase_GetTeamSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(mockStorage, events.Discard)
+	ctx := context.Background()
+
+	reviewers := 3
+	strategy := StrategyLeastLoaded
+
+	t.Run("overrides and defaults", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetTeamSettings("dream").
+			Return(&repository.TeamSettings{
+				TeamName:          "dream",
+				ReviewersCount:    &reviewers,
+				SelectionStrategy: &strategy,
+			}, nil)
+
+		got, err := usecase.GetTeamSettings(ctx, "dream")
+		require.NoError(t, err)
+		require.Equal(t, Settings{
+			TeamName:          "dream",
+			ReviewersCount:    3,
+			SelectionStrategy: StrategyLeastLoaded,
+			MinReviewers:      DefaultSettings.MinReviewers,
+		}, *got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetTeamSettings("dream").
+			Return(nil, repository.ErrNotFound)
+
+		got, err := usecase.GetTeamSettings(ctx, "dream")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Nil(t, got)
+	})
+}
+
+func TestUsecase_GetUserTeamSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(mockStorage, events.Discard)
+	ctx := context.Background()
+
+	t.Run("user without team gets defaults", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetUserTeamSettings("u1").
+			Return(nil, repository.ErrNotFound)
+
+		got, err := usecase.GetUserTeamSettings(ctx, "u1")
+		require.NoError(t, err)
+		require.Equal(t, DefaultSettings, *got)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetUserTeamSettings("u1").
+			Return(nil, errors.New("db fail"))
+
+		got, err := usecase.GetUserTeamSettings(ctx, "u1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "db fail")
+		require.Nil(t, got)
+	})
+}
+
+func TestUsecase_UpdateTeamSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(mockStorage, events.Discard)
+	ctx := context.Background()
+
+	one := 1
+	three := 3
+
+	t.Run("success", func(t *testing.T) {
+		update := SettingsUpdate{TeamName: "dream", MinReviewers: &one}
+		mockStorage.EXPECT().
+			GetTeamSettings("dream").
+			Return(&repository.TeamSettings{TeamName: "dream"}, nil)
+		mockStorage.EXPECT().
+			UpdateTeamSettings(repository.TeamSettings(update)).
+			Return(&repository.TeamSettings{TeamName: "dream", MinReviewers: &one}, nil)
+
+		got, err := usecase.UpdateTeamSettings(ctx, update)
+		require.NoError(t, err)
+		require.Equal(t, 1, got.MinReviewers)
+		require.Equal(t, DefaultSettings.ReviewersCount, got.ReviewersCount)
+	})
+
+	t.Run("min reviewers exceed reviewers count", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetTeamSettings("dream").
+			Return(&repository.TeamSettings{TeamName: "dream", ReviewersCount: &one}, nil)
+
+		got, err := usecase.UpdateTeamSettings(ctx, SettingsUpdate{TeamName: "dream", MinReviewers: &three})
+		require.ErrorIs(t, err, ErrInvalidSettings)
+		require.Nil(t, got)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetTeamSettings("dream").
+			Return(nil, repository.ErrNotFound)
+
+		got, err := usecase.UpdateTeamSettings(ctx, SettingsUpdate{TeamName: "dream"})
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Nil(t, got)
+	})
+}
+
+func TestUsecase_ListTeams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(mockStorage, events.Discard)
+	ctx := context.Background()
+
+	t.Run("default limit", func(t *testing.T) {
+		mockStorage.EXPECT().
+			ListTeams(repository.ListTeamsFilter{NamePrefix: "back", Limit: DefaultListLimit}).
+			Return(&repository.TeamsPage{
+				Teams: []repository.TeamSummary{
+					{TeamName: "backend", MembersCount: 3, ActiveMembersCount: 2, OpenPRsCount: 1},
+				},
+				Total: 1,
+			}, nil)
+
+		got, err := usecase.ListTeams(ctx, ListTeamsFilter{NamePrefix: "back"})
+		require.NoError(t, err)
+		require.Equal(t, 1, got.Total)
+		require.Equal(t, []TeamSummary{
+			{TeamName: "backend", MembersCount: 3, ActiveMembersCount: 2, OpenPRsCount: 1},
+		}, got.Teams)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mockStorage.EXPECT().
+			ListTeams(gomock.Any()).
+			Return(nil, errors.New("db fail"))
+
+		got, err := usecase.ListTeams(ctx, ListTeamsFilter{Limit: 5})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "db fail")
+		require.Nil(t, got)
+	})
+}
+
+func TestUsecase_SetMemberRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(mockStorage, events.Discard)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockStorage.EXPECT().
+			SetMemberRole("dream", "u1", RoleLead).
+			Return(&repository.TeamMember{UserID: "u1", Username: "Alice", IsActive: true, Role: RoleLead}, nil)
+
+		got, err := usecase.SetMemberRole(ctx, "dream", "u1", RoleLead)
+		require.NoError(t, err)
+		require.Equal(t, RoleLead, got.Role)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStorage.EXPECT().
+			SetMemberRole("dream", "u9", RoleLead).
+			Return(nil, repository.ErrNotFound)
+
+		got, err := usecase.SetMemberRole(ctx, "dream", "u9", RoleLead)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Nil(t, got)
+	})
+}
+
+func TestToStorageTeam_DefaultRole(t *testing.T) {
+	team := toStorageTeam(Team{
+		TeamName: "dream",
+		Members: []TeamMember{
+			{UserID: "u1"},
+			{UserID: "u2", Role: RoleLead},
+		},
+	})
+	require.Equal(t, RoleMember, team.Members[0].Role)
+	require.Equal(t, RoleLead, team.Members[1].Role)
+}
+
+func TestUsecase_ImportRoster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockstorage(ctrl)
+	usecase := NewUsecase(mockStorage, events.Discard)
+	ctx := context.Background()
+
+	rows := []RosterRow{
+		{Location: "line 2", TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: "true", Role: "lead"},
+		{Location: "line 3", TeamName: "frontend", UserID: "u2", Username: "Bob", IsActive: "false"},
+	}
+	state := &repository.RosterState{
+		ExistingTeams: []string{"backend"},
+		Members: []repository.RosterMember{
+			{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, Role: RoleMember},
+		},
+	}
+	wantChanges := []RosterChange{
+		{Kind: ChangeSetRole, TeamName: "backend", UserID: "u1", Details: "role member -> lead"},
+		{Kind: ChangeCreateTeam, TeamName: "frontend"},
+		{Kind: ChangeCreateUser, TeamName: "frontend", UserID: "u2", Details: "username=Bob is_active=false role=member"},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetRosterState([]string{"backend", "frontend"}, []string{"u1", "u2"}, []string{"Alice", "Bob"}).
+			Return(state, nil)
+
+		res, err := usecase.ImportRoster(ctx, rows, true)
+		require.NoError(t, err)
+		require.False(t, res.Applied)
+		require.Empty(t, res.Errors)
+		require.Equal(t, wantChanges, res.Changes)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetRosterState(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(state, nil)
+		mockStorage.EXPECT().
+			ImportTeams([]repository.Team{
+				{TeamName: "backend", Members: []repository.TeamMember{
+					{UserID: "u1", Username: "Alice", IsActive: true, Role: RoleLead},
+				}},
+				{TeamName: "frontend", Members: []repository.TeamMember{
+					{UserID: "u2", Username: "Bob", IsActive: false, Role: RoleMember},
+				}},
+			}).
+			Return(nil)
+
+		res, err := usecase.ImportRoster(ctx, rows, false)
+		require.NoError(t, err)
+		require.True(t, res.Applied)
+		require.Equal(t, wantChanges, res.Changes)
+	})
+
+	t.Run("row errors block import", func(t *testing.T) {
+		invalid := []RosterRow{
+			{Location: "line 2", TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: "maybe"},
+			{Location: "line 3", TeamName: "backend", UserID: "u1", Username: "Alice2", IsActive: "true", Role: "boss"},
+			{Location: "line 4", TeamName: "backend", UserID: "u3", Username: "Carl", IsActive: "true"},
+		}
+		mockStorage.EXPECT().
+			GetRosterState(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&repository.RosterState{
+				Members: []repository.RosterMember{{UserID: "u9", Username: "Carl"}},
+			}, nil)
+
+		res, err := usecase.ImportRoster(ctx, invalid, false)
+		require.ErrorIs(t, err, ErrInvalidRoster)
+		require.False(t, res.Applied)
+		require.Equal(t, []RowError{
+			{Location: "line 2", Message: `is_active must be true or false, got "maybe"`},
+			{Location: "line 3", Message: `unknown role "boss"`},
+			{Location: "line 3", Message: "user_id u1 is already listed at line 2"},
+			{Location: "line 4", Message: "username Carl already belongs to user u9"},
+		}, res.Errors)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mockStorage.EXPECT().
+			GetRosterState(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("db fail"))
+
+		res, err := usecase.ImportRoster(ctx, rows, true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "db fail")
+		require.Nil(t, res)
+	})
+}
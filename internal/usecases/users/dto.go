@@ -1,5 +1,14 @@
 package users
 
+import "time"
+
+// Статусы записи истории ревью.
+const (
+	HistoryStatusActive     = "ACTIVE"
+	HistoryStatusMerged     = "MERGED"
+	HistoryStatusUnassigned = "UNASSIGNED"
+)
+
 // PullRequestShort - сокращенная информация о PR
 type PullRequestShort struct {
 	PullRequestID   string
@@ -10,8 +19,148 @@ type PullRequestShort struct {
 
 // User - пользователь системы
 type User struct {
-	UserID   string
-	Username string
+	UserID      string
+	Username    string
+	TeamName    string
+	IsActive    bool
+	Email       string
+	DisplayName string
+	// Timezone - часовой пояс IANA, например Europe/Moscow.
+	Timezone   string
+	ChatHandle string
+	// VCSLogin - логин во внешней системе контроля версий.
+	VCSLogin string
+	// GitLabID - числовой id пользователя в GitLab.
+	GitLabID string
+}
+
+// ProfileUpdate - изменение профиля. nil-поля не меняются, пустая строка очищает поле.
+type ProfileUpdate struct {
+	Username    *string
+	Email       *string
+	DisplayName *string
+	Timezone    *string
+	ChatHandle  *string
+	VCSLogin    *string
+	GitLabID    *string
+}
+
+type MoveTeamOpts struct {
+	UserID          string
+	TeamName        string
+	HandoverReviews bool
+}
+
+// ReviewHandover - PR, ревью которого передано другому пользователю.
+type ReviewHandover struct {
+	PullRequestID string
+	NewReviewerID string
+}
+
+type MoveTeamResult struct {
+	User       User
+	HandedOver []ReviewHandover
+	// KeptReviews - PR, для которых не нашлось замены, и ревью осталось за пользователем.
+	KeptReviews []string
+}
+
+// TeamChangeOpts - изменение состава команды. Create заводит команду, Delete удаляет ее вместе с составом.
+// Set заменяет состав целиком до Join/Leave, Leave остаются без команды.
+type TeamChangeOpts struct {
+	TeamName        string
+	Create          bool
+	Delete          bool
+	Set             *[]string
+	Join            []string
+	Leave           []string
+	HandoverReviews bool
+}
+
+type TeamChangeResult struct {
+	HandedOver []ReviewHandover
+	// KeptReviews - PR, для которых не нашлось замены, и ревью осталось за ушедшим пользователем.
+	KeptReviews []string
+}
+
+type ListUsersFilter struct {
 	TeamName string
-	IsActive bool
+	IsActive *bool
+	Limit    int
+	Offset   int
+}
+
+type UsersPage struct {
+	Users []User
+	Total int
+}
+
+type DeleteUserOpts struct {
+	UserID string
+	// ReassignReviews - передать открытые ревью сокомандникам вместо отказа.
+	ReassignReviews bool
+}
+
+type DeleteUserResult struct {
+	UserID     string
+	HandedOver []ReviewHandover
+}
+
+type ReviewHistoryFilter struct {
+	UserID string
+	Status string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+// ReviewHistoryEntry - назначение на ревью. UnassignedAt и Reason заданы, если ревьюера сняли с PR.
+type ReviewHistoryEntry struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	Status          string
+	AssignedAt      time.Time
+	UnassignedAt    *time.Time
+	Reason          string
+}
+
+type ReviewHistoryPage struct {
+	Entries []ReviewHistoryEntry
+	Total   int
+}
+
+// DashboardPR - PR на персональной панели. Wait считается от CreatedAt.
+type DashboardPR struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	Reviewers       []string
+	Status          string
+	CreatedAt       time.Time
+	MergedAt        *time.Time
+	Wait            time.Duration
+}
+
+type Dashboard struct {
+	UserID         string
+	Authored       []DashboardPR
+	AwaitingReview []DashboardPR
+	RecentlyMerged []DashboardPR
+}
+
+type EraseUserOpts struct {
+	UserID string
+	// RequestedBy и Reason попадают в журнал удаления.
+	RequestedBy     string
+	Reason          string
+	ReassignReviews bool
+}
+
+type EraseUserResult struct {
+	// PseudonymID - идентификатор, под которым осталась история PR пользователя.
+	PseudonymID       string
+	AuthoredPRs       int
+	ReviewAssignments int
+	HandedOver        []ReviewHandover
 }
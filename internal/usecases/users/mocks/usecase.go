@@ -11,7 +11,9 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
+	events "github.com/qwerty268/pull_request_service/internal/events"
 	storage "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/storage"
 	storage0 "github.com/qwerty268/pull_request_service/internal/usecases/users/storage"
 	gomock "go.uber.org/mock/gomock"
@@ -41,21 +43,127 @@ func (m *MockuserStorage) EXPECT() *MockuserStorageMockRecorder {
 	return m.recorder
 }
 
-// SetUserActive mocks base method.
-func (m *MockuserStorage) SetUserActive(userID string, isActive bool) (*storage0.User, error) {
+// ChangeTeam mocks base method.
+func (m *MockuserStorage) ChangeTeam(change storage0.TeamChange, plan storage0.HandoverPlanner) (*storage0.TeamChangeResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetUserActive", userID, isActive)
+	ret := m.ctrl.Call(m, "ChangeTeam", change, plan)
+	ret0, _ := ret[0].(*storage0.TeamChangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeTeam indicates an expected call of ChangeTeam.
+func (mr *MockuserStorageMockRecorder) ChangeTeam(change, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTeam", reflect.TypeOf((*MockuserStorage)(nil).ChangeTeam), change, plan)
+}
+
+// DeleteUser mocks base method.
+func (m *MockuserStorage) DeleteUser(userID string, plan storage0.HandoverPlanner) ([]storage0.ReviewHandover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", userID, plan)
+	ret0, _ := ret[0].([]storage0.ReviewHandover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockuserStorageMockRecorder) DeleteUser(userID, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockuserStorage)(nil).DeleteUser), userID, plan)
+}
+
+// EraseUser mocks base method.
+func (m *MockuserStorage) EraseUser(userID, pseudonymID string, plan storage0.HandoverPlanner, audit storage0.ErasureAudit) (*storage0.ErasureAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", userID, pseudonymID, plan, audit)
+	ret0, _ := ret[0].(*storage0.ErasureAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockuserStorageMockRecorder) EraseUser(userID, pseudonymID, plan, audit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockuserStorage)(nil).EraseUser), userID, pseudonymID, plan, audit)
+}
+
+// GetUser mocks base method.
+func (m *MockuserStorage) GetUser(userID string) (*storage0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", userID)
 	ret0, _ := ret[0].(*storage0.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserStorageMockRecorder) GetUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserStorage)(nil).GetUser), userID)
+}
+
+// ListUsers mocks base method.
+func (m *MockuserStorage) ListUsers(filter storage0.ListUsersFilter) (*storage0.UsersPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", filter)
+	ret0, _ := ret[0].(*storage0.UsersPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockuserStorageMockRecorder) ListUsers(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockuserStorage)(nil).ListUsers), filter)
+}
+
+// MoveUserToTeam mocks base method.
+func (m *MockuserStorage) MoveUserToTeam(userID, teamName string, plan storage0.HandoverPlanner) (*storage0.TeamMove, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveUserToTeam", userID, teamName, plan)
+	ret0, _ := ret[0].(*storage0.TeamMove)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveUserToTeam indicates an expected call of MoveUserToTeam.
+func (mr *MockuserStorageMockRecorder) MoveUserToTeam(userID, teamName, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveUserToTeam", reflect.TypeOf((*MockuserStorage)(nil).MoveUserToTeam), userID, teamName, plan)
+}
+
+// SetUserActive mocks base method.
+func (m *MockuserStorage) SetUserActive(userID string, isActive bool) (*storage0.User, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActive", userID, isActive)
+	ret0, _ := ret[0].(*storage0.User)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // SetUserActive indicates an expected call of SetUserActive.
 func (mr *MockuserStorageMockRecorder) SetUserActive(userID, isActive any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockuserStorage)(nil).SetUserActive), userID, isActive)
 }
 
+// UpdateProfile mocks base method.
+func (m *MockuserStorage) UpdateProfile(userID string, update storage0.ProfileUpdate) (*storage0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", userID, update)
+	ret0, _ := ret[0].(*storage0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockuserStorageMockRecorder) UpdateProfile(userID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockuserStorage)(nil).UpdateProfile), userID, update)
+}
+
 // MockprStorage is a mock of prStorage interface.
 type MockprStorage struct {
 	ctrl     *gomock.Controller
@@ -80,6 +188,36 @@ func (m *MockprStorage) EXPECT() *MockprStorageMockRecorder {
 	return m.recorder
 }
 
+// GetUserDashboard mocks base method.
+func (m *MockprStorage) GetUserDashboard(userID string, mergedSince time.Time, mergedLimit int) (*storage.Dashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDashboard", userID, mergedSince, mergedLimit)
+	ret0, _ := ret[0].(*storage.Dashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDashboard indicates an expected call of GetUserDashboard.
+func (mr *MockprStorageMockRecorder) GetUserDashboard(userID, mergedSince, mergedLimit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDashboard", reflect.TypeOf((*MockprStorage)(nil).GetUserDashboard), userID, mergedSince, mergedLimit)
+}
+
+// GetUserReviewHistory mocks base method.
+func (m *MockprStorage) GetUserReviewHistory(filter storage.ReviewHistoryFilter) (*storage.ReviewHistoryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReviewHistory", filter)
+	ret0, _ := ret[0].(*storage.ReviewHistoryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReviewHistory indicates an expected call of GetUserReviewHistory.
+func (mr *MockprStorageMockRecorder) GetUserReviewHistory(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviewHistory", reflect.TypeOf((*MockprStorage)(nil).GetUserReviewHistory), filter)
+}
+
 // GetUserReviewRequests mocks base method.
 func (m *MockprStorage) GetUserReviewRequests(userID string) ([]storage.PullRequestShort, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +232,39 @@ func (mr *MockprStorageMockRecorder) GetUserReviewRequests(userID any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviewRequests", reflect.TypeOf((*MockprStorage)(nil).GetUserReviewRequests), userID)
 }
+
+// MockeventPublisher is a mock of eventPublisher interface.
+type MockeventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockeventPublisherMockRecorder is the mock recorder for MockeventPublisher.
+type MockeventPublisherMockRecorder struct {
+	mock *MockeventPublisher
+}
+
+// NewMockeventPublisher creates a new mock instance.
+func NewMockeventPublisher(ctrl *gomock.Controller) *MockeventPublisher {
+	mock := &MockeventPublisher{ctrl: ctrl}
+	mock.recorder = &MockeventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventPublisher) EXPECT() *MockeventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockeventPublisher) Publish(ev events.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ev)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockeventPublisherMockRecorder) Publish(ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockeventPublisher)(nil).Publish), ev)
+}
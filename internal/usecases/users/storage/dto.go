@@ -1,8 +1,113 @@
 package storage
 
+import "time"
+
 type User struct {
+	UserID      string
+	Username    string
+	TeamName    string
+	IsActive    bool
+	Email       string
+	DisplayName string
+	Timezone    string
+	ChatHandle  string
+	VCSLogin    string
+	GitLabID    string
+}
+
+// fields - указатели на поля в порядке userColumns.
+func (u *User) fields() []any {
+	return []any{
+		&u.UserID,
+		&u.Username,
+		&u.TeamName,
+		&u.IsActive,
+		&u.Email,
+		&u.DisplayName,
+		&u.Timezone,
+		&u.ChatHandle,
+		&u.VCSLogin,
+		&u.GitLabID,
+	}
+}
+
+// ProfileUpdate - изменение профиля. nil-поля не меняются.
+type ProfileUpdate struct {
+	Username    *string
+	Email       *string
+	DisplayName *string
+	Timezone    *string
+	ChatHandle  *string
+	VCSLogin    *string
+	GitLabID    *string
+}
+
+// ReviewHandover - передача ревью PR от одного пользователя другому.
+type ReviewHandover struct {
+	PullRequestID string
+	FromUserID    string
+	ToUserID      string
+}
+
+// OpenReview - открытый PR, где пользователь назначен ревьюером.
+type OpenReview struct {
+	PullRequestID string
+	AuthorID      string
+	Reviewers     []string
+}
+
+// HandoverPlanner подбирает замену для открытых ревью пользователя userID среди активных сокомандников.
+// Ревью, которые некому передать, возвращаются в kept.
+type HandoverPlanner func(userID string, teammates []string, reviews []OpenReview) (handovers []ReviewHandover, kept []string)
+
+// TeamMove - результат перевода пользователя в другую команду.
+type TeamMove struct {
+	User        User
+	HandedOver  []ReviewHandover
+	KeptReviews []string
+}
+
+// TeamChange - изменение состава команды. Create заводит команду, Delete удаляет ее вместе со всем составом.
+// Set заменяет состав целиком и применяется до Join/Leave. Join переводятся в команду из прежних,
+// Leave остаются без команды.
+type TeamChange struct {
+	TeamName string
+	Create   bool
+	Delete   bool
+	Set      *[]string
+	Join     []string
+	Leave    []string
+}
+
+// TeamChangeResult - ревью, переданные и оставшиеся у пользователей, ушедших из своих команд.
+type TeamChangeResult struct {
+	HandedOver  []ReviewHandover
+	KeptReviews []string
+}
+
+// ErasureAudit - запись журнала об удалении персональных данных пользователя.
+type ErasureAudit struct {
+	PseudonymID       string
+	RequestedBy       string
+	Reason            string
+	AuthoredPRs       int
+	ReviewAssignments int
+	ErasedAt          time.Time
+	// HandedOver - ревью, переданные сокомандникам перед удалением, в журнал не пишутся.
+	HandedOver []ReviewHandover
+}
+
+type ListUsersFilter struct {
 	UserID   string
 	Username string
 	TeamName string
-	IsActive bool
+	IsActive *bool
+	Limit    int
+	Offset   int
+}
+
+type UsersPage struct {
+	Users []User
+	// Total - сколько всего пользователей подходит под фильтр без учета пагинации.
+	Total int
 }
@@ -4,17 +4,40 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/qwerty268/pull_request_service/internal/assignment"
+	"github.com/qwerty268/pull_request_service/internal/metrics"
 )
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrTeamNotFound  = errors.New("team not found")
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrTeamExists - команда с таким именем уже есть.
+	ErrTeamExists = errors.New("team already exists")
+	// ErrHasReferences - на пользователя ссылаются PR, удалить его нельзя.
+	ErrHasReferences = errors.New("has references")
+	// ErrHasOpenPRs - пользователь автор открытых PR.
+	ErrHasOpenPRs = errors.New("has open pull requests")
+	// ErrHasOpenReviews - у пользователя остались открытые ревью, которые не передали.
+	ErrHasOpenReviews = errors.New("has open reviews")
+)
 
 type Storage struct {
 	db    *sqlx.DB
 	close func() error
 }
 
+// userColumns - колонки пользователя в порядке User.fields. Необязательные поля профиля приводятся к пустой строке.
+const userColumns = `user_id, username, COALESCE(team_name, ''), is_active,
+	COALESCE(email, ''), COALESCE(display_name, ''), COALESCE(timezone, ''),
+	COALESCE(chat_handle, ''), COALESCE(vcs_login, ''), COALESCE(gitlab_user_id, '')`
+
 func NewStorage(db *sqlx.DB) *Storage {
 	return &Storage{
 		db: db,
@@ -23,31 +46,38 @@ func NewStorage(db *sqlx.DB) *Storage {
 		},
 	}
 }
-func (s *Storage) SetUserActive(userID string, isActive bool) (*User, error) {
+
+// SetUserActive меняет флаг активности. Второе значение - отличался ли прежний флаг от нового.
+func (s *Storage) SetUserActive(userID string, isActive bool) (*User, bool, error) {
+	defer metrics.ObserveQuery("users", "SetUserActive", time.Now())
+
+	// Прежнее значение читается под блокировкой строки, чтобы параллельные вызовы не сочли изменение своим оба.
 	query := `
+		WITH prev AS (
+			SELECT is_active AS was_active FROM "user" WHERE user_id = $1 FOR UPDATE
+		)
 		UPDATE "user"
 		SET is_active = $2
+		FROM prev
 		WHERE user_id = $1
-		RETURNING user_id, username, team_name, is_active;
+		RETURNING ` + userColumns + `, was_active;
 	`
 
-	var user User
-	err := s.db.QueryRow(query, userID, isActive).Scan(
-		&user.UserID,
-		&user.Username,
-		&user.TeamName,
-		&user.IsActive,
-	)
+	var updated User
+	var wasActive bool
+	err := s.db.QueryRow(query, userID, isActive).Scan(append(updated.fields(), &wasActive)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrNotFound
+			return nil, false, ErrNotFound
 		}
-		return nil, fmt.Errorf("SetUserActive query: %w", err)
+		return nil, false, fmt.Errorf("SetUserActive query: %w", err)
 	}
-	return &user, nil
+	return &updated, wasActive != isActive, nil
 }
 
 func (s *Storage) CheckUserExists(userID string) (bool, error) {
+	defer metrics.ObserveQuery("users", "CheckUserExists", time.Now())
+
 	query := `SELECT user_id FROM "user" WHERE user_id = $1`
 	var id string
 	err := s.db.QueryRow(query, userID).Scan(&id)
@@ -59,3 +89,698 @@ func (s *Storage) CheckUserExists(userID string) (bool, error) {
 	}
 	return true, nil
 }
+
+func (s *Storage) GetUser(userID string) (*User, error) {
+	defer metrics.ObserveQuery("users", "GetUser", time.Now())
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM "user"
+		WHERE user_id = $1
+	`
+
+	user, err := scanUser(s.db.QueryRow(query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("GetUser query: %w", err)
+	}
+	return &user, nil
+}
+
+// MoveUserToTeam переводит пользователя в другую команду. Если задан plan, открытые ревью PR прежней
+// команды выбираются в той же транзакции, передаются по плану, и PR остаются заблокированными до коммита.
+func (s *Storage) MoveUserToTeam(userID, teamName string, plan HandoverPlanner) (*TeamMove, error) {
+	defer metrics.ObserveQuery("users", "MoveUserToTeam", time.Now())
+
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	oldTeam, err := lockUser(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	move, err := moveUser(tx, userID, oldTeam, teamName, plan, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return move, nil
+}
+
+// ChangeTeam применяет изменение состава команды одной транзакцией. Каждый затронутый пользователь
+// переводится так же, как в MoveUserToTeam: уходя из своей команды, он передает по plan открытые ревью
+// ее PR оставшимся активным участникам. Тем, кто уходит из команды вместе с ним, ревью не передаются.
+// Пользователи из Leave, которых нет в команде, пропускаются.
+func (s *Storage) ChangeTeam(change TeamChange, plan HandoverPlanner) (*TeamChangeResult, error) {
+	defer metrics.ObserveQuery("users", "ChangeTeam", time.Now())
+
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// 1. Заводим или блокируем команду, чтобы изменения одного состава шли по очереди.
+	if change.Create {
+		_, err = tx.Exec(`INSERT INTO team (team_name) VALUES ($1)`, change.TeamName)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return nil, ErrTeamExists
+			}
+			return nil, fmt.Errorf("insert team: %w", err)
+		}
+	} else {
+		// Удалению нужна полная блокировка, остальным хватает той, что не мешает проверке внешних ключей.
+		lock := "FOR NO KEY UPDATE"
+		if change.Delete {
+			lock = "FOR UPDATE"
+		}
+		var name string
+		err = tx.QueryRow(`SELECT team_name FROM team WHERE team_name = $1 `+lock, change.TeamName).Scan(&name)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrTeamNotFound
+			}
+			return nil, fmt.Errorf("select team: %w", err)
+		}
+	}
+
+	// 2. Состав читаем внутри транзакции, после блокировки команды.
+	members, err := teamMembers(tx, change.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	join, leave := change.Join, change.Leave
+	if change.Set != nil {
+		join = append(excludeIDs(*change.Set, members), join...)
+		leave = append(excludeIDs(members, *change.Set), leave...)
+	}
+	if change.Delete {
+		join, leave = nil, members
+	}
+
+	leaving := make(map[string]struct{}, len(leave))
+	for _, userID := range leave {
+		leaving[userID] = struct{}{}
+	}
+
+	result := &TeamChangeResult{
+		HandedOver:  make([]ReviewHandover, 0),
+		KeptReviews: make([]string, 0),
+	}
+
+	// 3. Переводим новых участников из их прежних команд.
+	for _, userID := range join {
+		oldTeam, err := lockUser(tx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("user %s: %w", userID, err)
+		}
+		move, err := moveUser(tx, userID, oldTeam, change.TeamName, plan, nil)
+		if err != nil {
+			return nil, err
+		}
+		result.HandedOver = append(result.HandedOver, move.HandedOver...)
+		result.KeptReviews = append(result.KeptReviews, move.KeptReviews...)
+	}
+
+	// 4. Убираем уходящих.
+	for _, userID := range leave {
+		oldTeam, err := lockUser(tx, userID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("user %s: %w", userID, err)
+		}
+		if oldTeam != change.TeamName {
+			continue
+		}
+		move, err := moveUser(tx, userID, oldTeam, "", plan, leaving)
+		if err != nil {
+			return nil, err
+		}
+		result.HandedOver = append(result.HandedOver, move.HandedOver...)
+		result.KeptReviews = append(result.KeptReviews, move.KeptReviews...)
+	}
+
+	if change.Delete {
+		if _, err := tx.Exec(`DELETE FROM team WHERE team_name = $1`, change.TeamName); err != nil {
+			return nil, fmt.Errorf("delete team: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return result, nil
+}
+
+// moveUser переводит заблокированного пользователя из oldTeam в teamName, пустая teamName - без команды.
+// Если задан plan, открытые ревью PR прежней команды передаются ее активным участникам, кроме leaving.
+func moveUser(tx *sqlx.Tx, userID, oldTeam, teamName string, plan HandoverPlanner, leaving map[string]struct{}) (*TeamMove, error) {
+	move := &TeamMove{
+		HandedOver:  make([]ReviewHandover, 0),
+		KeptReviews: make([]string, 0),
+	}
+
+	// 1. Планируем передачу, пока пользователь еще числится в прежней команде.
+	if plan != nil && oldTeam != "" && oldTeam != teamName {
+		teammates, err := activeTeammates(tx, userID, oldTeam)
+		if err != nil {
+			return nil, err
+		}
+		teammates = slices.DeleteFunc(teammates, func(id string) bool {
+			_, ok := leaving[id]
+			return ok
+		})
+		reviews, err := openReviews(tx, userID, oldTeam)
+		if err != nil {
+			return nil, err
+		}
+		move.HandedOver, move.KeptReviews = plan(userID, teammates, reviews)
+	}
+
+	// 2. Меняем команду в профиле пользователя.
+	var err error
+	move.User, err = scanUser(tx.QueryRow(`
+		UPDATE "user"
+		SET team_name = NULLIF($2, '')
+		WHERE user_id = $1
+		RETURNING `+userColumns, userID, teamName))
+	if err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	// 3. Убираем членство в прежних командах и добавляем в новую.
+	_, err = tx.Exec(`DELETE FROM team_user_map WHERE user_id = $1 AND team_name <> $2`, userID, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("delete team_user_map: %w", err)
+	}
+
+	if teamName != "" {
+		_, err = tx.Exec(`
+			INSERT INTO team_user_map (team_name, user_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, teamName, userID)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+				return nil, ErrTeamNotFound
+			}
+			return nil, fmt.Errorf("insert team_user_map: %w", err)
+		}
+	}
+
+	// 4. Передаем ревью бывшим сокомандникам.
+	if err := applyHandovers(tx, move.HandedOver); err != nil {
+		return nil, err
+	}
+	return move, nil
+}
+
+// teamMembers выдает участников команды.
+func teamMembers(tx *sqlx.Tx, teamName string) ([]string, error) {
+	members := make([]string, 0)
+	err := tx.Select(&members, `SELECT user_id FROM team_user_map WHERE team_name = $1 ORDER BY user_id`, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("select members: %w", err)
+	}
+	return members, nil
+}
+
+// excludeIDs возвращает элементы from, которых нет в exclude.
+func excludeIDs(from, exclude []string) []string {
+	res := make([]string, 0, len(from))
+	for _, id := range from {
+		if !slices.Contains(exclude, id) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// activeTeammates выдает активных участников команды, не включая самого пользователя.
+func activeTeammates(tx *sqlx.Tx, userID, teamName string) ([]string, error) {
+	rows, err := tx.Query(`
+		SELECT u.user_id
+		FROM "user" AS u
+		JOIN team_user_map AS tum ON tum.user_id = u.user_id
+		WHERE tum.team_name = $2 AND u.user_id <> $1 AND u.is_active
+	`, userID, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("select teammates: %w", err)
+	}
+	defer rows.Close()
+
+	teammates := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan teammate: %w", err)
+		}
+		teammates = append(teammates, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+	return teammates, nil
+}
+
+// openReviews выдает открытые PR, где пользователь назначен ревьюером. С teamName - только PR авторов этой команды.
+// Строки PR блокируются, чтобы ревьюеров не поменяли до применения передачи.
+func openReviews(tx *sqlx.Tx, userID, teamName string) ([]OpenReview, error) {
+	rows, err := tx.Query(`
+		SELECT
+			pr.pull_request_id,
+			pr.author_id,
+			ARRAY(
+				SELECT all_prm.user_id
+				FROM pr_reviewers_map AS all_prm
+				WHERE all_prm.pull_request_id = pr.pull_request_id
+			)
+		FROM pull_request AS pr
+		JOIN pr_reviewers_map AS prm ON prm.pull_request_id = pr.pull_request_id
+		WHERE prm.user_id = $1 AND NOT pr.is_merged
+		AND ($2 = '' OR EXISTS (
+			SELECT 1 FROM team_user_map AS tum
+			WHERE tum.user_id = pr.author_id AND tum.team_name = $2
+		))
+		ORDER BY pr.created_at
+		FOR UPDATE OF pr
+	`, userID, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("select open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := make([]OpenReview, 0)
+	for rows.Next() {
+		var r OpenReview
+		if err := rows.Scan(&r.PullRequestID, &r.AuthorID, pq.Array(&r.Reviewers)); err != nil {
+			return nil, fmt.Errorf("scan open review: %w", err)
+		}
+		reviews = append(reviews, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+	return reviews, nil
+}
+
+// CreateUser создает пользователя без команды.
+func (s *Storage) CreateUser(user User) error {
+	defer metrics.ObserveQuery("users", "CreateUser", time.Now())
+
+	_, err := s.db.Exec(`
+		INSERT INTO "user" (user_id, username, is_active, email, display_name, timezone, chat_handle, vcs_login, gitlab_user_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''))
+	`, user.UserID, user.Username, user.IsActive,
+		user.Email, user.DisplayName, user.Timezone, user.ChatHandle, user.VCSLogin, user.GitLabID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("CreateUser: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) UpdateUsername(userID, username string) (*User, error) {
+	defer metrics.ObserveQuery("users", "UpdateUsername", time.Now())
+
+	query := `
+		UPDATE "user"
+		SET username = $2
+		WHERE user_id = $1
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(s.db.QueryRow(query, userID, username))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("UpdateUsername: %w", err)
+	}
+	return &user, nil
+}
+
+// UpdateProfile меняет поля профиля. nil-поля не меняются, пустая строка очищает поле.
+func (s *Storage) UpdateProfile(userID string, update ProfileUpdate) (*User, error) {
+	defer metrics.ObserveQuery("users", "UpdateProfile", time.Now())
+
+	query := `
+		UPDATE "user"
+		SET
+			username = COALESCE($2, username),
+			email = CASE WHEN $3::TEXT IS NULL THEN email ELSE NULLIF($3, '') END,
+			display_name = CASE WHEN $4::TEXT IS NULL THEN display_name ELSE NULLIF($4, '') END,
+			timezone = CASE WHEN $5::TEXT IS NULL THEN timezone ELSE NULLIF($5, '') END,
+			chat_handle = CASE WHEN $6::TEXT IS NULL THEN chat_handle ELSE NULLIF($6, '') END,
+			vcs_login = CASE WHEN $7::TEXT IS NULL THEN vcs_login ELSE NULLIF($7, '') END,
+			gitlab_user_id = CASE WHEN $8::TEXT IS NULL THEN gitlab_user_id ELSE NULLIF($8, '') END
+		WHERE user_id = $1
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(s.db.QueryRow(
+		query,
+		userID,
+		update.Username,
+		update.Email,
+		update.DisplayName,
+		update.Timezone,
+		update.ChatHandle,
+		update.VCSLogin,
+		update.GitLabID,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("UpdateProfile: %w", err)
+	}
+	return &user, nil
+}
+
+// ListUsers выдает страницу пользователей. Пустые поля фильтра не учитываются.
+func (s *Storage) ListUsers(filter ListUsersFilter) (*UsersPage, error) {
+	defer metrics.ObserveQuery("users", "ListUsers", time.Now())
+
+	query := `
+		SELECT
+			` + userColumns + `,
+			COUNT(*) OVER ()
+		FROM "user"
+		WHERE ($1 = '' OR user_id = $1)
+		AND ($2 = '' OR username = $2)
+		AND ($3 = '' OR team_name = $3)
+		AND ($4::BOOLEAN IS NULL OR is_active = $4)
+		ORDER BY user_id
+		LIMIT $5 OFFSET $6
+	`
+
+	rows, err := s.db.Query(
+		query,
+		filter.UserID,
+		filter.Username,
+		filter.TeamName,
+		filter.IsActive,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ListUsers: %w", err)
+	}
+	defer rows.Close()
+
+	page := &UsersPage{Users: make([]User, 0)}
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(append(user.fields(), &page.Total)...); err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+		page.Users = append(page.Users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %v", err)
+	}
+
+	// Страница за пределами выборки - общее количество считаем отдельно.
+	if len(page.Users) == 0 && filter.Offset > 0 {
+		err = s.db.QueryRow(`
+			SELECT COUNT(*)
+			FROM "user"
+			WHERE ($1 = '' OR user_id = $1)
+			AND ($2 = '' OR username = $2)
+			AND ($3 = '' OR team_name = $3)
+			AND ($4::BOOLEAN IS NULL OR is_active = $4)
+		`, filter.UserID, filter.Username, filter.TeamName, filter.IsActive).Scan(&page.Total)
+		if err != nil {
+			return nil, fmt.Errorf("ListUsers count: %w", err)
+		}
+	}
+	return page, nil
+}
+
+// DeleteUser удаляет пользователя вместе с членством в командах и назначениями на ревью.
+// Автор открытых PR не удаляется. Открытые ревью в той же транзакции передаются по plan,
+// без plan или если что-то передать не вышло удаление отклоняется с ErrHasOpenReviews.
+func (s *Storage) DeleteUser(userID string, plan HandoverPlanner) ([]ReviewHandover, error) {
+	defer metrics.ObserveQuery("users", "DeleteUser", time.Now())
+
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Блокировка строки пользователя не дает параллельно завести его PR или назначить его ревьюером.
+	teamName, err := lockUser(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var openPRs int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM pull_request WHERE author_id = $1 AND NOT is_merged`, userID).Scan(&openPRs)
+	if err != nil {
+		return nil, fmt.Errorf("count open PRs: %w", err)
+	}
+	if openPRs > 0 {
+		return nil, fmt.Errorf("%d open PRs: %w", openPRs, ErrHasOpenPRs)
+	}
+
+	handovers, err := handOverReviews(tx, userID, teamName, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	// pr_reviewers_map смерженных PR уйдет каскадом, из массива ревьюеров id убираем сами.
+	_, err = tx.Exec(`
+		UPDATE pull_request
+		SET assigned_reviewers = array_remove(assigned_reviewers, $1)
+		WHERE $1 = ANY(assigned_reviewers)
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("update pull_request reviewers: %w", err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM "user" WHERE user_id = $1`, userID)
+	if err != nil {
+		// Пользователь - автор PR.
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return nil, ErrHasReferences
+		}
+		return nil, fmt.Errorf("DeleteUser: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return handovers, nil
+}
+
+// EraseUser заменяет пользователя обезличенной записью pseudonymID. Ссылки из PR, ревью и истории
+// переводятся на псевдоним, членство в командах удаляется, в журнал пишется запись об удалении.
+// Все выполняется в одной транзакции вместе с передачей открытых ревью по plan, как в DeleteUser.
+func (s *Storage) EraseUser(userID, pseudonymID string, plan HandoverPlanner, audit ErasureAudit) (*ErasureAudit, error) {
+	defer metrics.ObserveQuery("users", "EraseUser", time.Now())
+
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	teamName, err := lockUser(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	audit.HandedOver, err = handOverReviews(tx, userID, teamName, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Заводим обезличенную запись: имя совпадает с псевдонимом, профиль пустой.
+	_, err = tx.Exec(`
+		INSERT INTO "user" (user_id, username, is_active)
+		VALUES ($1, $1, FALSE)
+	`, pseudonymID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("insert tombstone: %w", err)
+	}
+
+	// 2. Переводим ссылки на псевдоним.
+	res, err := tx.Exec(`UPDATE pull_request SET author_id = $2 WHERE author_id = $1`, userID, pseudonymID)
+	if err != nil {
+		return nil, fmt.Errorf("update pull_request author: %w", err)
+	}
+	authored, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE pull_request
+		SET assigned_reviewers = array_replace(assigned_reviewers, $1, $2)
+		WHERE $1 = ANY(assigned_reviewers)
+	`, userID, pseudonymID)
+	if err != nil {
+		return nil, fmt.Errorf("update pull_request reviewers: %w", err)
+	}
+
+	_, err = tx.Exec(`UPDATE pr_reviewers_map SET user_id = $2 WHERE user_id = $1`, userID, pseudonymID)
+	if err != nil {
+		return nil, fmt.Errorf("update pr_reviewers_map: %w", err)
+	}
+
+	res, err = tx.Exec(`UPDATE review_assignment SET user_id = $2 WHERE user_id = $1`, userID, pseudonymID)
+	if err != nil {
+		return nil, fmt.Errorf("update review_assignment: %w", err)
+	}
+	reviews, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected: %w", err)
+	}
+
+	// 3. Удаляем исходную запись, членство в командах уходит каскадом.
+	_, err = tx.Exec(`DELETE FROM "user" WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM user_activity WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user_activity: %w", err)
+	}
+
+	// 4. Пишем журнал.
+	audit.PseudonymID = pseudonymID
+	audit.AuthoredPRs = int(authored)
+	audit.ReviewAssignments = int(reviews)
+	_, err = tx.Exec(`
+		INSERT INTO user_erasure_audit
+			(pseudonym_id, requested_by, reason, authored_prs, review_assignments, erased_at)
+		VALUES
+			($1, $2, NULLIF($3, ''), $4, $5, $6)
+	`, audit.PseudonymID, audit.RequestedBy, audit.Reason, audit.AuthoredPRs, audit.ReviewAssignments, audit.ErasedAt)
+	if err != nil {
+		return nil, fmt.Errorf("insert audit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return &audit, nil
+}
+
+// lockUser блокирует строку пользователя до конца транзакции и выдает его команду.
+func lockUser(tx *sqlx.Tx, userID string) (string, error) {
+	var teamName string
+	err := tx.QueryRow(`SELECT COALESCE(team_name, '') FROM "user" WHERE user_id = $1 FOR UPDATE`, userID).Scan(&teamName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("select user: %w", err)
+	}
+	return teamName, nil
+}
+
+// handOverReviews передает все открытые ревью уходящего пользователя по plan его активным сокомандникам.
+// Если plan не задан или оставил часть ревью за пользователем, возвращается ErrHasOpenReviews.
+func handOverReviews(tx *sqlx.Tx, userID, teamName string, plan HandoverPlanner) ([]ReviewHandover, error) {
+	reviews, err := openReviews(tx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return make([]ReviewHandover, 0), nil
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("%d open reviews: %w", len(reviews), ErrHasOpenReviews)
+	}
+
+	teammates := make([]string, 0)
+	if teamName != "" {
+		teammates, err = activeTeammates(tx, userID, teamName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	handovers, kept := plan(userID, teammates, reviews)
+	if len(kept) > 0 {
+		return nil, fmt.Errorf("no candidate for %v: %w", kept, ErrHasOpenReviews)
+	}
+	if err := applyHandovers(tx, handovers); err != nil {
+		return nil, err
+	}
+	return handovers, nil
+}
+
+// applyHandovers переназначает ревью в pr_reviewers_map и в массиве assigned_reviewers.
+func applyHandovers(tx *sqlx.Tx, handovers []ReviewHandover) error {
+	for _, h := range handovers {
+		_, err := tx.Exec(`
+			UPDATE pr_reviewers_map
+			SET user_id = $3
+			WHERE pull_request_id = $1 AND user_id = $2
+		`, h.PullRequestID, h.FromUserID, h.ToUserID)
+		if err != nil {
+			return fmt.Errorf("update pr_reviewers_map: %w", err)
+		}
+
+		_, err = tx.Exec(`
+			UPDATE pull_request
+			SET assigned_reviewers = array_replace(assigned_reviewers, $2, $3)
+			WHERE pull_request_id = $1
+		`, h.PullRequestID, h.FromUserID, h.ToUserID)
+		if err != nil {
+			return fmt.Errorf("update pull_request: %w", err)
+		}
+
+		_, err = tx.Exec(`
+			UPDATE review_assignment
+			SET unassigned_at = now(), reason = $3
+			WHERE pull_request_id = $1 AND user_id = $2 AND unassigned_at IS NULL
+		`, h.PullRequestID, h.FromUserID, assignment.ReasonHandover)
+		if err != nil {
+			return fmt.Errorf("close review_assignment: %w", err)
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO review_assignment (pull_request_id, user_id, assigned_at)
+			VALUES ($1, $2, now())
+		`, h.PullRequestID, h.ToUserID)
+		if err != nil {
+			return fmt.Errorf("insert review_assignment: %w", err)
+		}
+	}
+	return nil
+}
+
+func scanUser(row *sql.Row) (User, error) {
+	var user User
+	err := row.Scan(user.fields()...)
+	return user, err
+}
@@ -4,14 +4,41 @@ package users
 
 import (
 	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/rand"
+	"time"
 
+	"github.com/qwerty268/pull_request_service/internal/events"
 	prRepository "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/storage"
 	userRepository "github.com/qwerty268/pull_request_service/internal/usecases/users/storage"
 )
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrTeamNotFound = errors.New("team not found")
+	// ErrTeamExists - команда с таким именем уже есть.
+	ErrTeamExists = errors.New("team already exists")
+	// ErrUsernameTaken - имя пользователя уже занято другим пользователем.
+	ErrUsernameTaken = errors.New("username taken")
+	// ErrHasOpenPRs - пользователь автор открытых PR.
+	ErrHasOpenPRs = errors.New("user has open pull requests")
+	// ErrHasOpenReviews - пользователь назначен ревьюером открытых PR, и их некому передать.
+	ErrHasOpenReviews = errors.New("user has open reviews")
+	// ErrHasHistory - пользователь автор смерженных PR, удалить его нельзя без потери истории.
+	ErrHasHistory = errors.New("user has pull request history")
+)
+
+// DefaultListLimit - размер страницы списка пользователей, если он не задан.
+const DefaultListLimit = 20
+
+const (
+	// DefaultMergedWindow - за какой период показываются смерженные PR на панели.
+	DefaultMergedWindow  = 14 * 24 * time.Hour
+	dashboardMergedLimit = 20
+)
 
 const (
 	statusOpen   = "OPEN"
@@ -19,27 +46,45 @@ const (
 )
 
 type userStorage interface {
-	SetUserActive(userID string, isActive bool) (*userRepository.User, error)
+	SetUserActive(userID string, isActive bool) (*userRepository.User, bool, error)
+	GetUser(userID string) (*userRepository.User, error)
+	// MoveUserToTeam атомарно меняет команду пользователя и передает ревью по plan, если он задан.
+	MoveUserToTeam(userID, teamName string, plan userRepository.HandoverPlanner) (*userRepository.TeamMove, error)
+	// ChangeTeam меняет состав команды одной транзакцией, переводя каждого пользователя как MoveUserToTeam.
+	ChangeTeam(change userRepository.TeamChange, plan userRepository.HandoverPlanner) (*userRepository.TeamChangeResult, error)
+	ListUsers(filter userRepository.ListUsersFilter) (*userRepository.UsersPage, error)
+	UpdateProfile(userID string, update userRepository.ProfileUpdate) (*userRepository.User, error)
+	// DeleteUser удаляет пользователя, в той же транзакции передав его открытые ревью по plan.
+	DeleteUser(userID string, plan userRepository.HandoverPlanner) ([]userRepository.ReviewHandover, error)
+	EraseUser(userID, pseudonymID string, plan userRepository.HandoverPlanner, audit userRepository.ErasureAudit) (*userRepository.ErasureAudit, error)
 }
 
 type prStorage interface {
 	GetUserReviewRequests(userID string) ([]prRepository.PullRequestShort, error)
+	GetUserReviewHistory(filter prRepository.ReviewHistoryFilter) (*prRepository.ReviewHistoryPage, error)
+	GetUserDashboard(userID string, mergedSince time.Time, mergedLimit int) (*prRepository.Dashboard, error)
+}
+
+type eventPublisher interface {
+	Publish(ev events.Event)
 }
 
 type Usecase struct {
 	userStorage userStorage
 	prStorage   prStorage
+	events      eventPublisher
 }
 
-func NewUsecase(storage userStorage, prStorage prStorage) Usecase {
+func NewUsecase(storage userStorage, prStorage prStorage, publisher eventPublisher) Usecase {
 	return Usecase{
 		userStorage: storage,
 		prStorage:   prStorage,
+		events:      publisher,
 	}
 }
 
 func (u Usecase) SetUserActive(_ context.Context, userID string, isActive bool) (*User, error) {
-	storageUser, err := u.userStorage.SetUserActive(userID, isActive)
+	storageUser, changed, err := u.userStorage.SetUserActive(userID, isActive)
 	if err != nil {
 		if errors.Is(err, userRepository.ErrNotFound) {
 			return nil, fmt.Errorf("failed to find user: %w", ErrNotFound)
@@ -47,9 +92,155 @@ func (u Usecase) SetUserActive(_ context.Context, userID string, isActive bool)
 		return nil, fmt.Errorf("failed to set user active: %v", err)
 	}
 	user := User(*storageUser)
+	// Повторная деактивация уже неактивного пользователя события не порождает.
+	if !isActive && changed {
+		u.events.Publish(events.Event{
+			Type:     events.UserDeactivated,
+			TeamName: user.TeamName,
+			UserIDs:  []string{user.UserID},
+			Data:     events.User{UserID: user.UserID, Username: user.Username, TeamName: user.TeamName},
+		})
+	}
+	return &user, nil
+}
+
+func (u Usecase) GetUser(_ context.Context, userID string) (*User, error) {
+	storageUser, err := u.userStorage.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, userRepository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to find user: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %v", err)
+	}
+	user := User(*storageUser)
 	return &user, nil
 }
 
+// UpdateProfile меняет поля профиля. Формат полей проверяется на уровне запроса.
+func (u Usecase) UpdateProfile(_ context.Context, userID string, update ProfileUpdate) (*User, error) {
+	storageUser, err := u.userStorage.UpdateProfile(userID, userRepository.ProfileUpdate(update))
+	if err != nil {
+		if errors.Is(err, userRepository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to find user: %w", ErrNotFound)
+		}
+		if errors.Is(err, userRepository.ErrAlreadyExists) {
+			return nil, fmt.Errorf("failed to update profile: %w", ErrUsernameTaken)
+		}
+		return nil, fmt.Errorf("failed to update profile: %v", err)
+	}
+	user := User(*storageUser)
+	return &user, nil
+}
+
+func (u Usecase) ListUsers(_ context.Context, filter ListUsersFilter) (*UsersPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+
+	storagePage, err := u.userStorage.ListUsers(userRepository.ListUsersFilter{
+		TeamName: filter.TeamName,
+		IsActive: filter.IsActive,
+		Limit:    filter.Limit,
+		Offset:   filter.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %v", err)
+	}
+
+	page := &UsersPage{
+		Users: make([]User, len(storagePage.Users)),
+		Total: storagePage.Total,
+	}
+	for i, v := range storagePage.Users {
+		page.Users[i] = User(v)
+	}
+	return page, nil
+}
+
+// DeleteUser удаляет пользователя. Автор открытых PR не удаляется.
+// Открытые ревью без ReassignReviews блокируют удаление, с ним - передаются сокомандникам.
+// Проверки и передача идут в одной транзакции с удалением.
+func (u Usecase) DeleteUser(_ context.Context, opts DeleteUserOpts) (*DeleteUserResult, error) {
+	handovers, err := u.userStorage.DeleteUser(opts.UserID, removalPlanner(opts.ReassignReviews))
+	if err != nil {
+		return nil, removalError("failed to delete user", err)
+	}
+
+	return &DeleteUserResult{
+		UserID:     opts.UserID,
+		HandedOver: fromStorageHandovers(handovers),
+	}, nil
+}
+
+// EraseUser удаляет персональные данные пользователя. История PR сохраняется под псевдонимом,
+// открытые ревью без ReassignReviews блокируют удаление, как и в DeleteUser.
+func (u Usecase) EraseUser(_ context.Context, opts EraseUserOpts) (*EraseUserResult, error) {
+	pseudonymID, err := NewPseudonymID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate pseudonym: %v", err)
+	}
+
+	audit, err := u.userStorage.EraseUser(opts.UserID, pseudonymID, removalPlanner(opts.ReassignReviews), userRepository.ErasureAudit{
+		RequestedBy: opts.RequestedBy,
+		Reason:      opts.Reason,
+		ErasedAt:    Now(),
+	})
+	if err != nil {
+		return nil, removalError("failed to erase user", err)
+	}
+
+	return &EraseUserResult{
+		PseudonymID:       audit.PseudonymID,
+		AuthoredPRs:       audit.AuthoredPRs,
+		ReviewAssignments: audit.ReviewAssignments,
+		HandedOver:        fromStorageHandovers(audit.HandedOver),
+	}, nil
+}
+
+// removalPlanner - план передачи ревью удаляемого пользователя. Без reassign ревью не передаются,
+// и storage отклоняет удаление, если они есть.
+func removalPlanner(reassign bool) userRepository.HandoverPlanner {
+	if !reassign {
+		return nil
+	}
+	return pickHandovers
+}
+
+func removalError(msg string, err error) error {
+	switch {
+	case errors.Is(err, userRepository.ErrNotFound):
+		return fmt.Errorf("%s: %w", msg, ErrNotFound)
+	case errors.Is(err, userRepository.ErrHasOpenPRs):
+		return fmt.Errorf("%s: %v: %w", msg, err, ErrHasOpenPRs)
+	case errors.Is(err, userRepository.ErrHasOpenReviews):
+		return fmt.Errorf("%s: %v: %w", msg, err, ErrHasOpenReviews)
+	case errors.Is(err, userRepository.ErrHasReferences):
+		return fmt.Errorf("%s: %w", msg, ErrHasHistory)
+	}
+	return fmt.Errorf("%s: %v", msg, err)
+}
+
+func fromStorageHandovers(handovers []userRepository.ReviewHandover) []ReviewHandover {
+	res := make([]ReviewHandover, len(handovers))
+	for i, h := range handovers {
+		res[i] = ReviewHandover{
+			PullRequestID: h.PullRequestID,
+			NewReviewerID: h.ToUserID,
+		}
+	}
+	return res
+}
+
+var NewPseudonymID = newPseudonymID // Чтобы тестить.
+
+func newPseudonymID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", err
+	}
+	return "erased-" + hex.EncodeToString(b), nil
+}
+
 func (u Usecase) GetUserReviewRequests(_ context.Context, userID string) ([]PullRequestShort, error) {
 	storagePrs, err := u.prStorage.GetUserReviewRequests(userID)
 	if err != nil {
@@ -61,6 +252,89 @@ func (u Usecase) GetUserReviewRequests(_ context.Context, userID string) ([]Pull
 	return fromStoragePrs(storagePrs), nil
 }
 
+// GetReviewHistory выдает страницу назначений пользователя на ревью, включая снятые.
+func (u Usecase) GetReviewHistory(_ context.Context, filter ReviewHistoryFilter) (*ReviewHistoryPage, error) {
+	_, err := u.userStorage.GetUser(filter.UserID)
+	if err != nil {
+		if errors.Is(err, userRepository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to find user: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %v", err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+
+	storagePage, err := u.prStorage.GetUserReviewHistory(prRepository.ReviewHistoryFilter(filter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review history: %v", err)
+	}
+
+	page := &ReviewHistoryPage{
+		Entries: make([]ReviewHistoryEntry, len(storagePage.Entries)),
+		Total:   storagePage.Total,
+	}
+	for i, v := range storagePage.Entries {
+		page.Entries[i] = ReviewHistoryEntry(v)
+	}
+	return page, nil
+}
+
+// GetDashboard собирает персональную панель: авторские открытые PR, PR в ожидании ревью
+// пользователя и недавно смерженные авторские PR. Для открытых PR Wait - сколько PR уже ждет,
+// для смерженных - сколько он ждал до мержа.
+func (u Usecase) GetDashboard(_ context.Context, userID string, mergedWindow time.Duration) (*Dashboard, error) {
+	_, err := u.userStorage.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, userRepository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to find user: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %v", err)
+	}
+
+	if mergedWindow <= 0 {
+		mergedWindow = DefaultMergedWindow
+	}
+
+	now := Now()
+	storageDashboard, err := u.prStorage.GetUserDashboard(userID, now.Add(-mergedWindow), dashboardMergedLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dashboard: %v", err)
+	}
+
+	return &Dashboard{
+		UserID:         userID,
+		Authored:       fromStorageDashboardPRs(storageDashboard.Authored, now),
+		AwaitingReview: fromStorageDashboardPRs(storageDashboard.AwaitingReview, now),
+		RecentlyMerged: fromStorageDashboardPRs(storageDashboard.RecentlyMerged, now),
+	}, nil
+}
+
+var Now = time.Now // Чтобы тестить.
+
+func fromStorageDashboardPRs(storagePrs []prRepository.DashboardPR, now time.Time) []DashboardPR {
+	prs := make([]DashboardPR, len(storagePrs))
+	for i, v := range storagePrs {
+		prs[i] = DashboardPR{
+			PullRequestID:   v.PullRequestID,
+			PullRequestName: v.PullRequestName,
+			AuthorID:        v.AuthorID,
+			Reviewers:       v.Reviewers,
+			CreatedAt:       v.CreatedAt,
+			Status:          statusOpen,
+			Wait:            now.Sub(v.CreatedAt),
+		}
+		if !v.MergedAt.IsZero() {
+			mergedAt := v.MergedAt
+			prs[i].Status = statusMerged
+			prs[i].MergedAt = &mergedAt
+			prs[i].Wait = mergedAt.Sub(v.CreatedAt)
+		}
+	}
+	return prs
+}
+
 func fromStoragePrs(storagePrs []prRepository.PullRequestShort) []PullRequestShort {
 	prs := make([]PullRequestShort, len(storagePrs))
 	for i, v := range storagePrs {
@@ -77,3 +351,100 @@ func fromStoragePrs(storagePrs []prRepository.PullRequestShort) []PullRequestSho
 	}
 	return prs
 }
+
+// MoveUserToTeam переводит пользователя в другую команду.
+// Если задан HandoverReviews, открытые ревью PR прежней команды передаются бывшим сокомандникам.
+func (u Usecase) MoveUserToTeam(_ context.Context, opts MoveTeamOpts) (*MoveTeamResult, error) {
+	var plan userRepository.HandoverPlanner
+	if opts.HandoverReviews {
+		plan = pickHandovers
+	}
+
+	move, err := u.userStorage.MoveUserToTeam(opts.UserID, opts.TeamName, plan)
+	if err != nil {
+		if errors.Is(err, userRepository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to move user: %w", ErrNotFound)
+		}
+		if errors.Is(err, userRepository.ErrTeamNotFound) {
+			return nil, fmt.Errorf("failed to move user: %w", ErrTeamNotFound)
+		}
+		return nil, fmt.Errorf("failed to move user: %v", err)
+	}
+
+	return &MoveTeamResult{
+		User:        User(move.User),
+		HandedOver:  fromStorageHandovers(move.HandedOver),
+		KeptReviews: move.KeptReviews,
+	}, nil
+}
+
+// ChangeTeam меняет состав команды, в том числе создает и удаляет ее, одной транзакцией.
+// Каждый затронутый пользователь переводится как в MoveUserToTeam: с HandoverReviews его открытые ревью
+// PR прежней команды передаются тем, кто в ней остается.
+func (u Usecase) ChangeTeam(_ context.Context, opts TeamChangeOpts) (*TeamChangeResult, error) {
+	var plan userRepository.HandoverPlanner
+	if opts.HandoverReviews {
+		plan = pickHandovers
+	}
+
+	change, err := u.userStorage.ChangeTeam(userRepository.TeamChange{
+		TeamName: opts.TeamName,
+		Create:   opts.Create,
+		Delete:   opts.Delete,
+		Set:      opts.Set,
+		Join:     opts.Join,
+		Leave:    opts.Leave,
+	}, plan)
+	if err != nil {
+		switch {
+		case errors.Is(err, userRepository.ErrNotFound):
+			return nil, fmt.Errorf("failed to change team: %v: %w", err, ErrNotFound)
+		case errors.Is(err, userRepository.ErrTeamNotFound):
+			return nil, fmt.Errorf("failed to change team: %w", ErrTeamNotFound)
+		case errors.Is(err, userRepository.ErrTeamExists):
+			return nil, fmt.Errorf("failed to change team: %w", ErrTeamExists)
+		}
+		return nil, fmt.Errorf("failed to change team: %v", err)
+	}
+
+	return &TeamChangeResult{
+		HandedOver:  fromStorageHandovers(change.HandedOver),
+		KeptReviews: change.KeptReviews,
+	}, nil
+}
+
+// pickHandovers выбирает для каждого ревью случайного сокомандника, который еще не автор и не ревьюер PR.
+func pickHandovers(userID string, teammates []string, reviews []userRepository.OpenReview) ([]userRepository.ReviewHandover, []string) {
+	handovers := make([]userRepository.ReviewHandover, 0, len(reviews))
+	kept := make([]string, 0)
+	for _, review := range reviews {
+		busy := map[string]struct{}{review.AuthorID: {}}
+		for _, reviewer := range review.Reviewers {
+			busy[reviewer] = struct{}{}
+		}
+
+		candidates := make([]string, 0, len(teammates))
+		for _, teammate := range teammates {
+			if _, ok := busy[teammate]; !ok {
+				candidates = append(candidates, teammate)
+			}
+		}
+		if len(candidates) == 0 {
+			kept = append(kept, review.PullRequestID)
+			continue
+		}
+
+		handovers = append(handovers, userRepository.ReviewHandover{
+			PullRequestID: review.PullRequestID,
+			FromUserID:    userID,
+			ToUserID:      GetRandomCandidate(candidates),
+		})
+	}
+	return handovers, kept
+}
+
+var GetRandomCandidate = getRandomCandidate // Чтобы тестить.
+
+func getRandomCandidate(candidates []string) string {
+	return candidates[rand.Intn(len(candidates))]
+}
@@ -2,13 +2,17 @@ package users
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"context"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/qwerty268/pull_request_service/internal/assignment"
+	"github.com/qwerty268/pull_request_service/internal/events"
 	prRepository "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests/storage"
 	"github.com/qwerty268/pull_request_service/internal/usecases/users/mocks"
 	userRepository "github.com/qwerty268/pull_request_service/internal/usecases/users/storage"
@@ -20,7 +24,7 @@ func TestUsecase_SetUserActive(t *testing.T) {
 
 	userStorage := mocks.NewMockuserStorage(ctrl)
 	prStorage := mocks.NewMockprStorage(ctrl)
-	usecase := NewUsecase(userStorage, prStorage)
+	usecase := NewUsecase(userStorage, prStorage, events.Discard)
 	ctx := context.Background()
 
 	expectedRepoUser := &userRepository.User{
@@ -33,7 +37,7 @@ func TestUsecase_SetUserActive(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		userStorage.EXPECT().
 			SetUserActive("u10", true).
-			Return(expectedRepoUser, nil)
+			Return(expectedRepoUser, true, nil)
 		user, err := usecase.SetUserActive(ctx, "u10", true)
 		require.NoError(t, err)
 		require.NotNil(t, user)
@@ -43,7 +47,7 @@ func TestUsecase_SetUserActive(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		userStorage.EXPECT().
 			SetUserActive("u99", true).
-			Return(nil, userRepository.ErrNotFound)
+			Return(nil, false, userRepository.ErrNotFound)
 		user, err := usecase.SetUserActive(ctx, "u99", true)
 		require.ErrorIs(t, err, ErrNotFound)
 		require.Contains(t, err.Error(), "failed to find user")
@@ -53,13 +57,48 @@ func TestUsecase_SetUserActive(t *testing.T) {
 	t.Run("other storage error", func(t *testing.T) {
 		userStorage.EXPECT().
 			SetUserActive("u10", false).
-			Return(nil, errors.New("db down"))
+			Return(nil, false, errors.New("db down"))
 		user, err := usecase.SetUserActive(ctx, "u10", false)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed to set user active")
 		require.Contains(t, err.Error(), "db down")
 		require.Nil(t, user)
 	})
+
+	t.Run("deactivation publishes event", func(t *testing.T) {
+		mockEvents := mocks.NewMockeventPublisher(ctrl)
+		uc := NewUsecase(userStorage, prStorage, mockEvents)
+
+		inactive := *expectedRepoUser
+		inactive.IsActive = false
+		userStorage.EXPECT().
+			SetUserActive("u10", false).
+			Return(&inactive, true, nil)
+		mockEvents.EXPECT().Publish(events.Event{
+			Type:     events.UserDeactivated,
+			TeamName: "avengers",
+			UserIDs:  []string{"u10"},
+			Data:     events.User{UserID: "u10", Username: "Bruce", TeamName: "avengers"},
+		})
+
+		_, err := uc.SetUserActive(ctx, "u10", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("already inactive user publishes nothing", func(t *testing.T) {
+		mockEvents := mocks.NewMockeventPublisher(ctrl)
+		uc := NewUsecase(userStorage, prStorage, mockEvents)
+
+		inactive := *expectedRepoUser
+		inactive.IsActive = false
+		userStorage.EXPECT().
+			SetUserActive("u10", false).
+			Return(&inactive, false, nil)
+
+		user, err := uc.SetUserActive(ctx, "u10", false)
+		require.NoError(t, err)
+		require.False(t, user.IsActive)
+	})
 }
 
 func TestUsecase_GetUserReviewRequests(t *testing.T) {
@@ -68,7 +107,7 @@ func TestUsecase_GetUserReviewRequests(t *testing.T) {
 
 	userStorage := mocks.NewMockuserStorage(ctrl)
 	prStorage := mocks.NewMockprStorage(ctrl)
-	usecase := NewUsecase(userStorage, prStorage)
+	usecase := NewUsecase(userStorage, prStorage, events.Discard)
 	ctx := context.Background()
 
 	prs := []prRepository.PullRequestShort{
@@ -121,3 +160,479 @@ func TestUsecase_GetUserReviewRequests(t *testing.T) {
 		require.Nil(t, result)
 	})
 }
+
+func TestUsecase_MoveUserToTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	usecase := NewUsecase(userStorage, nil, events.Discard)
+	ctx := context.Background()
+
+	moved := userRepository.User{UserID: "u1", Username: "Bruce", TeamName: "xmen", IsActive: true}
+
+	t.Run("move without handover", func(t *testing.T) {
+		userStorage.EXPECT().
+			MoveUserToTeam("u1", "xmen", gomock.Nil()).
+			Return(&userRepository.TeamMove{User: moved, HandedOver: []userRepository.ReviewHandover{}, KeptReviews: []string{}}, nil)
+
+		res, err := usecase.MoveUserToTeam(ctx, MoveTeamOpts{UserID: "u1", TeamName: "xmen"})
+		require.NoError(t, err)
+		require.Equal(t, User(moved), res.User)
+		require.Empty(t, res.HandedOver)
+		require.Empty(t, res.KeptReviews)
+	})
+
+	t.Run("move with handover", func(t *testing.T) {
+		orig := GetRandomCandidate
+		GetRandomCandidate = func(c []string) string { return c[0] }
+		defer func() { GetRandomCandidate = orig }()
+
+		userStorage.EXPECT().
+			MoveUserToTeam("u1", "xmen", gomock.Not(gomock.Nil())).
+			DoAndReturn(func(_, _ string, plan userRepository.HandoverPlanner) (*userRepository.TeamMove, error) {
+				handovers, kept := plan("u1", []string{"tony", "steve"}, []userRepository.OpenReview{
+					{PullRequestID: "pr1", AuthorID: "tony", Reviewers: []string{"u1"}},
+					{PullRequestID: "pr2", AuthorID: "tony", Reviewers: []string{"u1", "steve"}},
+				})
+				require.Equal(t, []userRepository.ReviewHandover{
+					{PullRequestID: "pr1", FromUserID: "u1", ToUserID: "steve"},
+				}, handovers)
+				return &userRepository.TeamMove{User: moved, HandedOver: handovers, KeptReviews: kept}, nil
+			})
+
+		res, err := usecase.MoveUserToTeam(ctx, MoveTeamOpts{UserID: "u1", TeamName: "xmen", HandoverReviews: true})
+		require.NoError(t, err)
+		require.Equal(t, []ReviewHandover{{PullRequestID: "pr1", NewReviewerID: "steve"}}, res.HandedOver)
+		require.Equal(t, []string{"pr2"}, res.KeptReviews)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		userStorage.EXPECT().MoveUserToTeam("u9", "xmen", gomock.Nil()).Return(nil, userRepository.ErrNotFound)
+
+		res, err := usecase.MoveUserToTeam(ctx, MoveTeamOpts{UserID: "u9", TeamName: "xmen"})
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Nil(t, res)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		userStorage.EXPECT().MoveUserToTeam("u1", "nope", gomock.Nil()).Return(nil, userRepository.ErrTeamNotFound)
+
+		res, err := usecase.MoveUserToTeam(ctx, MoveTeamOpts{UserID: "u1", TeamName: "nope"})
+		require.ErrorIs(t, err, ErrTeamNotFound)
+		require.Nil(t, res)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		userStorage.EXPECT().MoveUserToTeam("u1", "xmen", gomock.Any()).Return(nil, errors.New("db down"))
+
+		res, err := usecase.MoveUserToTeam(ctx, MoveTeamOpts{UserID: "u1", TeamName: "xmen", HandoverReviews: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "db down")
+		require.Nil(t, res)
+	})
+}
+
+func TestUsecase_ChangeTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	usecase := NewUsecase(userStorage, nil, events.Discard)
+	ctx := context.Background()
+
+	t.Run("handover for every leaving user", func(t *testing.T) {
+		orig := GetRandomCandidate
+		GetRandomCandidate = func(c []string) string { return c[0] }
+		defer func() { GetRandomCandidate = orig }()
+
+		set := []string{"steve"}
+		userStorage.EXPECT().
+			ChangeTeam(userRepository.TeamChange{TeamName: "xmen", Set: &set, Leave: []string{"u2"}}, gomock.Not(gomock.Nil())).
+			DoAndReturn(func(_ userRepository.TeamChange, plan userRepository.HandoverPlanner) (*userRepository.TeamChangeResult, error) {
+				first, _ := plan("u1", []string{"steve"}, []userRepository.OpenReview{
+					{PullRequestID: "pr1", AuthorID: "tony", Reviewers: []string{"u1"}},
+				})
+				second, kept := plan("u2", []string{"steve"}, []userRepository.OpenReview{
+					{PullRequestID: "pr2", AuthorID: "steve", Reviewers: []string{"u2"}},
+				})
+				require.Equal(t, []userRepository.ReviewHandover{
+					{PullRequestID: "pr1", FromUserID: "u1", ToUserID: "steve"},
+				}, first)
+				return &userRepository.TeamChangeResult{HandedOver: append(first, second...), KeptReviews: kept}, nil
+			})
+
+		res, err := usecase.ChangeTeam(ctx, TeamChangeOpts{TeamName: "xmen", Set: &set, Leave: []string{"u2"}, HandoverReviews: true})
+		require.NoError(t, err)
+		require.Equal(t, []ReviewHandover{{PullRequestID: "pr1", NewReviewerID: "steve"}}, res.HandedOver)
+		require.Equal(t, []string{"pr2"}, res.KeptReviews)
+	})
+
+	t.Run("team exists", func(t *testing.T) {
+		userStorage.EXPECT().
+			ChangeTeam(userRepository.TeamChange{TeamName: "xmen", Create: true}, gomock.Nil()).
+			Return(nil, userRepository.ErrTeamExists)
+
+		res, err := usecase.ChangeTeam(ctx, TeamChangeOpts{TeamName: "xmen", Create: true})
+		require.ErrorIs(t, err, ErrTeamExists)
+		require.Nil(t, res)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		userStorage.EXPECT().
+			ChangeTeam(gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("user u9: %w", userRepository.ErrNotFound))
+
+		res, err := usecase.ChangeTeam(ctx, TeamChangeOpts{TeamName: "xmen", Join: []string{"u9"}, HandoverReviews: true})
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Contains(t, err.Error(), "u9")
+		require.Nil(t, res)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		userStorage.EXPECT().ChangeTeam(gomock.Any(), gomock.Any()).Return(nil, userRepository.ErrTeamNotFound)
+
+		res, err := usecase.ChangeTeam(ctx, TeamChangeOpts{TeamName: "nope", Delete: true})
+		require.ErrorIs(t, err, ErrTeamNotFound)
+		require.Nil(t, res)
+	})
+}
+
+func TestUsecase_ListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	usecase := NewUsecase(userStorage, nil, events.Discard)
+	ctx := context.Background()
+
+	isActive := true
+	userStorage.EXPECT().
+		ListUsers(userRepository.ListUsersFilter{TeamName: "avengers", IsActive: &isActive, Limit: DefaultListLimit}).
+		Return(&userRepository.UsersPage{
+			Users: []userRepository.User{{UserID: "u1", Username: "Bruce", TeamName: "avengers", IsActive: true}},
+			Total: 3,
+		}, nil)
+
+	page, err := usecase.ListUsers(ctx, ListUsersFilter{TeamName: "avengers", IsActive: &isActive})
+	require.NoError(t, err)
+	require.Equal(t, 3, page.Total)
+	require.Equal(t, []User{{UserID: "u1", Username: "Bruce", TeamName: "avengers", IsActive: true}}, page.Users)
+}
+
+func TestUsecase_DeleteUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	usecase := NewUsecase(userStorage, nil, events.Discard)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		userStorage.EXPECT().DeleteUser("u1", gomock.Nil()).Return([]userRepository.ReviewHandover{}, nil)
+
+		res, err := usecase.DeleteUser(ctx, DeleteUserOpts{UserID: "u1"})
+		require.NoError(t, err)
+		require.Equal(t, "u1", res.UserID)
+		require.Empty(t, res.HandedOver)
+	})
+
+	t.Run("authors open PRs", func(t *testing.T) {
+		userStorage.EXPECT().
+			DeleteUser("u1", gomock.Any()).
+			Return(nil, fmt.Errorf("2 open PRs: %w", userRepository.ErrHasOpenPRs))
+
+		res, err := usecase.DeleteUser(ctx, DeleteUserOpts{UserID: "u1", ReassignReviews: true})
+		require.ErrorIs(t, err, ErrHasOpenPRs)
+		require.Nil(t, res)
+	})
+
+	t.Run("open reviews without reassign", func(t *testing.T) {
+		userStorage.EXPECT().
+			DeleteUser("u1", gomock.Nil()).
+			Return(nil, fmt.Errorf("1 open reviews: %w", userRepository.ErrHasOpenReviews))
+
+		res, err := usecase.DeleteUser(ctx, DeleteUserOpts{UserID: "u1"})
+		require.ErrorIs(t, err, ErrHasOpenReviews)
+		require.Nil(t, res)
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		orig := GetRandomCandidate
+		GetRandomCandidate = func(c []string) string { return c[0] }
+		defer func() { GetRandomCandidate = orig }()
+
+		userStorage.EXPECT().
+			DeleteUser("u1", gomock.Not(gomock.Nil())).
+			DoAndReturn(func(_ string, plan userRepository.HandoverPlanner) ([]userRepository.ReviewHandover, error) {
+				handovers, kept := plan("u1", []string{"tony", "steve"}, []userRepository.OpenReview{
+					{PullRequestID: "pr1", AuthorID: "tony", Reviewers: []string{"u1"}},
+				})
+				require.Empty(t, kept)
+				return handovers, nil
+			})
+
+		res, err := usecase.DeleteUser(ctx, DeleteUserOpts{UserID: "u1", ReassignReviews: true})
+		require.NoError(t, err)
+		require.Equal(t, []ReviewHandover{{PullRequestID: "pr1", NewReviewerID: "steve"}}, res.HandedOver)
+	})
+
+	t.Run("reassign without candidates", func(t *testing.T) {
+		userStorage.EXPECT().
+			DeleteUser("u1", gomock.Not(gomock.Nil())).
+			DoAndReturn(func(_ string, plan userRepository.HandoverPlanner) ([]userRepository.ReviewHandover, error) {
+				_, kept := plan("u1", []string{"tony"}, []userRepository.OpenReview{
+					{PullRequestID: "pr1", AuthorID: "tony", Reviewers: []string{"u1"}},
+				})
+				require.Equal(t, []string{"pr1"}, kept)
+				return nil, fmt.Errorf("no candidate for %v: %w", kept, userRepository.ErrHasOpenReviews)
+			})
+
+		res, err := usecase.DeleteUser(ctx, DeleteUserOpts{UserID: "u1", ReassignReviews: true})
+		require.ErrorIs(t, err, ErrHasOpenReviews)
+		require.Nil(t, res)
+	})
+
+	t.Run("merged history", func(t *testing.T) {
+		userStorage.EXPECT().DeleteUser("u1", gomock.Nil()).Return(nil, userRepository.ErrHasReferences)
+
+		_, err := usecase.DeleteUser(ctx, DeleteUserOpts{UserID: "u1"})
+		require.ErrorIs(t, err, ErrHasHistory)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		userStorage.EXPECT().DeleteUser("u9", gomock.Nil()).Return(nil, userRepository.ErrNotFound)
+
+		_, err := usecase.DeleteUser(ctx, DeleteUserOpts{UserID: "u9"})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUsecase_UpdateProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	usecase := NewUsecase(userStorage, nil, events.Discard)
+	ctx := context.Background()
+
+	email := "bruce@avengers.io"
+	timezone := "America/New_York"
+
+	t.Run("success", func(t *testing.T) {
+		updated := &userRepository.User{
+			UserID:   "u1",
+			Username: "Bruce",
+			IsActive: true,
+			Email:    email,
+			Timezone: timezone,
+		}
+		userStorage.EXPECT().
+			UpdateProfile("u1", userRepository.ProfileUpdate{Email: &email, Timezone: &timezone}).
+			Return(updated, nil)
+
+		user, err := usecase.UpdateProfile(ctx, "u1", ProfileUpdate{Email: &email, Timezone: &timezone})
+		require.NoError(t, err)
+		require.Equal(t, User(*updated), *user)
+	})
+
+	t.Run("username taken", func(t *testing.T) {
+		username := "Tony"
+		userStorage.EXPECT().
+			UpdateProfile("u1", userRepository.ProfileUpdate{Username: &username}).
+			Return(nil, userRepository.ErrAlreadyExists)
+
+		_, err := usecase.UpdateProfile(ctx, "u1", ProfileUpdate{Username: &username})
+		require.ErrorIs(t, err, ErrUsernameTaken)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		userStorage.EXPECT().
+			UpdateProfile("u9", gomock.Any()).
+			Return(nil, userRepository.ErrNotFound)
+
+		_, err := usecase.UpdateProfile(ctx, "u9", ProfileUpdate{Email: &email})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUsecase_GetReviewHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	prStorage := mocks.NewMockprStorage(ctrl)
+	usecase := NewUsecase(userStorage, prStorage, events.Discard)
+	ctx := context.Background()
+
+	assignedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	unassignedAt := assignedAt.Add(2 * time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		userStorage.EXPECT().GetUser("u1").Return(&userRepository.User{UserID: "u1"}, nil)
+		prStorage.EXPECT().
+			GetUserReviewHistory(prRepository.ReviewHistoryFilter{
+				UserID: "u1",
+				Status: HistoryStatusUnassigned,
+				Limit:  DefaultListLimit,
+			}).
+			Return(&prRepository.ReviewHistoryPage{
+				Entries: []prRepository.ReviewHistoryEntry{{
+					PullRequestID: "pr1",
+					AuthorID:      "u2",
+					Status:        prRepository.HistoryStatusUnassigned,
+					AssignedAt:    assignedAt,
+					UnassignedAt:  &unassignedAt,
+					Reason:        assignment.ReasonReassigned,
+				}},
+				Total: 1,
+			}, nil)
+
+		page, err := usecase.GetReviewHistory(ctx, ReviewHistoryFilter{UserID: "u1", Status: HistoryStatusUnassigned})
+		require.NoError(t, err)
+		require.Equal(t, 1, page.Total)
+		require.Equal(t, ReviewHistoryEntry{
+			PullRequestID: "pr1",
+			AuthorID:      "u2",
+			Status:        HistoryStatusUnassigned,
+			AssignedAt:    assignedAt,
+			UnassignedAt:  &unassignedAt,
+			Reason:        "reassigned",
+		}, page.Entries[0])
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		userStorage.EXPECT().GetUser("u9").Return(nil, userRepository.ErrNotFound)
+
+		_, err := usecase.GetReviewHistory(ctx, ReviewHistoryFilter{UserID: "u9"})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUsecase_GetDashboard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	prStorage := mocks.NewMockprStorage(ctrl)
+	usecase := NewUsecase(userStorage, prStorage, events.Discard)
+	ctx := context.Background()
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	orig := Now
+	Now = func() time.Time { return now }
+	defer func() { Now = orig }()
+
+	t.Run("success", func(t *testing.T) {
+		createdAt := now.Add(-3 * time.Hour)
+		mergedAt := now.Add(-24 * time.Hour)
+
+		userStorage.EXPECT().GetUser("u1").Return(&userRepository.User{UserID: "u1"}, nil)
+		prStorage.EXPECT().
+			GetUserDashboard("u1", now.Add(-DefaultMergedWindow), dashboardMergedLimit).
+			Return(&prRepository.Dashboard{
+				Authored: []prRepository.DashboardPR{
+					{PullRequestID: "pr1", AuthorID: "u1", Reviewers: []string{"u2"}, CreatedAt: createdAt},
+				},
+				AwaitingReview: []prRepository.DashboardPR{},
+				RecentlyMerged: []prRepository.DashboardPR{
+					{PullRequestID: "pr0", AuthorID: "u1", CreatedAt: mergedAt.Add(-2 * time.Hour), MergedAt: mergedAt},
+				},
+			}, nil)
+
+		dashboard, err := usecase.GetDashboard(ctx, "u1", 0)
+		require.NoError(t, err)
+		require.Equal(t, []DashboardPR{{
+			PullRequestID: "pr1",
+			AuthorID:      "u1",
+			Reviewers:     []string{"u2"},
+			Status:        statusOpen,
+			CreatedAt:     createdAt,
+			Wait:          3 * time.Hour,
+		}}, dashboard.Authored)
+		require.Empty(t, dashboard.AwaitingReview)
+		require.Equal(t, statusMerged, dashboard.RecentlyMerged[0].Status)
+		require.Equal(t, mergedAt, *dashboard.RecentlyMerged[0].MergedAt)
+		require.Equal(t, 2*time.Hour, dashboard.RecentlyMerged[0].Wait)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		userStorage.EXPECT().GetUser("u9").Return(nil, userRepository.ErrNotFound)
+
+		_, err := usecase.GetDashboard(ctx, "u9", time.Hour)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUsecase_EraseUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockuserStorage(ctrl)
+	usecase := NewUsecase(userStorage, nil, events.Discard)
+	ctx := context.Background()
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	origNow, origPseudonym := Now, NewPseudonymID
+	Now = func() time.Time { return now }
+	NewPseudonymID = func() (string, error) { return "erased-1", nil }
+	defer func() { Now, NewPseudonymID = origNow, origPseudonym }()
+
+	audit := userRepository.ErasureAudit{
+		RequestedBy: "dpo",
+		Reason:      "left the company",
+		ErasedAt:    now,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		orig := GetRandomCandidate
+		GetRandomCandidate = func(c []string) string { return c[0] }
+		defer func() { GetRandomCandidate = orig }()
+
+		userStorage.EXPECT().
+			EraseUser("u1", "erased-1", gomock.Not(gomock.Nil()), audit).
+			DoAndReturn(func(_, _ string, plan userRepository.HandoverPlanner, audit userRepository.ErasureAudit) (*userRepository.ErasureAudit, error) {
+				handovers, kept := plan("u1", []string{"steve"}, []userRepository.OpenReview{
+					{PullRequestID: "pr1", AuthorID: "tony", Reviewers: []string{"u1"}},
+				})
+				require.Empty(t, kept)
+				audit.PseudonymID = "erased-1"
+				audit.AuthoredPRs = 4
+				audit.ReviewAssignments = 7
+				audit.HandedOver = handovers
+				return &audit, nil
+			})
+
+		res, err := usecase.EraseUser(ctx, EraseUserOpts{
+			UserID:          "u1",
+			RequestedBy:     "dpo",
+			Reason:          "left the company",
+			ReassignReviews: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, &EraseUserResult{
+			PseudonymID:       "erased-1",
+			AuthoredPRs:       4,
+			ReviewAssignments: 7,
+			HandedOver:        []ReviewHandover{{PullRequestID: "pr1", NewReviewerID: "steve"}},
+		}, res)
+	})
+
+	t.Run("open reviews without reassign", func(t *testing.T) {
+		userStorage.EXPECT().
+			EraseUser("u1", "erased-1", gomock.Nil(), audit).
+			Return(nil, fmt.Errorf("1 open reviews: %w", userRepository.ErrHasOpenReviews))
+
+		_, err := usecase.EraseUser(ctx, EraseUserOpts{UserID: "u1", RequestedBy: "dpo", Reason: "left the company"})
+		require.ErrorIs(t, err, ErrHasOpenReviews)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		userStorage.EXPECT().
+			EraseUser("u9", "erased-1", gomock.Nil(), audit).
+			Return(nil, userRepository.ErrNotFound)
+
+		_, err := usecase.EraseUser(ctx, EraseUserOpts{UserID: "u9", RequestedBy: "dpo", Reason: "left the company"})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,44 @@
+package webhooks
+
+const (
+	SourceGitHub = "github"
+	SourceGitLab = "gitlab"
+)
+
+// Действия с PR во внешней системе, которые понимает HandlePullRequest.
+const (
+	ActionOpened   = "opened"
+	ActionReopened = "reopened"
+	ActionMerged   = "merged"
+	ActionClosed   = "closed"
+	// ActionUpdated - PR изменили, заводим его, если он открыт до подключения вебхука.
+	ActionUpdated = "updated"
+)
+
+// PullRequestEvent - событие PR внешней системы, уже переведенное в термины сервиса.
+type PullRequestEvent struct {
+	Source string
+	// DeliveryID - id доставки во внешней системе, повтор с тем же id не применяется.
+	DeliveryID    string
+	Action        string
+	PullRequestID string
+	Title         string
+	// Author - автор во внешней системе: логин GitHub сопоставляется с vcs_login пользователя,
+	// числовой id GitLab - с gitlab_user_id.
+	Author string
+}
+
+// Чем закончилась обработка события.
+const (
+	OutcomeCreated   = "created"
+	OutcomeMerged    = "merged"
+	OutcomeIgnored   = "ignored"
+	OutcomeDuplicate = "duplicate"
+)
+
+type Result struct {
+	Outcome       string
+	PullRequestID string
+	// Reason - почему событие пропущено, для журнала доставок во внешней системе.
+	Reason string
+}
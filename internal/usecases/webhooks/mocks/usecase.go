@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+//
+// Generated by this command:
+//
+//	mockgen --source=usecase.go --destination=mocks/usecase.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pullrequests "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+	isgomock struct{}
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// ClaimDelivery mocks base method.
+func (m *Mockstorage) ClaimDelivery(source, deliveryID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDelivery", source, deliveryID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDelivery indicates an expected call of ClaimDelivery.
+func (mr *MockstorageMockRecorder) ClaimDelivery(source, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDelivery", reflect.TypeOf((*Mockstorage)(nil).ClaimDelivery), source, deliveryID)
+}
+
+// GetUserIDByGitLabID mocks base method.
+func (m *Mockstorage) GetUserIDByGitLabID(gitlabID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByGitLabID", gitlabID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByGitLabID indicates an expected call of GetUserIDByGitLabID.
+func (mr *MockstorageMockRecorder) GetUserIDByGitLabID(gitlabID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByGitLabID", reflect.TypeOf((*Mockstorage)(nil).GetUserIDByGitLabID), gitlabID)
+}
+
+// GetUserIDByVCSLogin mocks base method.
+func (m *Mockstorage) GetUserIDByVCSLogin(login string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByVCSLogin", login)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByVCSLogin indicates an expected call of GetUserIDByVCSLogin.
+func (mr *MockstorageMockRecorder) GetUserIDByVCSLogin(login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByVCSLogin", reflect.TypeOf((*Mockstorage)(nil).GetUserIDByVCSLogin), login)
+}
+
+// ReleaseDelivery mocks base method.
+func (m *Mockstorage) ReleaseDelivery(source, deliveryID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDelivery", source, deliveryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDelivery indicates an expected call of ReleaseDelivery.
+func (mr *MockstorageMockRecorder) ReleaseDelivery(source, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDelivery", reflect.TypeOf((*Mockstorage)(nil).ReleaseDelivery), source, deliveryID)
+}
+
+// MockprUsecase is a mock of prUsecase interface.
+type MockprUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockprUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockprUsecaseMockRecorder is the mock recorder for MockprUsecase.
+type MockprUsecaseMockRecorder struct {
+	mock *MockprUsecase
+}
+
+// NewMockprUsecase creates a new mock instance.
+func NewMockprUsecase(ctrl *gomock.Controller) *MockprUsecase {
+	mock := &MockprUsecase{ctrl: ctrl}
+	mock.recorder = &MockprUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockprUsecase) EXPECT() *MockprUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreatePR mocks base method.
+func (m *MockprUsecase) CreatePR(ctx context.Context, opts pullrequests.CreatePROpst) (*pullrequests.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePR", ctx, opts)
+	ret0, _ := ret[0].(*pullrequests.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePR indicates an expected call of CreatePR.
+func (mr *MockprUsecaseMockRecorder) CreatePR(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePR", reflect.TypeOf((*MockprUsecase)(nil).CreatePR), ctx, opts)
+}
+
+// MarkMerged mocks base method.
+func (m *MockprUsecase) MarkMerged(ctx context.Context, prID string) (*pullrequests.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMerged", ctx, prID)
+	ret0, _ := ret[0].(*pullrequests.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMerged indicates an expected call of MarkMerged.
+func (mr *MockprUsecaseMockRecorder) MarkMerged(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMerged", reflect.TypeOf((*MockprUsecase)(nil).MarkMerged), ctx, prID)
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/qwerty268/pull_request_service/internal/metrics"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	// ErrAmbiguous - одному внешнему логину или id соответствуют несколько пользователей.
+	ErrAmbiguous = errors.New("ambiguous")
+)
+
+type Storage struct {
+	db    *sqlx.DB
+	close func() error
+}
+
+func NewStorage(db *sqlx.DB) *Storage {
+	return &Storage{
+		db: db,
+		close: func() error {
+			return fmt.Errorf("close: %v", db.Close())
+		},
+	}
+}
+
+// GetUserIDByVCSLogin ищет пользователя по логину в системе контроля версий без учета регистра.
+func (s *Storage) GetUserIDByVCSLogin(login string) (string, error) {
+	defer metrics.ObserveQuery("webhooks", "GetUserIDByVCSLogin", time.Now())
+
+	query := `
+		SELECT user_id
+		FROM "user"
+		WHERE lower(vcs_login) = lower($1)
+		ORDER BY user_id
+		LIMIT 2
+	`
+
+	var userIDs []string
+	if err := s.db.Select(&userIDs, query, login); err != nil {
+		return "", fmt.Errorf("GetUserIDByVCSLogin: %w", err)
+	}
+	return singleUserID(userIDs)
+}
+
+// GetUserIDByGitLabID ищет пользователя по его id в GitLab.
+func (s *Storage) GetUserIDByGitLabID(gitlabID string) (string, error) {
+	defer metrics.ObserveQuery("webhooks", "GetUserIDByGitLabID", time.Now())
+
+	query := `
+		SELECT user_id
+		FROM "user"
+		WHERE gitlab_user_id = $1
+		ORDER BY user_id
+		LIMIT 2
+	`
+
+	var userIDs []string
+	if err := s.db.Select(&userIDs, query, gitlabID); err != nil {
+		return "", fmt.Errorf("GetUserIDByGitLabID: %w", err)
+	}
+	return singleUserID(userIDs)
+}
+
+// ClaimDelivery запоминает доставку. false - доставка уже была принята раньше.
+func (s *Storage) ClaimDelivery(source, deliveryID string) (bool, error) {
+	defer metrics.ObserveQuery("webhooks", "ClaimDelivery", time.Now())
+
+	query := `
+		INSERT INTO webhook_delivery (source, delivery_id, received_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT DO NOTHING
+	`
+
+	res, err := s.db.Exec(query, source, deliveryID)
+	if err != nil {
+		return false, fmt.Errorf("ClaimDelivery: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ClaimDelivery rows affected: %w", err)
+	}
+	return affected == 1, nil
+}
+
+// ReleaseDelivery забывает доставку, чтобы ее повтор обработался заново.
+func (s *Storage) ReleaseDelivery(source, deliveryID string) error {
+	defer metrics.ObserveQuery("webhooks", "ReleaseDelivery", time.Now())
+
+	query := `DELETE FROM webhook_delivery WHERE source = $1 AND delivery_id = $2`
+	if _, err := s.db.Exec(query, source, deliveryID); err != nil {
+		return fmt.Errorf("ReleaseDelivery: %w", err)
+	}
+	return nil
+}
+
+func singleUserID(userIDs []string) (string, error) {
+	switch len(userIDs) {
+	case 0:
+		return "", ErrNotFound
+	case 1:
+		return userIDs[0], nil
+	}
+	return "", ErrAmbiguous
+}
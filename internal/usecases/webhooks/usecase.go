@@ -0,0 +1,134 @@
+//go:generate mockgen --source=usecase.go --destination=mocks/usecase.go -package=mocks
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	repository "github.com/qwerty268/pull_request_service/internal/usecases/webhooks/storage"
+)
+
+type storage interface {
+	GetUserIDByVCSLogin(login string) (string, error)
+	GetUserIDByGitLabID(gitlabID string) (string, error)
+	ClaimDelivery(source, deliveryID string) (bool, error)
+	ReleaseDelivery(source, deliveryID string) error
+}
+
+type prUsecase interface {
+	CreatePR(ctx context.Context, opts pullrequests.CreatePROpst) (*pullrequests.PullRequest, error)
+	MarkMerged(ctx context.Context, prID string) (*pullrequests.PullRequest, error)
+}
+
+type Usecase struct {
+	storage   storage
+	prUsecase prUsecase
+}
+
+func NewUsecase(storage storage, prUsecase prUsecase) Usecase {
+	return Usecase{
+		storage:   storage,
+		prUsecase: prUsecase,
+	}
+}
+
+// HandlePullRequest повторяет у себя открытие и мерж PR во внешней системе. События, которые нельзя
+// применить (неизвестный автор, PR заведен до подключения вебхука), пропускаются с причиной, а не ошибкой,
+// чтобы внешняя система не слала их повторно.
+func (u Usecase) HandlePullRequest(ctx context.Context, ev PullRequestEvent) (*Result, error) {
+	claimed, err := u.storage.ClaimDelivery(ev.Source, ev.DeliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim delivery: %v", err)
+	}
+	if !claimed {
+		return &Result{Outcome: OutcomeDuplicate, PullRequestID: ev.PullRequestID}, nil
+	}
+
+	result, err := u.apply(ctx, ev)
+	if err != nil {
+		// Доставка не применена, ее повтор должен обработаться заново.
+		if releaseErr := u.storage.ReleaseDelivery(ev.Source, ev.DeliveryID); releaseErr != nil {
+			return nil, fmt.Errorf("%w (failed to release delivery: %v)", err, releaseErr)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (u Usecase) apply(ctx context.Context, ev PullRequestEvent) (*Result, error) {
+	switch ev.Action {
+	case ActionOpened, ActionReopened, ActionUpdated:
+		return u.create(ctx, ev)
+	case ActionMerged:
+		return u.merge(ctx, ev)
+	}
+	// Закрытие без мержа не отражается: статуса закрытого PR в сервисе нет, такой PR остается открытым.
+	// Прочие действия у нас тоже не хранятся.
+	return ignored(ev, "action %s is not mirrored", ev.Action), nil
+}
+
+func (u Usecase) create(ctx context.Context, ev PullRequestEvent) (*Result, error) {
+	authorID, err := u.authorID(ev)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ignored(ev, "no user with %s %s", authorField(ev.Source), ev.Author), nil
+	case errors.Is(err, repository.ErrAmbiguous):
+		return ignored(ev, "several users with %s %s", authorField(ev.Source), ev.Author), nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to map author: %v", err)
+	}
+
+	_, err = u.prUsecase.CreatePR(ctx, pullrequests.CreatePROpst{
+		PullRequestID:   ev.PullRequestID,
+		PullRequestName: ev.Title,
+		AuthorID:        authorID,
+	})
+	switch {
+	case errors.Is(err, pullrequests.ErrAlreadyExists):
+		// Переоткрытый PR у нас так и оставался открытым, а измененный уже заведен.
+		return ignored(ev, "pull request already exists"), nil
+	case errors.Is(err, pullrequests.ErrNotFound):
+		return ignored(ev, "author %s is not a member of any team", authorID), nil
+	case err != nil:
+		return nil, err
+	}
+	return &Result{Outcome: OutcomeCreated, PullRequestID: ev.PullRequestID}, nil
+}
+
+func (u Usecase) authorID(ev PullRequestEvent) (string, error) {
+	if ev.Source == SourceGitLab {
+		return u.storage.GetUserIDByGitLabID(ev.Author)
+	}
+	return u.storage.GetUserIDByVCSLogin(ev.Author)
+}
+
+// authorField - поле профиля, с которым сопоставляется автор из источника.
+func authorField(source string) string {
+	if source == SourceGitLab {
+		return "gitlab_user_id"
+	}
+	return "vcs_login"
+}
+
+// merge повторяет мерж из внешней системы. Апрувы и настройки команды не проверяются: мерж уже случился.
+func (u Usecase) merge(ctx context.Context, ev PullRequestEvent) (*Result, error) {
+	_, err := u.prUsecase.MarkMerged(ctx, ev.PullRequestID)
+	switch {
+	case errors.Is(err, pullrequests.ErrNotFound):
+		return ignored(ev, "pull request was opened before the webhook was set up"), nil
+	case err != nil:
+		return nil, err
+	}
+	return &Result{Outcome: OutcomeMerged, PullRequestID: ev.PullRequestID}, nil
+}
+
+func ignored(ev PullRequestEvent, format string, args ...any) *Result {
+	return &Result{
+		Outcome:       OutcomeIgnored,
+		PullRequestID: ev.PullRequestID,
+		Reason:        fmt.Sprintf(format, args...),
+	}
+}
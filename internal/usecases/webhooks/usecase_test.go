@@ -0,0 +1,172 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	"github.com/qwerty268/pull_request_service/internal/usecases/webhooks/mocks"
+	repository "github.com/qwerty268/pull_request_service/internal/usecases/webhooks/storage"
+)
+
+func TestUsecase_HandlePullRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockstorage(ctrl)
+	mockPR := mocks.NewMockprUsecase(ctrl)
+	uc := NewUsecase(mockStorage, mockPR)
+	ctx := context.Background()
+
+	opened := PullRequestEvent{
+		Source:        SourceGitHub,
+		DeliveryID:    "d1",
+		Action:        ActionOpened,
+		PullRequestID: "acme/api#7",
+		Title:         "Fix bug",
+		Author:        "Alice",
+	}
+
+	t.Run("opened", func(t *testing.T) {
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(true, nil)
+		mockStorage.EXPECT().GetUserIDByVCSLogin("Alice").Return("u1", nil)
+		mockPR.EXPECT().
+			CreatePR(ctx, pullrequests.CreatePROpst{PullRequestID: "acme/api#7", PullRequestName: "Fix bug", AuthorID: "u1"}).
+			Return(&pullrequests.PullRequest{}, nil)
+
+		result, err := uc.HandlePullRequest(ctx, opened)
+		require.NoError(t, err)
+		require.Equal(t, &Result{Outcome: OutcomeCreated, PullRequestID: "acme/api#7"}, result)
+	})
+
+	t.Run("duplicate delivery", func(t *testing.T) {
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(false, nil)
+
+		result, err := uc.HandlePullRequest(ctx, opened)
+		require.NoError(t, err)
+		require.Equal(t, OutcomeDuplicate, result.Outcome)
+	})
+
+	t.Run("unknown author", func(t *testing.T) {
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(true, nil)
+		mockStorage.EXPECT().GetUserIDByVCSLogin("Alice").Return("", repository.ErrNotFound)
+
+		result, err := uc.HandlePullRequest(ctx, opened)
+		require.NoError(t, err)
+		require.Equal(t, OutcomeIgnored, result.Outcome)
+		require.Equal(t, "no user with vcs_login Alice", result.Reason)
+	})
+
+	t.Run("reopened existing PR", func(t *testing.T) {
+		reopened := opened
+		reopened.Action = ActionReopened
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(true, nil)
+		mockStorage.EXPECT().GetUserIDByVCSLogin("Alice").Return("u1", nil)
+		mockPR.EXPECT().CreatePR(ctx, gomock.Any()).Return(nil, pullrequests.ErrAlreadyExists)
+
+		result, err := uc.HandlePullRequest(ctx, reopened)
+		require.NoError(t, err)
+		require.Equal(t, OutcomeIgnored, result.Outcome)
+	})
+
+	gitlabOpened := PullRequestEvent{
+		Source:        SourceGitLab,
+		DeliveryID:    "g1",
+		Action:        ActionOpened,
+		PullRequestID: "acme/web!12",
+		Title:         "Add dark theme",
+		Author:        "4821",
+	}
+
+	t.Run("gitlab author by id", func(t *testing.T) {
+		mockStorage.EXPECT().ClaimDelivery(SourceGitLab, "g1").Return(true, nil)
+		mockStorage.EXPECT().GetUserIDByGitLabID("4821").Return("u2", nil)
+		mockPR.EXPECT().
+			CreatePR(ctx, pullrequests.CreatePROpst{PullRequestID: "acme/web!12", PullRequestName: "Add dark theme", AuthorID: "u2"}).
+			Return(&pullrequests.PullRequest{}, nil)
+
+		result, err := uc.HandlePullRequest(ctx, gitlabOpened)
+		require.NoError(t, err)
+		require.Equal(t, OutcomeCreated, result.Outcome)
+	})
+
+	t.Run("unknown gitlab author", func(t *testing.T) {
+		mockStorage.EXPECT().ClaimDelivery(SourceGitLab, "g1").Return(true, nil)
+		mockStorage.EXPECT().GetUserIDByGitLabID("4821").Return("", repository.ErrNotFound)
+
+		result, err := uc.HandlePullRequest(ctx, gitlabOpened)
+		require.NoError(t, err)
+		require.Equal(t, "no user with gitlab_user_id 4821", result.Reason)
+	})
+
+	t.Run("updated creates missing PR", func(t *testing.T) {
+		updated := gitlabOpened
+		updated.Action = ActionUpdated
+		mockStorage.EXPECT().ClaimDelivery(SourceGitLab, "g1").Return(true, nil)
+		mockStorage.EXPECT().GetUserIDByGitLabID("4821").Return("u2", nil)
+		mockPR.EXPECT().CreatePR(ctx, gomock.Any()).Return(nil, pullrequests.ErrAlreadyExists)
+
+		result, err := uc.HandlePullRequest(ctx, updated)
+		require.NoError(t, err)
+		require.Equal(t, OutcomeIgnored, result.Outcome)
+		require.Equal(t, "pull request already exists", result.Reason)
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		merged := opened
+		merged.Action = ActionMerged
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(true, nil)
+		mockPR.EXPECT().MarkMerged(ctx, "acme/api#7").Return(&pullrequests.PullRequest{}, nil)
+
+		result, err := uc.HandlePullRequest(ctx, merged)
+		require.NoError(t, err)
+		require.Equal(t, OutcomeMerged, result.Outcome)
+	})
+
+	t.Run("merge without enough reviewers", func(t *testing.T) {
+		merged := opened
+		merged.Action = ActionMerged
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(true, nil)
+		// Мерж во внешней системе уже случился, настройки команды его не останавливают.
+		mockPR.EXPECT().
+			MarkMerged(ctx, "acme/api#7").
+			Return(&pullrequests.PullRequest{PullRequestID: "acme/api#7", Status: "MERGED"}, nil)
+
+		result, err := uc.HandlePullRequest(ctx, merged)
+		require.NoError(t, err)
+		require.Equal(t, OutcomeMerged, result.Outcome)
+	})
+
+	t.Run("failed merge releases delivery", func(t *testing.T) {
+		merged := opened
+		merged.Action = ActionMerged
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(true, nil)
+		mockPR.EXPECT().MarkMerged(ctx, "acme/api#7").Return(nil, errors.New("db down"))
+		mockStorage.EXPECT().ReleaseDelivery(SourceGitHub, "d1").Return(nil)
+
+		result, err := uc.HandlePullRequest(ctx, merged)
+		require.ErrorContains(t, err, "db down")
+		require.Nil(t, result)
+	})
+
+	t.Run("closed without merge", func(t *testing.T) {
+		closed := opened
+		closed.Action = ActionClosed
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(true, nil)
+
+		result, err := uc.HandlePullRequest(ctx, closed)
+		require.NoError(t, err)
+		require.Equal(t, OutcomeIgnored, result.Outcome)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		mockStorage.EXPECT().ClaimDelivery(SourceGitHub, "d1").Return(false, errors.New("db down"))
+
+		_, err := uc.HandlePullRequest(ctx, opened)
+		require.ErrorContains(t, err, "db down")
+	})
+}
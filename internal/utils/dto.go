@@ -1,11 +1,24 @@
 package utils
 
 type ErrorDetail struct {
-	Code    string `json:"code" validate:"required,oneof=TEAM_EXISTS PR_EXISTS PR_MERGED NOT_ASSIGNED NO_CANDIDATE NOT_FOUND"`
+	Code    string `json:"code" validate:"required,oneof=TEAM_EXISTS PR_EXISTS PR_MERGED NOT_ASSIGNED NO_CANDIDATE NOT_FOUND USER_NOT_FOUND NOT_ENOUGH_REVIEWERS HAS_OPEN_PRS HAS_OPEN_REVIEWS HAS_HISTORY USERNAME_TAKEN INVALID_SETTINGS INVALID_WINDOW BAD_REQUEST VALIDATION_FAILED UNAUTHORIZED METHOD_NOT_ALLOWED INTERNAL"`
 	Message string `json:"message" validate:"required"`
+	// Fields - ошибки отдельных полей, только для VALIDATION_FAILED.
+	Fields []FieldError `json:"fields,omitempty"`
+	// CorrelationID - по нему внутренняя ошибка ищется в логах, только для INTERNAL.
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 // ErrorResponse - структура для ошибок API
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error" validate:"required"`
 }
+
+// FieldError - ошибка одного поля запроса
+type FieldError struct {
+	// Field - путь до поля в терминах запроса, например members[0].user_id.
+	Field string `json:"field"`
+	// Rule - нарушенное правило: required, oneof и т.д.
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	prUsecase "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	statsUsecase "github.com/qwerty268/pull_request_service/internal/usecases/stats"
+	teamUsecase "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	userUsecase "github.com/qwerty268/pull_request_service/internal/usecases/users"
+)
+
+// ValidationError - запрос не прошел проверку, в ответ уходит по ошибке на поле.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.Field + " " + f.Message
+	}
+	return "validate: " + strings.Join(parts, "; ")
+}
+
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+	// message - текст для клиента, пустой - берется текст самой ошибки.
+	message string
+}
+
+// errorMappings - единственное место, где ошибки usecase превращаются в коды API.
+var errorMappings = []errorMapping{
+	{prUsecase.ErrAlreadyExists, http.StatusConflict, PrExists, "PR already exists"},
+	{prUsecase.ErrNotFound, http.StatusNotFound, NotFound, "pull request, author or team not found"},
+	{prUsecase.ErrPRMerged, http.StatusConflict, PrMerged, "cannot change merged PR"},
+	{prUsecase.ErrNotAssigned, http.StatusConflict, NotAssigned, "reviewer is not assigned to this PR"},
+	{prUsecase.ErrNoCandidate, http.StatusConflict, NoCandidate, "no active replacement candidate in team"},
+	{prUsecase.ErrNotEnoughReviewers, http.StatusConflict, NotEnoughReviewers, "not enough reviewers required by team settings"},
+
+	{teamUsecase.ErrAlreadyExists, http.StatusBadRequest, TeamExists, "team_name already exists"},
+	{teamUsecase.ErrNotFound, http.StatusNotFound, NotFound, "team or team member not found"},
+	{teamUsecase.ErrInvalidSettings, http.StatusBadRequest, InvalidSettings, ""},
+
+	{userUsecase.ErrNotFound, http.StatusNotFound, UserNotFound, "user not found"},
+	{userUsecase.ErrTeamNotFound, http.StatusNotFound, NotFound, "team not found"},
+	{userUsecase.ErrUsernameTaken, http.StatusConflict, UsernameTaken, "username already taken"},
+	{userUsecase.ErrHasOpenPRs, http.StatusConflict, HasOpenPRs, "user is the author of open PRs"},
+	{userUsecase.ErrHasOpenReviews, http.StatusConflict, HasOpenReviews, "user is assigned to open PRs"},
+	{userUsecase.ErrHasHistory, http.StatusConflict, HasHistory, "user authored merged PRs, deactivate instead"},
+
+	{statsUsecase.ErrInvalidWindow, http.StatusBadRequest, InvalidWindow, ""},
+	{statsUsecase.ErrTeamNotFound, http.StatusNotFound, NotFound, "team not found"},
+}
+
+// ErrorCode - код API для известной ошибки usecase. Нужен API, которые отдают ошибки не через HTTPErrorHandler.
+func ErrorCode(err error) (string, bool) {
+	m, ok := lookupError(err)
+	return m.code, ok
+}
+
+func lookupError(err error) (errorMapping, bool) {
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return m, true
+		}
+	}
+	return errorMapping{}, false
+}
+
+// statusCodes - коды для ошибок echo, у которых есть только HTTP-статус.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:       BadRequest,
+	http.StatusUnauthorized:     Unauthorized,
+	http.StatusNotFound:         NotFound,
+	http.StatusMethodNotAllowed: MethodNotAllowed,
+}
+
+// HTTPErrorHandler отдает любую ошибку обработчика в виде ErrorResponse.
+// Текст внутренних ошибок клиенту не показывается: он пишется в лог вместе с correlation_id из ответа.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, detail := resolveError(err)
+	if status >= http.StatusInternalServerError {
+		detail.CorrelationID = CorrelationID(c)
+		c.Logger().Errorf("correlation_id=%s %s %s: %v", detail.CorrelationID, c.Request().Method, c.Request().URL.Path, err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, ErrorResponse{Error: detail})
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+func resolveError(err error) (int, ErrorDetail) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest, ErrorDetail{
+			Code:    ValidationFailed,
+			Message: "request validation failed",
+			Fields:  validationErr.Fields,
+		}
+	}
+
+	if m, ok := lookupError(err); ok {
+		message := m.message
+		if message == "" {
+			message = err.Error()
+		}
+		return m.status, ErrorDetail{Code: m.code, Message: message}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code < http.StatusInternalServerError {
+		code, ok := statusCodes[httpErr.Code]
+		if !ok {
+			code = BadRequest
+		}
+		message := http.StatusText(httpErr.Code)
+		if msg, ok := httpErr.Message.(string); ok && msg != "" {
+			message = msg
+		}
+		return httpErr.Code, ErrorDetail{Code: code, Message: message}
+	}
+
+	status := http.StatusInternalServerError
+	if httpErr != nil {
+		status = httpErr.Code
+	}
+	return status, ErrorDetail{Code: Internal, Message: "internal error"}
+}
+
+// CorrelationID берет X-Request-ID запроса или выдает новый и возвращает его в заголовке ответа.
+func CorrelationID(c echo.Context) string {
+	id := c.Request().Header.Get(echo.HeaderXRequestID)
+	if id == "" {
+		id = c.Response().Header().Get(echo.HeaderXRequestID)
+	}
+	if id == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return fmt.Sprintf("unavailable-%v", err)
+		}
+		id = hex.EncodeToString(b)
+	}
+	c.Response().Header().Set(echo.HeaderXRequestID, id)
+	return id
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	prUsecase "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	userUsecase "github.com/qwerty268/pull_request_service/internal/usecases/users"
+)
+
+func handleError(t *testing.T, err error, requestID string) (*httptest.ResponseRecorder, ErrorResponse) {
+	t.Helper()
+	e := echo.New()
+	e.Logger.SetOutput(io.Discard)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pullRequest/merge", nil)
+	if requestID != "" {
+		req.Header.Set(echo.HeaderXRequestID, requestID)
+	}
+	rec := httptest.NewRecorder()
+	HTTPErrorHandler(err, e.NewContext(req, rec))
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec, resp
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	t.Run("usecase error", func(t *testing.T) {
+		rec, resp := handleError(t, fmt.Errorf("merge pr-1: %w", prUsecase.ErrNotEnoughReviewers), "")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, ErrorDetail{
+			Code:    NotEnoughReviewers,
+			Message: "not enough reviewers required by team settings",
+		}, resp.Error)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		rec, resp := handleError(t, userUsecase.ErrNotFound, "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, UserNotFound, resp.Error.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		fields := []FieldError{{Field: "pull_request_id", Rule: "required", Message: "is required"}}
+		rec, resp := handleError(t, &ValidationError{Fields: fields}, "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, ValidationFailed, resp.Error.Code)
+		assert.Equal(t, fields, resp.Error.Fields)
+	})
+
+	t.Run("echo error", func(t *testing.T) {
+		rec, resp := handleError(t, echo.ErrMethodNotAllowed, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, MethodNotAllowed, resp.Error.Code)
+	})
+
+	t.Run("internal error is hidden", func(t *testing.T) {
+		rec, resp := handleError(t, errors.New("pq: connection refused"), "req-42")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, ErrorDetail{Code: Internal, Message: "internal error", CorrelationID: "req-42"}, resp.Error)
+		assert.Equal(t, "req-42", rec.Header().Get(echo.HeaderXRequestID))
+	})
+
+	t.Run("internal error gets correlation id", func(t *testing.T) {
+		rec, resp := handleError(t, echo.NewHTTPError(http.StatusInternalServerError, "pq: connection refused"), "")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "connection refused")
+		assert.Len(t, resp.Error.CorrelationID, 32)
+		assert.Equal(t, resp.Error.CorrelationID, rec.Header().Get(echo.HeaderXRequestID))
+	})
+}
+
+func TestValidate(t *testing.T) {
+	type member struct {
+		UserID string `json:"user_id" validate:"required"`
+		Role   string `json:"role" validate:"omitempty,oneof=member lead"`
+	}
+	type request struct {
+		TeamName string   `query:"team_name" validate:"required"`
+		Members  []member `json:"members" validate:"dive"`
+	}
+
+	err := NewHTTPRequestValidator().Validate(&request{Members: []member{{Role: "owner"}}})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{
+		{Field: "team_name", Rule: "required", Message: "is required"},
+		{Field: "members[0].user_id", Rule: "required", Message: "is required"},
+		{Field: "members[0].role", Rule: "oneof", Message: "must be one of: member lead"},
+	}, validationErr.Fields)
+}
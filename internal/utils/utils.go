@@ -1,8 +1,13 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
-	"net/http"
+	"reflect"
+	"strings"
+	"time"
+	// База часовых поясов для тега timezone, в контейнере ее может не быть.
+	_ "time/tzdata"
 
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
@@ -15,35 +20,115 @@ const (
 	NotAssigned = "NOT_ASSIGNED"
 	NoCandidate = "NO_CANDIDATE"
 	NotFound    = "NOT_FOUND"
+
+	NotEnoughReviewers = "NOT_ENOUGH_REVIEWERS"
+
+	HasOpenPRs     = "HAS_OPEN_PRS"
+	HasOpenReviews = "HAS_OPEN_REVIEWS"
+	HasHistory     = "HAS_HISTORY"
+
+	UserNotFound    = "USER_NOT_FOUND"
+	UsernameTaken   = "USERNAME_TAKEN"
+	InvalidSettings = "INVALID_SETTINGS"
+	InvalidWindow   = "INVALID_WINDOW"
+
+	BadRequest       = "BAD_REQUEST"
+	ValidationFailed = "VALIDATION_FAILED"
+	Unauthorized     = "UNAUTHORIZED"
+	MethodNotAllowed = "METHOD_NOT_ALLOWED"
+	Internal         = "INTERNAL"
 )
 
+// Router - общее у *echo.Echo и *echo.Group, обработчики регистрируются через него,
+// чтобы один и тот же набор маршрутов можно было смонтировать под разными версиями API.
+type Router interface {
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
 type HTTPRequestValidator struct {
 	validator *validator.Validate
 }
 
 func NewHTTPRequestValidator() *HTTPRequestValidator {
+	v := validator.New()
+	_ = v.RegisterValidation("timezone", isTimezone)
+	// В ошибках поле называется так же, как в запросе.
+	v.RegisterTagNameFunc(requestFieldName)
 	return &HTTPRequestValidator{
-		validator: validator.New(),
+		validator: v,
+	}
+}
+
+// isTimezone проверяет, что строка - часовой пояс IANA, например Europe/Moscow.
+func isTimezone(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if name == "" || name == "Local" {
+		return false
 	}
+	_, err := time.LoadLocation(name)
+	return err == nil
 }
 
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "param", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Validate проверяет запрос. Ошибки полей возвращаются как *ValidationError.
 func (cv *HTTPRequestValidator) Validate(i any) error {
-	if err := cv.validator.Struct(i); err != nil {
+	err := cv.validator.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
 		return fmt.Errorf("validate: %v", err)
 	}
-	return nil
-}
 
-func ReturnNotFound(c echo.Context, err ErrorDetail) error {
-	return c.JSON(
-		http.StatusNotFound,
-		ErrorResponse{err},
-	)
+	validationErr := &ValidationError{Fields: make([]FieldError, len(fieldErrs))}
+	for i, fe := range fieldErrs {
+		// Namespace начинается с имени структуры запроса, клиенту оно ни о чем не говорит.
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		validationErr.Fields[i] = FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Message: ruleMessage(fe.Tag(), fe.Param()),
+		}
+	}
+	return validationErr
 }
 
-func ReturnConflict(c echo.Context, err ErrorDetail) error {
-	return c.JSON(
-		http.StatusConflict,
-		ErrorResponse{err},
-	)
+func ruleMessage(rule, param string) string {
+	switch rule {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + param
+	case "min", "gte":
+		return "must be at least " + param
+	case "max", "lte":
+		return "must be at most " + param
+	case "gt":
+		return "must be greater than " + param
+	case "lt":
+		return "must be less than " + param
+	case "len":
+		return "must have length " + param
+	case "email":
+		return "must be a valid email"
+	case "timezone":
+		return "must be an IANA time zone"
+	default:
+		return fmt.Sprintf("failed on '%s' rule", rule)
+	}
 }
@@ -0,0 +1,160 @@
+// Package client - типизированный клиент HTTP API сервиса назначения ревьюеров.
+// Запросы и ответы повторяют DTO обработчиков сервиса, ошибки API оборачивают коды из ErrorResponse:
+//
+//	if errors.Is(err, client.ErrPRMerged) { ... }
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const mimeJSON = "application/json"
+
+// apiPrefix - версия API, с которой работает клиент.
+const apiPrefix = "/api/v1"
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	retry      RetryPolicy
+}
+
+type Option func(*Client)
+
+// WithHTTPClient подменяет http.Client, например чтобы задать таймаут или свой транспорт.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken добавляет к запросам заголовок Authorization: Bearer.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// New создает клиент для сервиса по адресу baseURL, например http://localhost:8080.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// do отправляет body в JSON и раскладывает успешный ответ в out.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	resp, err := c.send(ctx, method, path, query, mimeJSON, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// send выполняет запрос с повторами по политике клиента. Тело ответа закрывает вызывающий.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, contentType string, payload []byte) (*http.Response, error) {
+	target := c.baseURL + apiPrefix + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", contentType)
+		}
+		req.Header.Set("Accept", mimeJSON)
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retry.MaxAttempts || !shouldRetry(ctx, method, resp, err) {
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			return resp, nil
+		}
+
+		wait := c.retry.backoff(attempt, resp)
+		if resp != nil {
+			// Соединение переиспользуется, только если тело дочитано.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%s %s: %w", method, path, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// queryValues собирает параметры запроса из полей с тегом query. Нулевые значения не передаются.
+func queryValues(req any) url.Values {
+	values := url.Values{}
+	v := reflect.Indirect(reflect.ValueOf(req))
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("query")
+		field := v.Field(i)
+		if name == "" || field.IsZero() {
+			continue
+		}
+		switch value := reflect.Indirect(field).Interface().(type) {
+		case time.Time:
+			values.Set(name, value.Format(time.RFC3339))
+		default:
+			values.Set(name, fmt.Sprint(value))
+		}
+	}
+	return values
+}
@@ -0,0 +1,280 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/qwerty268/pull_request_service/internal/openapi"
+	prHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/pullrequests"
+	prMocks "github.com/qwerty268/pull_request_service/internal/rest_api/pullrequests/mocks"
+	statsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/stats"
+	statsMocks "github.com/qwerty268/pull_request_service/internal/rest_api/stats/mocks"
+	teamsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/teams"
+	teamsMocks "github.com/qwerty268/pull_request_service/internal/rest_api/teams/mocks"
+	userHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/users"
+	userMocks "github.com/qwerty268/pull_request_service/internal/rest_api/users/mocks"
+	"github.com/qwerty268/pull_request_service/internal/rest_api/versions"
+	ucPR "github.com/qwerty268/pull_request_service/internal/usecases/pullrequests"
+	ucTeams "github.com/qwerty268/pull_request_service/internal/usecases/teams"
+	ucUsers "github.com/qwerty268/pull_request_service/internal/usecases/users"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+type testServer struct {
+	*httptest.Server
+	pr    *prMocks.MockPRCreator
+	teams *teamsMocks.MockUsecase
+	users *userMocks.MockUserGetter
+	stats *statsMocks.MockUsecase
+}
+
+// newTestServer поднимает настоящие обработчики с проверкой по спецификации поверх моков usecase.
+// middleware выполняется до обработчиков, через него тесты имитируют сбои прокси.
+func newTestServer(t *testing.T, middleware ...echo.MiddlewareFunc) *testServer {
+	ctrl := gomock.NewController(t)
+	srv := &testServer{
+		pr:    prMocks.NewMockPRCreator(ctrl),
+		teams: teamsMocks.NewMockUsecase(ctrl),
+		users: userMocks.NewMockUserGetter(ctrl),
+		stats: statsMocks.NewMockUsecase(ctrl),
+	}
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	validator, err := openapi.Middleware(doc, true)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Validator = utils.NewHTTPRequestValidator()
+	e.HTTPErrorHandler = utils.HTTPErrorHandler
+	e.Use(middleware...)
+	e.Use(validator)
+	v1 := versions.Version{
+		Name: "v1",
+		Handlers: []versions.Registrar{
+			prHandlers.NewHandlers(srv.pr),
+			teamsHandlers.NewHandlers(srv.teams),
+			userHandlers.NewUserHandlers(srv.users, nil),
+			statsHandlers.NewHandlers(srv.stats),
+		},
+	}
+	v1.Mount(e)
+
+	srv.Server = httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// failFirst отвечает status на первые n запросов и считает все запросы.
+func failFirst(n int32, status int, calls *atomic.Int32) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if calls.Add(1) <= n {
+				return c.String(status, http.StatusText(status))
+			}
+			return next(c)
+		}
+	}
+}
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestClient_PullRequests(t *testing.T) {
+	srv := newTestServer(t)
+	c := New(srv.URL)
+	ctx := context.Background()
+
+	t.Run("create", func(t *testing.T) {
+		srv.pr.EXPECT().
+			CreatePR(gomock.Any(), ucPR.CreatePROpst{PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "u1"}).
+			Return(&ucPR.PullRequest{
+				PullRequestID:     "pr1",
+				PullRequestName:   "Fix",
+				AuthorID:          "u1",
+				Status:            "OPEN",
+				AssignedReviewers: []string{"u2"},
+				CreatedAt:         time.Now(),
+			}, nil)
+
+		pr, err := c.CreatePR(ctx, CreatePRRequest{PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "u1"})
+		require.NoError(t, err)
+		assert.Equal(t, "OPEN", pr.Status)
+		assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	})
+
+	t.Run("merged PR error", func(t *testing.T) {
+		srv.pr.EXPECT().
+			ReassignReviewer(gomock.Any(), "pr1", "u2").
+			Return(nil, ucPR.ErrPRMerged)
+
+		resp, err := c.ReassignReviewer(ctx, ReassignReviewerRequest{PullRequestID: "pr1", OldUserID: "u2"})
+		require.ErrorIs(t, err, ErrPRMerged)
+		assert.Nil(t, resp)
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+		assert.Equal(t, "cannot change merged PR", apiErr.Message)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		_, err := c.CreatePR(ctx, CreatePRRequest{PullRequestID: "pr1"})
+		require.ErrorIs(t, err, ErrValidationFailed)
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.NotEmpty(t, apiErr.Fields)
+		assert.Equal(t, "pull_request_name", apiErr.Fields[0].Field)
+	})
+}
+
+func TestClient_TeamsAndUsers(t *testing.T) {
+	srv := newTestServer(t)
+	c := New(srv.URL)
+	ctx := context.Background()
+
+	t.Run("get team not found", func(t *testing.T) {
+		srv.teams.EXPECT().
+			GetTeam(gomock.Any(), "ghost").
+			Return(nil, ucTeams.ErrNotFound)
+
+		_, err := c.GetTeam(ctx, "ghost")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("list users query", func(t *testing.T) {
+		active := true
+		srv.users.EXPECT().
+			ListUsers(gomock.Any(), ucUsers.ListUsersFilter{TeamName: "backend", IsActive: &active, Limit: 10}).
+			Return(&ucUsers.UsersPage{Users: []ucUsers.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}}, Total: 1}, nil)
+
+		resp, err := c.ListUsers(ctx, ListUsersRequest{TeamName: "backend", IsActive: &active, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Total)
+		require.Len(t, resp.Users, 1)
+		assert.Equal(t, "Alice", resp.Users[0].Username)
+	})
+
+	t.Run("import invalid roster", func(t *testing.T) {
+		srv.teams.EXPECT().
+			ImportRoster(gomock.Any(), gomock.Any(), true).
+			Return(&ucTeams.ImportResult{Errors: []ucTeams.RowError{{Location: "line 2", Message: "user_id is required"}}}, ucTeams.ErrInvalidRoster)
+
+		roster := "team_name,user_id,username,is_active\nbackend,,Alice,true\n"
+		resp, err := c.ImportTeams(ctx, ImportTeamsRequest{Format: "csv", DryRun: true}, strings.NewReader(roster))
+		require.ErrorIs(t, err, ErrInvalidRoster)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "line 2", resp.Errors[0].Location)
+	})
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Run("idempotent request is retried", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newTestServer(t, failFirst(2, http.StatusBadGateway, &calls))
+		srv.users.EXPECT().
+			GetUser(gomock.Any(), "u1").
+			Return(&ucUsers.User{UserID: "u1", Username: "Alice", TeamName: "backend"}, nil)
+
+		user, err := New(srv.URL, WithRetryPolicy(fastRetry)).GetUser(context.Background(), "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", user.Username)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("POST is not retried on bad gateway", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newTestServer(t, failFirst(1, http.StatusBadGateway, &calls))
+
+		_, err := New(srv.URL, WithRetryPolicy(fastRetry)).MergePR(context.Background(), MergePRRequest{PullRequestID: "pr1"})
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.Empty(t, apiErr.Code)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("POST is retried on service unavailable", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newTestServer(t, failFirst(1, http.StatusServiceUnavailable, &calls))
+		srv.pr.EXPECT().
+			MergePR(gomock.Any(), "pr1").
+			Return(&ucPR.PullRequest{PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "u1", Status: "MERGED"}, nil)
+
+		pr, err := New(srv.URL, WithRetryPolicy(fastRetry)).MergePR(context.Background(), MergePRRequest{PullRequestID: "pr1"})
+		require.NoError(t, err)
+		assert.Equal(t, "MERGED", pr.Status)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("attempts are exhausted", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newTestServer(t, failFirst(10, http.StatusServiceUnavailable, &calls))
+
+		_, err := New(srv.URL, WithRetryPolicy(fastRetry)).GetUser(context.Background(), "u1")
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("context is cancelled during backoff", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newTestServer(t, failFirst(10, http.StatusServiceUnavailable, &calls))
+		slow := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Minute, MaxDelay: time.Minute}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := New(srv.URL, WithRetryPolicy(slow)).GetUser(ctx, "u1")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClient_Options(t *testing.T) {
+	srv := newTestServer(t)
+	srv.teams.EXPECT().
+		GetTeamSettings(gomock.Any(), "backend").
+		Return(&ucTeams.Settings{TeamName: "backend", ReviewersCount: 2, SelectionStrategy: "random"}, nil)
+
+	transport := &recordingTransport{}
+	c := New(srv.URL+"/", WithHTTPClient(&http.Client{Transport: transport}), WithToken("secret"))
+	settings, err := c.GetTeamSettings(context.Background(), "backend")
+	require.NoError(t, err)
+	assert.Equal(t, 2, settings.ReviewersCount)
+
+	require.Len(t, transport.requests, 1)
+	req := transport.requests[0]
+	assert.Equal(t, "/api/v1/team/settings", req.URL.Path)
+	assert.Equal(t, "Bearer secret", req.Header.Get(echo.HeaderAuthorization))
+}
+
+func TestAPIError(t *testing.T) {
+	err := error(&APIError{StatusCode: http.StatusConflict, Code: "PR_MERGED", Message: "cannot change merged PR"})
+	assert.True(t, errors.Is(err, ErrPRMerged))
+	assert.False(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, "api error 409 PR_MERGED: cannot change merged PR", err.Error())
+
+	unknown := error(&APIError{StatusCode: http.StatusTeapot, Code: "SOMETHING_NEW"})
+	assert.False(t, errors.Is(unknown, ErrInternal))
+}
@@ -0,0 +1,407 @@
+package client
+
+import "time"
+
+// DTO повторяют формат REST API и не зависят от пакетов сервиса. Что они совпадают
+// с DTO обработчиков, проверяет TestDTO_MatchHandlers.
+
+type CreatePRRequest struct {
+	PullRequestID   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+	AuthorID        string `json:"author_id"`
+}
+
+type MergePRRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type ReassignReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldUserID     string `json:"old_user_id"`
+}
+
+type ReassignReviewerResponse struct {
+	PR         PullRequest `json:"pr"`
+	ReplacedBy string      `json:"replaced_by"`
+	Escalated  bool        `json:"escalated,omitempty"`
+}
+
+type PullRequest struct {
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	CreatedAt         *time.Time `json:"createdAt,omitempty"`
+	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+}
+
+type AddTeamRequest struct {
+	TeamName string              `json:"team_name"`
+	Members  []TeamMemberRequest `json:"members"`
+}
+
+type TeamMemberRequest struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	IsActive *bool  `json:"is_active"`
+	Role     string `json:"role,omitempty"`
+}
+
+type TeamResponse struct {
+	TeamName string               `json:"team_name"`
+	Members  []TeamMemberResponse `json:"members"`
+}
+
+type TeamMemberResponse struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	IsActive bool   `json:"is_active"`
+	Role     string `json:"role"`
+}
+
+// UpdateTeamSettingsRequest - изменение настроек команды. Незаданные поля не меняются.
+type UpdateTeamSettingsRequest struct {
+	TeamName          string  `json:"team_name"`
+	ReviewersCount    *int    `json:"reviewers_count"`
+	SelectionStrategy *string `json:"selection_strategy"`
+	MinReviewers      *int    `json:"min_reviewers"`
+}
+
+type TeamSettingsResponse struct {
+	TeamName          string `json:"team_name"`
+	ReviewersCount    int    `json:"reviewers_count"`
+	SelectionStrategy string `json:"selection_strategy"`
+	MinReviewers      int    `json:"min_reviewers"`
+}
+
+type ListTeamsRequest struct {
+	NamePrefix string `query:"name_prefix"`
+	Limit      int    `query:"limit"`
+	Offset     int    `query:"offset"`
+}
+
+type ListTeamsResponse struct {
+	Teams  []TeamSummaryResponse `json:"teams"`
+	Total  int                   `json:"total"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+type TeamSummaryResponse struct {
+	TeamName           string `json:"team_name"`
+	MembersCount       int    `json:"members_count"`
+	ActiveMembersCount int    `json:"active_members_count"`
+	OpenPRsCount       int    `json:"open_prs_count"`
+}
+
+type SetMemberRoleRequest struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	Role     string `json:"role"`
+}
+
+type SetMemberRoleResponse struct {
+	TeamName string             `json:"team_name"`
+	Member   TeamMemberResponse `json:"member"`
+}
+
+// ImportTeamsRequest - параметры импорта. Сам файл передается телом запроса.
+type ImportTeamsRequest struct {
+	Format string `query:"format"`
+	DryRun bool   `query:"dry_run"`
+}
+
+type ImportTeamsResponse struct {
+	Applied bool                   `json:"applied"`
+	Changes []RosterChangeResponse `json:"changes"`
+	Errors  []RowErrorResponse     `json:"errors"`
+}
+
+type RosterChangeResponse struct {
+	Kind     string `json:"kind"`
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id,omitempty"`
+	Details  string `json:"details,omitempty"`
+}
+
+type RowErrorResponse struct {
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+type SetUserActiveRequest struct {
+	UserID   string `json:"user_id"`
+	IsActive *bool  `json:"is_active"`
+}
+
+type SetUserActiveResponse struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name"`
+	IsActive    bool   `json:"is_active"`
+	Email       string `json:"email,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+	ChatHandle  string `json:"chat_handle,omitempty"`
+	VCSLogin    string `json:"vcs_login,omitempty"`
+	GitLabID    string `json:"gitlab_user_id,omitempty"`
+}
+
+type GetUserReviewRequestsResponse struct {
+	UserID       string             `json:"user_id"`
+	PullRequests []PullRequestShort `json:"pull_requests"`
+}
+
+type PullRequestShort struct {
+	PullRequestID   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+	AuthorID        string `json:"author_id"`
+	Status          string `json:"status"`
+}
+
+type MoveTeamRequest struct {
+	UserID          string `json:"user_id"`
+	TeamName        string `json:"team_name"`
+	HandoverReviews bool   `json:"handover_reviews"`
+}
+
+type MoveTeamResponse struct {
+	User        SetUserActiveResponse `json:"user"`
+	HandedOver  []ReviewHandover      `json:"handed_over"`
+	KeptReviews []string              `json:"kept_reviews"`
+}
+
+type ReviewHandover struct {
+	PullRequestID string `json:"pull_request_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+type UserResponse struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name"`
+	IsActive    bool   `json:"is_active"`
+	Email       string `json:"email,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+	ChatHandle  string `json:"chat_handle,omitempty"`
+	VCSLogin    string `json:"vcs_login,omitempty"`
+	GitLabID    string `json:"gitlab_user_id,omitempty"`
+}
+
+// UpdateUserRequest - изменение профиля. Отсутствующие поля не меняются, пустая строка очищает поле.
+type UpdateUserRequest struct {
+	UserID      string  `json:"user_id"`
+	Username    *string `json:"username"`
+	Email       *string `json:"email"`
+	DisplayName *string `json:"display_name"`
+	Timezone    *string `json:"timezone"`
+	ChatHandle  *string `json:"chat_handle"`
+	VCSLogin    *string `json:"vcs_login"`
+	GitLabID    *string `json:"gitlab_user_id"`
+}
+
+type ListUsersRequest struct {
+	TeamName string `query:"team_name"`
+	IsActive *bool  `query:"is_active"`
+	Limit    int    `query:"limit"`
+	Offset   int    `query:"offset"`
+}
+
+type ListUsersResponse struct {
+	Users  []UserResponse `json:"users"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// DeleteUserRequest - удаление пользователя. Reassign передает его открытые ревью сокомандникам.
+type DeleteUserRequest struct {
+	UserID   string `query:"user_id"`
+	Reassign bool   `query:"reassign"`
+}
+
+type DeleteUserResponse struct {
+	UserID     string           `json:"user_id"`
+	HandedOver []ReviewHandover `json:"handed_over"`
+}
+
+// EraseUserRequest - удаление персональных данных. Reason и имя администратора,
+// которому принадлежит токен клиента (WithToken), попадают в журнал.
+type EraseUserRequest struct {
+	UserID          string `json:"user_id"`
+	Reason          string `json:"reason"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+type EraseUserResponse struct {
+	PseudonymID       string           `json:"pseudonym_id"`
+	AuthoredPRs       int              `json:"authored_prs"`
+	ReviewAssignments int              `json:"review_assignments"`
+	HandedOver        []ReviewHandover `json:"handed_over"`
+}
+
+// ReviewHistoryRequest - фильтр истории ревью по времени назначения.
+type ReviewHistoryRequest struct {
+	UserID string     `query:"user_id"`
+	Status string     `query:"status"`
+	From   *time.Time `query:"from"`
+	To     *time.Time `query:"to"`
+	Limit  int        `query:"limit"`
+	Offset int        `query:"offset"`
+}
+
+type ReviewHistoryResponse struct {
+	UserID  string               `json:"user_id"`
+	Entries []ReviewHistoryEntry `json:"entries"`
+	Total   int                  `json:"total"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
+
+type ReviewHistoryEntry struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          string     `json:"status"`
+	AssignedAt      time.Time  `json:"assigned_at"`
+	UnassignedAt    *time.Time `json:"unassigned_at,omitempty"`
+	Reason          string     `json:"reason,omitempty"`
+}
+
+// DashboardRequest - персональная панель. MergedDays - за сколько дней показывать смерженные PR.
+type DashboardRequest struct {
+	UserID     string `query:"user_id"`
+	MergedDays int    `query:"merged_days"`
+}
+
+type DashboardResponse struct {
+	UserID         string        `json:"user_id"`
+	Authored       []DashboardPR `json:"authored"`
+	AwaitingReview []DashboardPR `json:"awaiting_review"`
+	RecentlyMerged []DashboardPR `json:"recently_merged"`
+}
+
+type DashboardPR struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Reviewers       []string   `json:"reviewers"`
+	Status          string     `json:"status"`
+	CreatedAt       time.Time  `json:"created_at"`
+	MergedAt        *time.Time `json:"merged_at,omitempty"`
+	// WaitSeconds - сколько PR ждет с создания, для смерженных - сколько ждал до мержа.
+	WaitSeconds int64 `json:"wait_seconds"`
+}
+
+// WindowRequest - период статистики. По умолчанию последние 30 дней.
+type WindowRequest struct {
+	From     time.Time `query:"from"`
+	To       time.Time `query:"to"`
+	TeamName string    `query:"team_name"`
+}
+
+type WindowResponse struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	TeamName string    `json:"team_name,omitempty"`
+}
+
+type ReviewersResponse struct {
+	Window    WindowResponse          `json:"window"`
+	Reviewers []ReviewerStatsResponse `json:"reviewers"`
+}
+
+type ReviewerStatsResponse struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	Assigned       int    `json:"assigned"`
+	ReassignedAway int    `json:"reassigned_away"`
+	Authored       int    `json:"authored"`
+}
+
+type TeamsResponse struct {
+	Window WindowResponse      `json:"window"`
+	Teams  []TeamStatsResponse `json:"teams"`
+}
+
+type TeamStatsResponse struct {
+	TeamName       string `json:"team_name"`
+	MembersCount   int    `json:"members_count"`
+	Assigned       int    `json:"assigned"`
+	ReassignedAway int    `json:"reassigned_away"`
+	Authored       int    `json:"authored"`
+}
+
+type LatencyResponse struct {
+	Window  WindowResponse          `json:"window"`
+	Teams   []TeamLatencyResponse   `json:"teams"`
+	Authors []AuthorLatencyResponse `json:"authors"`
+}
+
+type TeamLatencyResponse struct {
+	TeamName    string              `json:"team_name"`
+	Merged      int                 `json:"merged"`
+	TimeToMerge PercentilesResponse `json:"time_to_merge"`
+}
+
+type AuthorLatencyResponse struct {
+	UserID      string              `json:"user_id"`
+	Username    string              `json:"username"`
+	TeamName    string              `json:"team_name"`
+	Merged      int                 `json:"merged"`
+	TimeToMerge PercentilesResponse `json:"time_to_merge"`
+}
+
+// PercentilesResponse - перцентили в секундах.
+type PercentilesResponse struct {
+	P50Seconds int64 `json:"p50_seconds"`
+	P90Seconds int64 `json:"p90_seconds"`
+	P99Seconds int64 `json:"p99_seconds"`
+}
+
+type FairnessRequest struct {
+	From     time.Time `query:"from"`
+	To       time.Time `query:"to"`
+	TeamName string    `query:"team_name"`
+}
+
+type FairnessResponse struct {
+	Window         WindowResponse           `json:"window"`
+	TotalAssigned  int                      `json:"total_assigned"`
+	Gini           float64                  `json:"gini"`
+	Members        []MemberFairnessResponse `json:"members"`
+	MostOverloaded *MemberFairnessResponse  `json:"most_overloaded,omitempty"`
+	MostUnderused  *MemberFairnessResponse  `json:"most_underused,omitempty"`
+}
+
+type MemberFairnessResponse struct {
+	UserID      string  `json:"user_id"`
+	Username    string  `json:"username"`
+	Assigned    int     `json:"assigned"`
+	ActiveShare float64 `json:"active_share"`
+	IdealShare  float64 `json:"ideal_share"`
+	ActualShare float64 `json:"actual_share"`
+	Expected    float64 `json:"expected"`
+}
+
+// FieldError - ошибка одного поля запроса.
+type FieldError struct {
+	// Field - путь до поля в терминах запроса, например members[0].user_id.
+	Field string `json:"field"`
+	// Rule - нарушенное правило: required, oneof и т.д.
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// errorResponse - тело ошибки API.
+type errorResponse struct {
+	Error struct {
+		Code          string       `json:"code"`
+		Message       string       `json:"message"`
+		Fields        []FieldError `json:"fields,omitempty"`
+		CorrelationID string       `json:"correlation_id,omitempty"`
+	} `json:"error"`
+}
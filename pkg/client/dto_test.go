@@ -0,0 +1,115 @@
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	prHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/pullrequests"
+	statsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/stats"
+	teamsHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/teams"
+	userHandlers "github.com/qwerty268/pull_request_service/internal/rest_api/users"
+	"github.com/qwerty268/pull_request_service/internal/utils"
+)
+
+// shape описывает тип так, как его видит API: имена из тегов json и query и вложенные типы.
+func shape(t reflect.Type) any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return map[string]any{"*": shape(t.Elem())}
+	case reflect.Slice:
+		return map[string]any{"[]": shape(t.Elem())}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return "time"
+		}
+		fields := map[string]any{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			for _, key := range []string{"json", "query"} {
+				if tag := f.Tag.Get(key); tag != "" {
+					fields[key+":"+tag] = shape(f.Type)
+				}
+			}
+		}
+		return fields
+	}
+	return t.Kind().String()
+}
+
+func TestDTO_MatchHandlers(t *testing.T) {
+	pairs := []struct {
+		client  any
+		handler any
+	}{
+		{CreatePRRequest{}, prHandlers.CreatePRRequest{}},
+		{MergePRRequest{}, prHandlers.MergePRRequest{}},
+		{ReassignReviewerRequest{}, prHandlers.ReassignReviewerRequest{}},
+		{ReassignReviewerResponse{}, prHandlers.ReassignReviewerResponse{}},
+		{PullRequest{}, prHandlers.PullRequest{}},
+
+		{AddTeamRequest{}, teamsHandlers.AddTeamRequest{}},
+		{TeamResponse{}, teamsHandlers.TeamResponse{}},
+		{UpdateTeamSettingsRequest{}, teamsHandlers.UpdateTeamSettingsRequest{}},
+		{TeamSettingsResponse{}, teamsHandlers.TeamSettingsResponse{}},
+		{ListTeamsRequest{}, teamsHandlers.ListTeamsRequest{}},
+		{ListTeamsResponse{}, teamsHandlers.ListTeamsResponse{}},
+		{SetMemberRoleRequest{}, teamsHandlers.SetMemberRoleRequest{}},
+		{SetMemberRoleResponse{}, teamsHandlers.SetMemberRoleResponse{}},
+		{ImportTeamsRequest{}, teamsHandlers.ImportTeamsRequest{}},
+		{ImportTeamsResponse{}, teamsHandlers.ImportTeamsResponse{}},
+
+		{SetUserActiveRequest{}, userHandlers.SetUserActiveRequest{}},
+		{SetUserActiveResponse{}, userHandlers.SetUserActiveResponse{}},
+		{GetUserReviewRequestsResponse{}, userHandlers.GetUserReviewRequestsResponse{}},
+		{MoveTeamRequest{}, userHandlers.MoveTeamRequest{}},
+		{MoveTeamResponse{}, userHandlers.MoveTeamResponse{}},
+		{UserResponse{}, userHandlers.UserResponse{}},
+		{UpdateUserRequest{}, userHandlers.UpdateUserRequest{}},
+		{ListUsersRequest{}, userHandlers.ListUsersRequest{}},
+		{ListUsersResponse{}, userHandlers.ListUsersResponse{}},
+		{DeleteUserRequest{}, userHandlers.DeleteUserRequest{}},
+		{DeleteUserResponse{}, userHandlers.DeleteUserResponse{}},
+		{EraseUserRequest{}, userHandlers.EraseUserRequest{}},
+		{EraseUserResponse{}, userHandlers.EraseUserResponse{}},
+		{ReviewHistoryRequest{}, userHandlers.ReviewHistoryRequest{}},
+		{ReviewHistoryResponse{}, userHandlers.ReviewHistoryResponse{}},
+		{DashboardRequest{}, userHandlers.DashboardRequest{}},
+		{DashboardResponse{}, userHandlers.DashboardResponse{}},
+
+		{WindowRequest{}, statsHandlers.WindowRequest{}},
+		{ReviewersResponse{}, statsHandlers.ReviewersResponse{}},
+		{TeamsResponse{}, statsHandlers.TeamsResponse{}},
+		{LatencyResponse{}, statsHandlers.LatencyResponse{}},
+		{FairnessRequest{}, statsHandlers.FairnessRequest{}},
+		{FairnessResponse{}, statsHandlers.FairnessResponse{}},
+
+		{FieldError{}, utils.FieldError{}},
+		{errorResponse{}, utils.ErrorResponse{}},
+	}
+
+	for _, p := range pairs {
+		clientType := reflect.TypeOf(p.client)
+		assert.Equal(t, shape(reflect.TypeOf(p.handler)), shape(clientType), clientType.Name())
+	}
+}
+
+func TestErrors_MatchCodes(t *testing.T) {
+	// Коды API перечислены в правиле oneof у ErrorDetail.Code.
+	field, _ := reflect.TypeOf(utils.ErrorDetail{}).FieldByName("Code")
+	_, oneof, _ := strings.Cut(field.Tag.Get("validate"), "oneof=")
+
+	var codes []string
+	for _, err := range []error{
+		ErrTeamExists, ErrPRExists, ErrPRMerged, ErrNotAssigned, ErrNoCandidate, ErrNotFound,
+		ErrUserNotFound, ErrNotEnoughReviewers, ErrHasOpenPRs, ErrHasOpenReviews,
+		ErrHasHistory, ErrUsernameTaken, ErrInvalidSettings, ErrInvalidWindow, ErrBadRequest,
+		ErrValidationFailed, ErrUnauthorized, ErrMethodNotAllowed, ErrInternal,
+	} {
+		codes = append(codes, err.Error())
+	}
+	assert.ElementsMatch(t, strings.Fields(oneof), codes)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Ошибки по кодам API. APIError оборачивает ту, что соответствует коду ответа.
+var (
+	ErrTeamExists         = errors.New("TEAM_EXISTS")
+	ErrPRExists           = errors.New("PR_EXISTS")
+	ErrPRMerged           = errors.New("PR_MERGED")
+	ErrNotAssigned        = errors.New("NOT_ASSIGNED")
+	ErrNoCandidate        = errors.New("NO_CANDIDATE")
+	ErrNotFound           = errors.New("NOT_FOUND")
+	ErrUserNotFound       = errors.New("USER_NOT_FOUND")
+	ErrNotEnoughReviewers = errors.New("NOT_ENOUGH_REVIEWERS")
+	ErrHasOpenPRs         = errors.New("HAS_OPEN_PRS")
+	ErrHasOpenReviews     = errors.New("HAS_OPEN_REVIEWS")
+	ErrHasHistory         = errors.New("HAS_HISTORY")
+	ErrUsernameTaken      = errors.New("USERNAME_TAKEN")
+	ErrInvalidSettings    = errors.New("INVALID_SETTINGS")
+	ErrInvalidWindow      = errors.New("INVALID_WINDOW")
+	ErrBadRequest         = errors.New("BAD_REQUEST")
+	ErrValidationFailed   = errors.New("VALIDATION_FAILED")
+	ErrUnauthorized       = errors.New("UNAUTHORIZED")
+	ErrMethodNotAllowed   = errors.New("METHOD_NOT_ALLOWED")
+	ErrInternal           = errors.New("INTERNAL")
+
+	// ErrInvalidRoster - файл импорта команд не прошел проверку, построчные ошибки лежат в ответе.
+	ErrInvalidRoster = errors.New("invalid roster")
+)
+
+var codeErrors = map[string]error{}
+
+func init() {
+	for _, err := range []error{
+		ErrTeamExists, ErrPRExists, ErrPRMerged, ErrNotAssigned, ErrNoCandidate, ErrNotFound, ErrUserNotFound,
+		ErrNotEnoughReviewers, ErrHasOpenPRs, ErrHasOpenReviews, ErrHasHistory, ErrUsernameTaken,
+		ErrInvalidSettings, ErrInvalidWindow, ErrBadRequest, ErrValidationFailed, ErrUnauthorized,
+		ErrMethodNotAllowed, ErrInternal,
+	} {
+		codeErrors[err.Error()] = err
+	}
+}
+
+// APIError - ответ сервиса с ошибкой. Code пустой, если ответ пришел не от сервиса, например от прокси.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// Fields - ошибки полей запроса для VALIDATION_FAILED.
+	Fields []FieldError
+	// CorrelationID - по нему ошибка INTERNAL ищется в логах сервиса.
+	CorrelationID string
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+	}
+	msg := fmt.Sprintf("api error %d %s: %s", e.StatusCode, e.Code, e.Message)
+	if e.CorrelationID != "" {
+		msg += " (correlation_id " + e.CorrelationID + ")"
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return codeErrors[e.Code]
+}
+
+// maxErrorBody - сколько тела чужой ошибки попадает в сообщение.
+const maxErrorBody = 512
+
+func decodeError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read error response: %w", err)
+	}
+
+	var errResp errorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Code != "" {
+		return &APIError{
+			StatusCode:    resp.StatusCode,
+			Code:          errResp.Error.Code,
+			Message:       errResp.Error.Message,
+			Fields:        errResp.Error.Fields,
+			CorrelationID: errResp.Error.CorrelationID,
+		}
+	}
+
+	message := strings.TrimSpace(string(body))
+	if len(message) > maxErrorBody {
+		message = message[:maxErrorBody]
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: message}
+}
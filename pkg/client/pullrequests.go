@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CreatePR создает PR и назначает ревьюверов
+func (c *Client) CreatePR(ctx context.Context, req CreatePRRequest) (*PullRequest, error) {
+	pr := new(PullRequest)
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/create", nil, req, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// MergePR мержит PR. Повторный мерж возвращает тот же PR.
+func (c *Client) MergePR(ctx context.Context, req MergePRRequest) (*PullRequest, error) {
+	pr := new(PullRequest)
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/merge", nil, req, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// ReassignReviewer заменяет ревьювера на другого участника его команды
+func (c *Client) ReassignReviewer(ctx context.Context, req ReassignReviewerRequest) (*ReassignReviewerResponse, error) {
+	resp := new(ReassignReviewerResponse)
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", nil, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetPR(ctx context.Context, prID string) (*PullRequest, error) {
+	pr := new(PullRequest)
+	query := url.Values{"pull_request_id": {prID}}
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/get", query, nil, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy - повторы временно неудачных запросов с экспоненциальной задержкой.
+type RetryPolicy struct {
+	// MaxAttempts - сколько всего попыток, 1 отключает повторы.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// backoff - задержка перед следующей попыткой: BaseDelay*2^(attempt-1) со случайным разбросом вниз до половины,
+// чтобы клиенты не приходили повторно одновременно. Retry-After сервера важнее, но не больше MaxDelay.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, p.MaxDelay)
+		}
+	}
+
+	delay := p.MaxDelay
+	if shift := attempt - 1; shift < 30 {
+		delay = min(p.BaseDelay<<shift, p.MaxDelay)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// shouldRetry повторяет сетевые ошибки и 502/504 только для идемпотентных методов: неизвестно,
+// дошел ли запрос до сервиса. 429 и 503 означают, что запрос не обработан, их можно повторить всегда.
+func shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return idempotent(method) && !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(method)
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+func (c *Client) GetReviewerStats(ctx context.Context, req WindowRequest) (*ReviewersResponse, error) {
+	resp := new(ReviewersResponse)
+	if err := c.do(ctx, http.MethodGet, "/stats/reviewers", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetTeamStats(ctx context.Context, req WindowRequest) (*TeamsResponse, error) {
+	resp := new(TeamsResponse)
+	if err := c.do(ctx, http.MethodGet, "/stats/teams", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetMergeLatency(ctx context.Context, req WindowRequest) (*LatencyResponse, error) {
+	resp := new(LatencyResponse)
+	if err := c.do(ctx, http.MethodGet, "/stats/latency", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetMergeLatencyCSV отдает тот же отчет о времени до мержа в CSV, как его формирует сервис.
+func (c *Client) GetMergeLatencyCSV(ctx context.Context, req WindowRequest) ([]byte, error) {
+	query := queryValues(req)
+	query.Set("format", "csv")
+
+	resp, err := c.send(ctx, http.MethodGet, "/stats/latency", query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, decodeError(resp)
+	}
+	report, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read latency report: %w", err)
+	}
+	return report, nil
+}
+
+func (c *Client) GetFairness(ctx context.Context, req FairnessRequest) (*FairnessResponse, error) {
+	resp := new(FairnessResponse)
+	if err := c.do(ctx, http.MethodGet, "/stats/fairness", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
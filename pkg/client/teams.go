@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// AddTeam создает команду с участниками
+func (c *Client) AddTeam(ctx context.Context, req AddTeamRequest) (*AddTeamRequest, error) {
+	team := new(AddTeamRequest)
+	if err := c.do(ctx, http.MethodPost, "/team/add", nil, req, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*TeamResponse, error) {
+	team := new(TeamResponse)
+	query := url.Values{"team_name": {teamName}}
+	if err := c.do(ctx, http.MethodGet, "/team/get", query, nil, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (c *Client) ListTeams(ctx context.Context, req ListTeamsRequest) (*ListTeamsResponse, error) {
+	resp := new(ListTeamsResponse)
+	if err := c.do(ctx, http.MethodGet, "/team/list", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetTeamSettings(ctx context.Context, teamName string) (*TeamSettingsResponse, error) {
+	settings := new(TeamSettingsResponse)
+	query := url.Values{"team_name": {teamName}}
+	if err := c.do(ctx, http.MethodGet, "/team/settings", query, nil, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateTeamSettings меняет заданные поля настроек и возвращает действующие настройки
+func (c *Client) UpdateTeamSettings(ctx context.Context, req UpdateTeamSettingsRequest) (*TeamSettingsResponse, error) {
+	settings := new(TeamSettingsResponse)
+	if err := c.do(ctx, http.MethodPost, "/team/settings/update", nil, req, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (c *Client) SetMemberRole(ctx context.Context, req SetMemberRoleRequest) (*SetMemberRoleResponse, error) {
+	resp := new(SetMemberRoleResponse)
+	if err := c.do(ctx, http.MethodPost, "/team/setRole", nil, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ImportTeams импортирует команды из CSV или YAML. Если файл не прошел проверку, вместе с ErrInvalidRoster
+// возвращается ответ с построчными ошибками.
+func (c *Client) ImportTeams(ctx context.Context, req ImportTeamsRequest, roster io.Reader) (*ImportTeamsResponse, error) {
+	// Тело читается целиком, чтобы его можно было отправить повторно.
+	payload, err := io.ReadAll(roster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roster: %w", err)
+	}
+	contentType := "text/csv"
+	if req.Format == "yaml" {
+		contentType = "application/yaml"
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, "/team/import", queryValues(req), contentType, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusUnprocessableEntity {
+		return nil, decodeError(resp)
+	}
+	result := new(ImportTeamsResponse)
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("failed to decode import response: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		return result, ErrInvalidRoster
+	}
+	return result, nil
+}
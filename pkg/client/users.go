@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) SetUserActive(ctx context.Context, req SetUserActiveRequest) (*SetUserActiveResponse, error) {
+	user := new(SetUserActiveResponse)
+	if err := c.do(ctx, http.MethodPost, "/users/setIsActive", nil, req, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUserReviewRequests получает PR, где пользователь назначен ревьювером
+func (c *Client) GetUserReviewRequests(ctx context.Context, userID string) (*GetUserReviewRequestsResponse, error) {
+	resp := new(GetUserReviewRequestsResponse)
+	query := url.Values{"user_id": {userID}}
+	if err := c.do(ctx, http.MethodGet, "/users/getReview", query, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MoveUserToTeam переводит пользователя в другую команду
+func (c *Client) MoveUserToTeam(ctx context.Context, req MoveTeamRequest) (*MoveTeamResponse, error) {
+	resp := new(MoveTeamResponse)
+	if err := c.do(ctx, http.MethodPost, "/users/moveTeam", nil, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetUser(ctx context.Context, userID string) (*UserResponse, error) {
+	user := new(UserResponse)
+	query := url.Values{"user_id": {userID}}
+	if err := c.do(ctx, http.MethodGet, "/users/get", query, nil, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (c *Client) ListUsers(ctx context.Context, req ListUsersRequest) (*ListUsersResponse, error) {
+	resp := new(ListUsersResponse)
+	if err := c.do(ctx, http.MethodGet, "/users/list", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateUser меняет заданные поля профиля
+func (c *Client) UpdateUser(ctx context.Context, req UpdateUserRequest) (*UserResponse, error) {
+	user := new(UserResponse)
+	if err := c.do(ctx, http.MethodPatch, "/users/update", nil, req, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser удаляет пользователя. Нужен токен администратора (WithToken)
+func (c *Client) DeleteUser(ctx context.Context, req DeleteUserRequest) (*DeleteUserResponse, error) {
+	resp := new(DeleteUserResponse)
+	if err := c.do(ctx, http.MethodDelete, "/users/delete", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EraseUser удаляет персональные данные пользователя, оставляя псевдоним в истории PR. Нужен токен администратора (WithToken)
+func (c *Client) EraseUser(ctx context.Context, req EraseUserRequest) (*EraseUserResponse, error) {
+	resp := new(EraseUserResponse)
+	if err := c.do(ctx, http.MethodPost, "/users/erase", nil, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetReviewHistory(ctx context.Context, req ReviewHistoryRequest) (*ReviewHistoryResponse, error) {
+	resp := new(ReviewHistoryResponse)
+	if err := c.do(ctx, http.MethodGet, "/users/reviewHistory", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetDashboard(ctx context.Context, req DashboardRequest) (*DashboardResponse, error) {
+	resp := new(DashboardResponse)
+	if err := c.do(ctx, http.MethodGet, "/users/dashboard", queryValues(req), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}